@@ -0,0 +1,263 @@
+package kgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+)
+
+// ErrPrecisionLoss 数值转换丢失精度,如小数转整数、大整数转浮点数
+var ErrPrecisionLoss = errors.New("[Number]`precision loss")
+
+// AddInt64 检查溢出的整数加法,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) AddInt64(a, b int64) (int64, error) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, ErrNumberOverflow
+	}
+	return c, nil
+}
+
+// SubInt64 检查溢出的整数减法,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) SubInt64(a, b int64) (int64, error) {
+	c := a - b
+	if (c < a) != (b > 0) {
+		return 0, ErrNumberOverflow
+	}
+	return c, nil
+}
+
+// MulInt64 检查溢出的整数乘法,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) MulInt64(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	c := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || c/b != a {
+		return 0, ErrNumberOverflow
+	}
+	return c, nil
+}
+
+// SumInt64 检查溢出的整数求和,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) SumInt64(nums ...int64) (res int64, err error) {
+	for _, v := range nums {
+		if res, err = kn.AddInt64(res, v); err != nil {
+			return 0, err
+		}
+	}
+	return
+}
+
+// AddUint64 检查溢出的无符号整数加法,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) AddUint64(a, b uint64) (uint64, error) {
+	c, carry := bits.Add64(a, b, 0)
+	if carry != 0 {
+		return 0, ErrNumberOverflow
+	}
+	return c, nil
+}
+
+// SubUint64 检查溢出的无符号整数减法,结果为负时返回 ErrNumberOverflow .
+func (kn *LkkNumber) SubUint64(a, b uint64) (uint64, error) {
+	c, borrow := bits.Sub64(a, b, 0)
+	if borrow != 0 {
+		return 0, ErrNumberOverflow
+	}
+	return c, nil
+}
+
+// MulUint64 检查溢出的无符号整数乘法,溢出时返回 ErrNumberOverflow .
+func (kn *LkkNumber) MulUint64(a, b uint64) (uint64, error) {
+	hi, lo := bits.Mul64(a, b)
+	if hi != 0 {
+		return 0, ErrNumberOverflow
+	}
+	return lo, nil
+}
+
+// SaturatingAddInt64 饱和整数加法,溢出时返回math.MaxInt64或math.MinInt64.
+func (kn *LkkNumber) SaturatingAddInt64(a, b int64) int64 {
+	res, err := kn.AddInt64(a, b)
+	if err != nil {
+		if b > 0 {
+			return math.MaxInt64
+		}
+		return math.MinInt64
+	}
+	return res
+}
+
+// SaturatingSubInt64 饱和整数减法,溢出时返回math.MaxInt64或math.MinInt64.
+func (kn *LkkNumber) SaturatingSubInt64(a, b int64) int64 {
+	res, err := kn.SubInt64(a, b)
+	if err != nil {
+		if b < 0 {
+			return math.MaxInt64
+		}
+		return math.MinInt64
+	}
+	return res
+}
+
+// SaturatingMulInt64 饱和整数乘法,溢出时返回math.MaxInt64或math.MinInt64.
+func (kn *LkkNumber) SaturatingMulInt64(a, b int64) int64 {
+	res, err := kn.MulInt64(a, b)
+	if err != nil {
+		if (a < 0) != (b < 0) {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+	return res
+}
+
+// SaturatingAddUint64 饱和无符号整数加法,溢出时返回math.MaxUint64.
+func (kn *LkkNumber) SaturatingAddUint64(a, b uint64) uint64 {
+	res, err := kn.AddUint64(a, b)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return res
+}
+
+// SaturatingSubUint64 饱和无符号整数减法,结果为负时返回0.
+func (kn *LkkNumber) SaturatingSubUint64(a, b uint64) uint64 {
+	res, err := kn.SubUint64(a, b)
+	if err != nil {
+		return 0
+	}
+	return res
+}
+
+// SaturatingMulUint64 饱和无符号整数乘法,溢出时返回math.MaxUint64.
+func (kn *LkkNumber) SaturatingMulUint64(a, b uint64) uint64 {
+	res, err := kn.MulUint64(a, b)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return res
+}
+
+// ConvertNumber 将数值src转换并写入dst,dst须为指向数值类型的指针,如*int8、*uint32、*float32.
+// src可以为任意整数、浮点数或数值字符串;超出dst类型范围时返回 ErrNumberOverflow ,
+// 丢失精度(如小数部分、浮点数无法精确表示的大整数)时返回 ErrPrecisionLoss ,可用errors.Is判断;出错时不修改dst.
+func (kn *LkkNumber) ConvertNumber(src interface{}, dst interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return errors.New("[ConvertNumber]`dst must be a non-nil pointer")
+	}
+	dv = dv.Elem()
+
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.String {
+		str := sv.String()
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			sv = reflect.ValueOf(i)
+		} else if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			sv = reflect.ValueOf(u)
+		} else if f, err := strconv.ParseFloat(str, 64); err == nil {
+			sv = reflect.ValueOf(f)
+		} else {
+			return fmt.Errorf("[ConvertNumber]`invalid number: %q", str)
+		}
+	}
+
+	fail := func(err error) error {
+		return fmt.Errorf("[ConvertNumber]`%v to %s: %w", src, dv.Type(), err)
+	}
+
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := sv.Int()
+		switch dv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dv.OverflowInt(i) {
+				return fail(ErrNumberOverflow)
+			}
+			dv.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if i < 0 || dv.OverflowUint(uint64(i)) {
+				return fail(ErrNumberOverflow)
+			}
+			dv.SetUint(uint64(i))
+		case reflect.Float32, reflect.Float64:
+			f := float64(i)
+			if dv.Kind() == reflect.Float32 {
+				f = float64(float32(f))
+			}
+			//2^63无法转回int64
+			if f >= math.MaxInt64 || int64(f) != i {
+				return fail(ErrPrecisionLoss)
+			}
+			dv.SetFloat(f)
+		default:
+			return fail(errors.New("unsupported dst type"))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := sv.Uint()
+		switch dv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if u > math.MaxInt64 || dv.OverflowInt(int64(u)) {
+				return fail(ErrNumberOverflow)
+			}
+			dv.SetInt(int64(u))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if dv.OverflowUint(u) {
+				return fail(ErrNumberOverflow)
+			}
+			dv.SetUint(u)
+		case reflect.Float32, reflect.Float64:
+			f := float64(u)
+			if dv.Kind() == reflect.Float32 {
+				f = float64(float32(f))
+			}
+			//2^64无法转回uint64
+			if f >= math.MaxUint64 || uint64(f) != u {
+				return fail(ErrPrecisionLoss)
+			}
+			dv.SetFloat(f)
+		default:
+			return fail(errors.New("unsupported dst type"))
+		}
+	case reflect.Float32, reflect.Float64:
+		f := sv.Float()
+		if math.IsNaN(f) && dv.Kind() != reflect.Float32 && dv.Kind() != reflect.Float64 {
+			return fail(ErrPrecisionLoss)
+		}
+
+		switch dv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 || dv.OverflowInt(int64(f)) {
+				return fail(ErrNumberOverflow)
+			} else if f != math.Trunc(f) {
+				return fail(ErrPrecisionLoss)
+			}
+			dv.SetInt(int64(f))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if math.IsInf(f, 0) || f < 0 || f >= math.MaxUint64 || dv.OverflowUint(uint64(f)) {
+				return fail(ErrNumberOverflow)
+			} else if f != math.Trunc(f) {
+				return fail(ErrPrecisionLoss)
+			}
+			dv.SetUint(uint64(f))
+		case reflect.Float32, reflect.Float64:
+			if !math.IsInf(f, 0) && dv.OverflowFloat(f) {
+				return fail(ErrNumberOverflow)
+			} else if dv.Kind() == reflect.Float32 && !math.IsNaN(f) && float64(float32(f)) != f {
+				return fail(ErrPrecisionLoss)
+			}
+			dv.SetFloat(f)
+		default:
+			return fail(errors.New("unsupported dst type"))
+		}
+	default:
+		return fmt.Errorf("[ConvertNumber]`unsupported src type: %T", src)
+	}
+
+	return nil
+}
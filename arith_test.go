@@ -0,0 +1,294 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArith_AddInt64(t *testing.T) {
+	var res int64
+	var err error
+
+	res, err = KNum.AddInt64(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res)
+
+	res, err = KNum.AddInt64(math.MaxInt64, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64-1), res)
+
+	_, err = KNum.AddInt64(math.MaxInt64, 1)
+	assert.Equal(t, ErrNumberOverflow, err)
+	_, err = KNum.AddInt64(math.MinInt64, -1)
+	assert.Equal(t, ErrNumberOverflow, err)
+}
+
+func BenchmarkArith_AddInt64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.AddInt64(int64(i), 100)
+	}
+}
+
+func TestArith_SubInt64(t *testing.T) {
+	var res int64
+	var err error
+
+	res, err = KNum.SubInt64(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), res)
+
+	res, err = KNum.SubInt64(-1, math.MaxInt64)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MinInt64), res)
+
+	_, err = KNum.SubInt64(math.MinInt64, 1)
+	assert.Equal(t, ErrNumberOverflow, err)
+	_, err = KNum.SubInt64(0, math.MinInt64)
+	assert.Equal(t, ErrNumberOverflow, err)
+}
+
+func BenchmarkArith_SubInt64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.SubInt64(int64(i), 100)
+	}
+}
+
+func TestArith_MulInt64(t *testing.T) {
+	var res int64
+	var err error
+
+	res, err = KNum.MulInt64(-3, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-12), res)
+
+	res, err = KNum.MulInt64(0, math.MinInt64)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res)
+
+	res, err = KNum.MulInt64(math.MinInt64, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MinInt64), res)
+
+	_, err = KNum.MulInt64(math.MinInt64, -1)
+	assert.Equal(t, ErrNumberOverflow, err)
+	_, err = KNum.MulInt64(-1, math.MinInt64)
+	assert.Equal(t, ErrNumberOverflow, err)
+	_, err = KNum.MulInt64(1<<32, 1<<31)
+	assert.Equal(t, ErrNumberOverflow, err)
+}
+
+func BenchmarkArith_MulInt64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MulInt64(int64(i), 100)
+	}
+}
+
+func TestArith_SumInt64(t *testing.T) {
+	var res int64
+	var err error
+
+	res, err = KNum.SumInt64()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res)
+
+	res, err = KNum.SumInt64(1, 2, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), res)
+
+	_, err = KNum.SumInt64(math.MaxInt64, 1, -2)
+	assert.Equal(t, ErrNumberOverflow, err)
+}
+
+func BenchmarkArith_SumInt64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.SumInt64(1, 2, 3, 4, 5)
+	}
+}
+
+func TestArith_Uint64(t *testing.T) {
+	var res uint64
+	var err error
+
+	res, err = KNum.AddUint64(1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), res)
+	_, err = KNum.AddUint64(math.MaxUint64, 1)
+	assert.Equal(t, ErrNumberOverflow, err)
+
+	res, err = KNum.SubUint64(3, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), res)
+	_, err = KNum.SubUint64(2, 3)
+	assert.Equal(t, ErrNumberOverflow, err)
+
+	res, err = KNum.MulUint64(1<<32, 1<<31)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<63), res)
+	_, err = KNum.MulUint64(1<<32, 1<<32)
+	assert.Equal(t, ErrNumberOverflow, err)
+}
+
+func BenchmarkArith_MulUint64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MulUint64(uint64(i), 100)
+	}
+}
+
+func TestArith_Saturating(t *testing.T) {
+	assert.Equal(t, int64(3), KNum.SaturatingAddInt64(1, 2))
+	assert.Equal(t, int64(math.MaxInt64), KNum.SaturatingAddInt64(math.MaxInt64, 1))
+	assert.Equal(t, int64(math.MinInt64), KNum.SaturatingAddInt64(math.MinInt64, -1))
+
+	assert.Equal(t, int64(-1), KNum.SaturatingSubInt64(1, 2))
+	assert.Equal(t, int64(math.MaxInt64), KNum.SaturatingSubInt64(0, math.MinInt64))
+	assert.Equal(t, int64(math.MinInt64), KNum.SaturatingSubInt64(math.MinInt64, 1))
+
+	assert.Equal(t, int64(-6), KNum.SaturatingMulInt64(2, -3))
+	assert.Equal(t, int64(math.MaxInt64), KNum.SaturatingMulInt64(math.MinInt64, -1))
+	assert.Equal(t, int64(math.MinInt64), KNum.SaturatingMulInt64(math.MaxInt64, -2))
+
+	assert.Equal(t, uint64(math.MaxUint64), KNum.SaturatingAddUint64(math.MaxUint64, 1))
+	assert.Equal(t, uint64(0), KNum.SaturatingSubUint64(1, 2))
+	assert.Equal(t, uint64(1), KNum.SaturatingSubUint64(3, 2))
+	assert.Equal(t, uint64(math.MaxUint64), KNum.SaturatingMulUint64(1<<32, 1<<32))
+	assert.Equal(t, uint64(6), KNum.SaturatingMulUint64(2, 3))
+}
+
+func BenchmarkArith_SaturatingAddInt64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.SaturatingAddInt64(math.MaxInt64, int64(i))
+	}
+}
+
+func TestArith_ConvertNumber(t *testing.T) {
+	var err error
+	var i8 int8
+	var i64 int64
+	var u8 uint8
+	var u64 uint64
+	var f32 float32
+	var f64 float64
+
+	//整数
+	err = KNum.ConvertNumber(127, &i8)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(127), i8)
+
+	err = KNum.ConvertNumber(128, &i8)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	assert.Equal(t, int8(127), i8)
+
+	err = KNum.ConvertNumber(-1, &u8)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(uint64(math.MaxUint64), &i64)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(uint64(math.MaxUint64), &u64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+
+	err = KNum.ConvertNumber(uint16(200), &u8)
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(200), u8)
+
+	//整数转浮点
+	err = KNum.ConvertNumber(1<<53, &f64)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1<<53), f64)
+
+	err = KNum.ConvertNumber(1<<53+1, &f64)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = KNum.ConvertNumber(1<<24+1, &f32)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = KNum.ConvertNumber(int64(math.MaxInt64), &f64)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = KNum.ConvertNumber(uint64(math.MaxUint64), &f64)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	//浮点转整数
+	err = KNum.ConvertNumber(3.0, &i8)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(3), i8)
+
+	err = KNum.ConvertNumber(3.5, &i8)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = KNum.ConvertNumber(-3.0, &u64)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(1e19, &i64)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(1e19, &u64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1e19), u64)
+
+	err = KNum.ConvertNumber(math.Inf(1), &i64)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(math.NaN(), &i64)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	//浮点转浮点
+	err = KNum.ConvertNumber(0.5, &f32)
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0.5), f32)
+
+	err = KNum.ConvertNumber(0.1, &f32)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = KNum.ConvertNumber(1e300, &f32)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	err = KNum.ConvertNumber(float32(0.1), &f64)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(float32(0.1)), f64)
+
+	//字符串
+	err = KNum.ConvertNumber("-12", &i8)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(-12), i8)
+
+	err = KNum.ConvertNumber("18446744073709551615", &u64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+
+	err = KNum.ConvertNumber("2.5", &f64)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.5, f64)
+
+	err = KNum.ConvertNumber("abc", &f64)
+	assert.NotNil(t, err)
+
+	//参数错误
+	err = KNum.ConvertNumber(1, i8)
+	assert.NotNil(t, err)
+	err = KNum.ConvertNumber(1, (*int8)(nil))
+	assert.NotNil(t, err)
+	err = KNum.ConvertNumber(true, &i8)
+	assert.NotNil(t, err)
+	var str string
+	err = KNum.ConvertNumber(1, &str)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkArith_ConvertNumber(b *testing.B) {
+	var i8 int8
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KNum.ConvertNumber(100, &i8)
+	}
+}
@@ -4,12 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"math"
-	"math/rand"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // ArrayKeys 返回数组(切片/字典/结构体)中所有的键名;如果是结构体,只返回公开的字段.
@@ -753,7 +751,7 @@ func (ka *LkkArray) ArrayShuffle(arr interface{}) []interface{} {
 		res[i] = val.Index(i).Interface()
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := ka.rnd()
 	r.Shuffle(num, func(i, j int) {
 		res[i], res[j] = res[j], res[i]
 	})
@@ -1105,7 +1103,7 @@ func (ka *LkkArray) ArrayRand(arr interface{}, num int) []interface{} {
 		num = length
 	}
 	res := make([]interface{}, num)
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := ka.rnd()
 
 	switch typ {
 	case reflect.Array, reflect.Slice:
@@ -1175,7 +1173,7 @@ func (ka *LkkArray) NewStrMapStr() map[string]string {
 
 // CopyStruct 将resources的值拷贝到dest目标结构体;
 // 要求dest必须是结构体指针,resources为多个源结构体;若resources存在多个相同字段的元素,结果以最后的为准;
-// 只简单核对字段名,无错误处理,需开发自行检查dest和resources字段类型才可操作.
+// 只简单核对字段名,类型无法转换的字段将被跳过;需要标签映射、补丁语义、深拷贝或类型转换时请使用 CopyStructWith .
 func (ka *LkkArray) CopyStruct(dest interface{}, resources ...interface{}) interface{} {
 	dVal := reflect.ValueOf(dest)
 	dTyp := reflect.TypeOf(dest)
@@ -1210,7 +1208,18 @@ func (ka *LkkArray) CopyStruct(dest interface{}, resources ...interface{}) inter
 			for i := 0; i < rTyp.NumField(); i++ {
 				field = rTyp.Field(i).Name
 				if typ, ok := dFields[field]; ok {
-					dVal.FieldByName(field).Set(rVal.FieldByName(field).Convert(typ))
+					//跳过无法转换的类型,以及整数转字符串(会变成字符)
+					sf := rVal.Field(i)
+					if !sf.Type().ConvertibleTo(typ) {
+						continue
+					} else if typ.Kind() == reflect.String {
+						switch sf.Kind() {
+						case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+							continue
+						}
+					}
+					dVal.FieldByName(field).Set(sf.Convert(typ))
 				}
 			}
 		}
@@ -1360,6 +1360,25 @@ func TestArray_CopyStruct(t *testing.T) {
 	assert.Equal(t, user.Addr, personS5.Addr)
 	assert.Equal(t, user.Age, personS5.Age)
 	assert.Equal(t, user.Gender, personS5.Gender)
+
+	//类型无法转换的字段跳过
+	type badAccount struct {
+		ID       []int
+		Nickname int
+	}
+	acc3 := &userAccountJson{Nickname: "keep"}
+	res = KArr.CopyStruct(acc3, badAccount{ID: []int{1}, Nickname: 65})
+	assert.Equal(t, "keep", res.(*userAccountJson).Nickname)
+
+	//[]byte和[]rune仍可转为字符串
+	type bytesAccount struct {
+		Nickname []byte
+		Avatar   []rune
+	}
+	acc4 := &userAccountJson{}
+	res = KArr.CopyStruct(acc4, bytesAccount{Nickname: []byte("bytes"), Avatar: []rune("头像")})
+	assert.Equal(t, "bytes", res.(*userAccountJson).Nickname)
+	assert.Equal(t, "头像", res.(*userAccountJson).Avatar)
 }
 
 func BenchmarkLkkArray_CopyStruct(b *testing.B) {
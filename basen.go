@@ -0,0 +1,484 @@
+package kgo
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// BaseEncoding 基于自定义字母表的N进制编码,可编码任意大的整数和字节切片;
+// 字母表中的字母若没有大小写重复,则解码时不区分大小写.
+type BaseEncoding struct {
+	alphabet  string
+	base      *big.Int
+	decodeMap [256]int16
+}
+
+// Hashids 兼容hashids算法的整数ID混淆器,将整数ID可逆地编码为短字符串,如12345编码为"NkK9".
+type Hashids struct {
+	salt      string
+	minLength int
+	alphabet  string
+	seps      string
+	guards    string
+}
+
+const (
+	// ALPHABET_BASE58 比特币Base58字母表,去掉了易混淆的0OIl
+	ALPHABET_BASE58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// ALPHABET_BASE62 Base62字母表
+	ALPHABET_BASE62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// ALPHABET_BASE36 Base36字母表
+	ALPHABET_BASE36 = "0123456789abcdefghijklmnopqrstuvwxyz"
+	// ALPHABET_BASE32_CROCKFORD Crockford Base32字母表,去掉了ILOU
+	ALPHABET_BASE32_CROCKFORD = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// ALPHABET_HASHIDS hashids默认字母表
+	ALPHABET_HASHIDS = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+
+	// hashidsSeps hashids默认分隔符
+	hashidsSeps = "cfhistuCFHISTU"
+	// decodeSkip 解码时忽略的字符
+	decodeSkip = -2
+)
+
+var (
+	// Base58Encoding 比特币Base58编码
+	Base58Encoding = mustBaseEncoding(ALPHABET_BASE58)
+	// Base62Encoding Base62编码
+	Base62Encoding = mustBaseEncoding(ALPHABET_BASE62)
+	// Base36Encoding Base36编码,解码不区分大小写
+	Base36Encoding = mustBaseEncoding(ALPHABET_BASE36)
+	// Base32CrockfordEncoding Crockford Base32整数编码,解码不区分大小写,I/L视为1,O视为0,忽略连字符
+	Base32CrockfordEncoding = newCrockfordEncoding()
+
+	// base32Crockford Crockford字母表的字节Base32编码,无填充
+	base32Crockford = base32.NewEncoding(ALPHABET_BASE32_CROCKFORD).WithPadding(base32.NoPadding)
+)
+
+// NewBaseEncoding 创建N进制编码,alphabet为ASCII字母表,长度至少为2且不能有重复字符.
+func NewBaseEncoding(alphabet string) (*BaseEncoding, error) {
+	if len(alphabet) < 2 || len(alphabet) > 255 {
+		return nil, errors.New("[NewBaseEncoding]`alphabet length must be between 2 and 255")
+	}
+
+	be := &BaseEncoding{alphabet: alphabet, base: big.NewInt(int64(len(alphabet)))}
+	for i := range be.decodeMap {
+		be.decodeMap[i] = -1
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return nil, errors.New("[NewBaseEncoding]`alphabet must be ascii")
+		} else if be.decodeMap[c] >= 0 {
+			return nil, fmt.Errorf("[NewBaseEncoding]`duplicate character %q in alphabet", c)
+		}
+		be.decodeMap[c] = int16(i)
+	}
+
+	//字母没有大小写重复时,解码不区分大小写
+	if !hasBothCases(alphabet) {
+		for i := 0; i < len(alphabet); i++ {
+			c := alphabet[i]
+			for _, o := range []byte(strings.ToLower(string(c)) + strings.ToUpper(string(c))) {
+				if be.decodeMap[o] < 0 {
+					be.decodeMap[o] = int16(i)
+				}
+			}
+		}
+	}
+
+	return be, nil
+}
+
+// hasBothCases 字母表中是否有字母同时存在大小写.
+func hasBothCases(alphabet string) bool {
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 'a' && c <= 'z' && strings.IndexByte(alphabet, c-'a'+'A') >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// mustBaseEncoding 创建N进制编码,出错时panic.
+func mustBaseEncoding(alphabet string) *BaseEncoding {
+	be, err := NewBaseEncoding(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return be
+}
+
+// newCrockfordEncoding 创建Crockford Base32整数编码.
+func newCrockfordEncoding() *BaseEncoding {
+	be := mustBaseEncoding(ALPHABET_BASE32_CROCKFORD)
+	for _, c := range "iIlL" {
+		be.decodeMap[c] = 1
+	}
+	for _, c := range "oO" {
+		be.decodeMap[c] = 0
+	}
+	be.decodeMap['-'] = decodeSkip
+	return be
+}
+
+// Alphabet 获取字母表.
+func (be *BaseEncoding) Alphabet() string {
+	return be.alphabet
+}
+
+// EncodeUint64 编码无符号整数.
+func (be *BaseEncoding) EncodeUint64(num uint64) string {
+	base := uint64(len(be.alphabet))
+	if num == 0 {
+		return be.alphabet[:1]
+	}
+
+	var buf [64]byte
+	i := len(buf)
+	for num > 0 {
+		i--
+		buf[i] = be.alphabet[num%base]
+		num /= base
+	}
+	return string(buf[i:])
+}
+
+// DecodeUint64 解码为无符号整数,超出uint64范围时返回 ErrNumberOverflow .
+func (be *BaseEncoding) DecodeUint64(str string) (uint64, error) {
+	if str == "" {
+		return 0, errors.New("[DecodeUint64]`str is empty")
+	}
+
+	base := uint64(len(be.alphabet))
+	var res uint64
+	for i := 0; i < len(str); i++ {
+		d := be.decodeMap[str[i]]
+		if d == decodeSkip {
+			continue
+		} else if d < 0 {
+			return 0, fmt.Errorf("[DecodeUint64]`invalid character %q", str[i])
+		} else if res > (math.MaxUint64-uint64(d))/base {
+			return 0, ErrNumberOverflow
+		}
+		res = res*base + uint64(d)
+	}
+
+	return res, nil
+}
+
+// EncodeBig 编码任意大的非负整数,负数取绝对值.
+func (be *BaseEncoding) EncodeBig(num *big.Int) string {
+	n := new(big.Int).Abs(num)
+	if n.Sign() == 0 {
+		return be.alphabet[:1]
+	}
+
+	var res []byte
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.QuoRem(n, be.base, mod)
+		res = append(res, be.alphabet[mod.Int64()])
+	}
+	reverseBytes(res)
+
+	return string(res)
+}
+
+// DecodeBig 解码为任意大的非负整数.
+func (be *BaseEncoding) DecodeBig(str string) (*big.Int, error) {
+	if str == "" {
+		return nil, errors.New("[DecodeBig]`str is empty")
+	}
+
+	res := new(big.Int)
+	for i := 0; i < len(str); i++ {
+		d := be.decodeMap[str[i]]
+		if d == decodeSkip {
+			continue
+		} else if d < 0 {
+			return nil, fmt.Errorf("[DecodeBig]`invalid character %q", str[i])
+		}
+		res.Mul(res, be.base)
+		res.Add(res, big.NewInt(int64(d)))
+	}
+
+	return res, nil
+}
+
+// EncodeBytes 将字节切片作为大整数编码,前导的0字节编码为字母表首字符(与比特币Base58一致).
+func (be *BaseEncoding) EncodeBytes(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	res := strings.Repeat(be.alphabet[:1], zeros)
+	if zeros < len(data) {
+		res += be.EncodeBig(new(big.Int).SetBytes(data[zeros:]))
+	}
+	return res
+}
+
+// DecodeBytes 解码 EncodeBytes 编码的字符串.
+func (be *BaseEncoding) DecodeBytes(str string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(str) && str[zeros] == be.alphabet[0] {
+		zeros++
+	}
+
+	res := make([]byte, zeros)
+	if zeros < len(str) {
+		num, err := be.DecodeBig(str[zeros:])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, num.Bytes()...)
+	}
+	return res, nil
+}
+
+// reverseBytes 反转字节切片.
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// BigBaseConvert 任意精度的进制转换,在2~62进制之间转换任意长度的整数.
+// 大于36进制时,数字依次为0-9a-zA-Z;num可带负号.
+func (kc *LkkConvert) BigBaseConvert(num string, frombase, tobase int) (string, error) {
+	if frombase < 2 || frombase > big.MaxBase || tobase < 2 || tobase > big.MaxBase {
+		return "", fmt.Errorf("[BigBaseConvert]`base must be between 2 and %d", big.MaxBase)
+	}
+
+	n, ok := new(big.Int).SetString(num, frombase)
+	if !ok {
+		return "", fmt.Errorf("[BigBaseConvert]`invalid number %q for base %d", num, frombase)
+	}
+	return n.Text(tobase), nil
+}
+
+// NewHashids 创建hashids整数ID混淆器.
+// salt为盐值,不同盐值生成不同结果;minLength为结果的最小长度;alphabet为可选的字母表,至少16个不重复字符.
+func NewHashids(salt string, minLength int, alphabet ...string) (*Hashids, error) {
+	alpha := ALPHABET_HASHIDS
+	if len(alphabet) > 0 && alphabet[0] != "" {
+		alpha = alphabet[0]
+	}
+
+	//去重
+	var uniq []byte
+	for i := 0; i < len(alpha); i++ {
+		if alpha[i] == ' ' {
+			return nil, errors.New("[NewHashids]`alphabet cannot contain spaces")
+		} else if strings.IndexByte(string(uniq), alpha[i]) < 0 {
+			uniq = append(uniq, alpha[i])
+		}
+	}
+	if len(uniq) < 16 {
+		return nil, errors.New("[NewHashids]`alphabet must contain at least 16 unique characters")
+	} else if minLength < 0 {
+		minLength = 0
+	}
+
+	//分隔符须在字母表中,并从字母表中移除
+	var seps, rest []byte
+	for i := 0; i < len(hashidsSeps); i++ {
+		if strings.IndexByte(string(uniq), hashidsSeps[i]) >= 0 {
+			seps = append(seps, hashidsSeps[i])
+		}
+	}
+	for _, c := range uniq {
+		if strings.IndexByte(string(seps), c) < 0 {
+			rest = append(rest, c)
+		}
+	}
+	hashidsShuffle(seps, salt)
+
+	if len(seps) == 0 || float64(len(rest))/float64(len(seps)) > 3.5 {
+		sepsLen := int(math.Ceil(float64(len(rest)) / 3.5))
+		if sepsLen == 1 {
+			sepsLen = 2
+		}
+		if sepsLen > len(seps) {
+			diff := sepsLen - len(seps)
+			seps = append(seps, rest[:diff]...)
+			rest = rest[diff:]
+		} else {
+			seps = seps[:sepsLen]
+		}
+	}
+	hashidsShuffle(rest, salt)
+
+	var guards []byte
+	guardCount := int(math.Ceil(float64(len(rest)) / 12))
+	if len(rest) < 3 {
+		guards, seps = seps[:guardCount], seps[guardCount:]
+	} else {
+		guards, rest = rest[:guardCount], rest[guardCount:]
+	}
+
+	return &Hashids{
+		salt:      salt,
+		minLength: minLength,
+		alphabet:  string(rest),
+		seps:      string(seps),
+		guards:    string(guards),
+	}, nil
+}
+
+// hashidsShuffle hashids的一致性洗牌.
+func hashidsShuffle(alphabet []byte, salt string) {
+	if salt == "" {
+		return
+	}
+
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		asc := int(salt[v])
+		p += asc
+		j := (asc + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
+
+// hashidsHash 将整数编码为字母表表示.
+func hashidsHash(num int64, alphabet []byte) []byte {
+	var res []byte
+	length := int64(len(alphabet))
+	for {
+		res = append(res, alphabet[num%length])
+		num /= length
+		if num == 0 {
+			break
+		}
+	}
+	reverseBytes(res)
+	return res
+}
+
+// hashidsUnhash 将字母表表示解码为整数.
+func hashidsUnhash(str string, alphabet []byte) (int64, error) {
+	var res int64
+	length := int64(len(alphabet))
+	for i := 0; i < len(str); i++ {
+		pos := strings.IndexByte(string(alphabet), str[i])
+		if pos < 0 {
+			return 0, errors.New("[Hashids.Decode]`invalid hash")
+		} else if res > (math.MaxInt64-int64(pos))/length {
+			return 0, ErrNumberOverflow
+		}
+		res = res*length + int64(pos)
+	}
+	return res, nil
+}
+
+// Encode 将非负整数编码为混淆字符串,可同时编码多个整数.
+func (h *Hashids) Encode(nums ...int64) (string, error) {
+	if len(nums) == 0 {
+		return "", errors.New("[Hashids.Encode]`nums is empty")
+	}
+
+	var numsHash int64
+	for i, n := range nums {
+		if n < 0 {
+			return "", errors.New("[Hashids.Encode]`nums cannot be negative")
+		}
+		numsHash += n % int64(i+100)
+	}
+
+	alphabet := []byte(h.alphabet)
+	lottery := alphabet[numsHash%int64(len(alphabet))]
+	res := []byte{lottery}
+	buf := make([]byte, 0, len(alphabet)+len(h.salt)+1)
+	for i, n := range nums {
+		buf = append(append(append(buf[:0], lottery), h.salt...), alphabet...)
+		hashidsShuffle(alphabet, string(buf[:len(alphabet)]))
+		last := hashidsHash(n, alphabet)
+		res = append(res, last...)
+
+		if i+1 < len(nums) {
+			n %= int64(last[0]) + int64(i)
+			res = append(res, h.seps[n%int64(len(h.seps))])
+		}
+	}
+
+	if len(res) < h.minLength {
+		idx := (numsHash + int64(res[0])) % int64(len(h.guards))
+		res = append([]byte{h.guards[idx]}, res...)
+		if len(res) < h.minLength {
+			idx = (numsHash + int64(res[2])) % int64(len(h.guards))
+			res = append(res, h.guards[idx])
+		}
+	}
+
+	half := len(alphabet) / 2
+	for len(res) < h.minLength {
+		hashidsShuffle(alphabet, string(alphabet))
+		res = append(append(append([]byte{}, alphabet[half:]...), res...), alphabet[:half]...)
+		if excess := len(res) - h.minLength; excess > 0 {
+			res = res[excess/2 : excess/2+h.minLength]
+		}
+	}
+
+	return string(res), nil
+}
+
+// Decode 将 Encode 生成的字符串解码为整数;字符串无效或盐值不匹配时返回错误.
+func (h *Hashids) Decode(hash string) ([]int64, error) {
+	errInvalid := errors.New("[Hashids.Decode]`invalid hash")
+	if hash == "" {
+		return nil, errInvalid
+	}
+
+	//去掉首尾的填充字符
+	breakdown := hash
+	if segs := splitAny(hash, h.guards); len(segs) == 2 || len(segs) == 3 {
+		breakdown = segs[1]
+	} else {
+		breakdown = segs[0]
+	}
+	if breakdown == "" {
+		return nil, errInvalid
+	}
+
+	alphabet := []byte(h.alphabet)
+	lottery := breakdown[0]
+	buf := make([]byte, 0, len(alphabet)+len(h.salt)+1)
+	var res []int64
+	for _, sub := range splitAny(breakdown[1:], h.seps) {
+		buf = append(append(append(buf[:0], lottery), h.salt...), alphabet...)
+		hashidsShuffle(alphabet, string(buf[:len(alphabet)]))
+		num, err := hashidsUnhash(sub, alphabet)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, num)
+	}
+
+	//重新编码校验
+	if check, err := h.Encode(res...); err != nil || check != hash {
+		return nil, errInvalid
+	}
+
+	return res, nil
+}
+
+// splitAny 按chars中的任意字符分割字符串,保留空段.
+func splitAny(str, chars string) []string {
+	var res []string
+	start := 0
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(chars, str[i]) >= 0 {
+			res = append(res, str[start:i])
+			start = i + 1
+		}
+	}
+	return append(res, str[start:])
+}
@@ -0,0 +1,261 @@
+package kgo
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseN_NewBaseEncoding(t *testing.T) {
+	var be *BaseEncoding
+	var err error
+
+	be, err = NewBaseEncoding("01")
+	assert.Nil(t, err)
+	assert.Equal(t, "01", be.Alphabet())
+	assert.Equal(t, "1010", be.EncodeUint64(10))
+
+	_, err = NewBaseEncoding("0")
+	assert.NotNil(t, err)
+	_, err = NewBaseEncoding("0120")
+	assert.NotNil(t, err)
+	_, err = NewBaseEncoding("01你")
+	assert.NotNil(t, err)
+
+	//字母没有大小写重复,解码不区分大小写
+	res, err := Base36Encoding.DecodeUint64("ZZ")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(35*36+35), res)
+
+	//大小写敏感
+	res, err = Base62Encoding.DecodeUint64("a")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(36), res)
+	res, err = Base62Encoding.DecodeUint64("A")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), res)
+}
+
+func BenchmarkBaseN_NewBaseEncoding(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewBaseEncoding(ALPHABET_BASE62)
+	}
+}
+
+func TestBaseN_Uint64(t *testing.T) {
+	var res uint64
+	var err error
+
+	assert.Equal(t, "0", Base62Encoding.EncodeUint64(0))
+	assert.Equal(t, "1c", Base62Encoding.EncodeUint64(100))
+	assert.Equal(t, "LygHa16AHYF", Base62Encoding.EncodeUint64(math.MaxUint64))
+	assert.Equal(t, "3w5e11264sgsf", Base36Encoding.EncodeUint64(math.MaxUint64))
+
+	for _, be := range []*BaseEncoding{Base58Encoding, Base62Encoding, Base36Encoding, Base32CrockfordEncoding} {
+		for _, n := range []uint64{0, 1, 57, 12345678, math.MaxUint64} {
+			res, err = be.DecodeUint64(be.EncodeUint64(n))
+			assert.Nil(t, err)
+			assert.Equal(t, n, res)
+		}
+	}
+
+	_, err = Base62Encoding.DecodeUint64("LygHa16AHYG")
+	assert.Equal(t, ErrNumberOverflow, err)
+	_, err = Base58Encoding.DecodeUint64("0OIl")
+	assert.NotNil(t, err)
+	_, err = Base58Encoding.DecodeUint64("")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBaseN_Uint64(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Base62Encoding.DecodeUint64(Base62Encoding.EncodeUint64(uint64(i)))
+	}
+}
+
+func TestBaseN_Crockford(t *testing.T) {
+	var res uint64
+	var err error
+
+	assert.Equal(t, "Z", Base32CrockfordEncoding.EncodeUint64(31))
+	assert.Equal(t, "10", Base32CrockfordEncoding.EncodeUint64(32))
+
+	res, err = Base32CrockfordEncoding.DecodeUint64("1o")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(32), res)
+
+	res, err = Base32CrockfordEncoding.DecodeUint64("iL-l")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(32*32+32+1), res)
+
+	_, err = Base32CrockfordEncoding.DecodeUint64("U")
+	assert.NotNil(t, err)
+}
+
+func TestBaseN_Big(t *testing.T) {
+	var res *big.Int
+	var err error
+
+	num, _ := new(big.Int).SetString("123456789012345678901234567890123456789", 10)
+	str := Base62Encoding.EncodeBig(num)
+	res, err = Base62Encoding.DecodeBig(str)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, num.Cmp(res))
+
+	assert.Equal(t, str, Base62Encoding.EncodeBig(new(big.Int).Neg(num)))
+	assert.Equal(t, "1", Base58Encoding.EncodeBig(big.NewInt(0)))
+
+	_, err = Base62Encoding.DecodeBig("")
+	assert.NotNil(t, err)
+	_, err = Base62Encoding.DecodeBig("a-b")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBaseN_Big(b *testing.B) {
+	num, _ := new(big.Int).SetString("123456789012345678901234567890123456789", 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Base62Encoding.DecodeBig(Base62Encoding.EncodeBig(num))
+	}
+}
+
+func TestBaseN_Bytes(t *testing.T) {
+	var res []byte
+	var err error
+
+	//比特币Base58测试向量
+	assert.Equal(t, "", Base58Encoding.EncodeBytes(nil))
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", Base58Encoding.EncodeBytes([]byte("Hello World!")))
+	assert.Equal(t, "11233QC4", Base58Encoding.EncodeBytes([]byte{0, 0, 40, 127, 180, 205}))
+
+	res, err = Base58Encoding.DecodeBytes("11233QC4")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 40, 127, 180, 205}, res)
+
+	res, err = Base58Encoding.DecodeBytes("2NEpo7TZRRrLZSi2U")
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello World!", string(res))
+
+	res, err = Base58Encoding.DecodeBytes("")
+	assert.Nil(t, err)
+	assert.Empty(t, res)
+
+	_, err = Base58Encoding.DecodeBytes("1l")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBaseN_Bytes(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Base58Encoding.DecodeBytes(Base58Encoding.EncodeBytes(bytsHello))
+	}
+}
+
+func TestBaseN_BigBaseConvert(t *testing.T) {
+	var res string
+	var err error
+
+	res, err = KConv.BigBaseConvert("ff", 16, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "11111111", res)
+
+	res, err = KConv.BigBaseConvert("-123456789012345678901234567890", 10, 16)
+	assert.Nil(t, err)
+	assert.Equal(t, "-18ee90ff6c373e0ee4e3f0ad2", res)
+
+	res, err = KConv.BigBaseConvert("18ee90ff6c373e0ee4e3f0ad2", 16, 62)
+	assert.Nil(t, err)
+	res, err = KConv.BigBaseConvert(res, 62, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345678901234567890", res)
+
+	_, err = KConv.BigBaseConvert("12", 1, 10)
+	assert.NotNil(t, err)
+	_, err = KConv.BigBaseConvert("12", 10, 63)
+	assert.NotNil(t, err)
+	_, err = KConv.BigBaseConvert("2", 2, 10)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBaseN_BigBaseConvert(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.BigBaseConvert("123456789012345678901234567890", 10, 62)
+	}
+}
+
+func TestBaseN_Hashids(t *testing.T) {
+	var h *Hashids
+	var str string
+	var res []int64
+	var err error
+
+	//hashids官方测试向量
+	h, err = NewHashids("this is my salt", 0)
+	assert.Nil(t, err)
+
+	str, err = h.Encode(12345)
+	assert.Nil(t, err)
+	assert.Equal(t, "NkK9", str)
+
+	str, err = h.Encode(683, 94108, 123, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, "aBMswoO2UB3Sj", str)
+
+	res, err = h.Decode("aBMswoO2UB3Sj")
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{683, 94108, 123, 5}, res)
+
+	h, err = NewHashids("this is my salt", 8)
+	assert.Nil(t, err)
+	str, err = h.Encode(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "gB0NV05e", str)
+
+	res, err = h.Decode(str)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1}, res)
+
+	//往返
+	h, _ = NewHashids("kgo", 12, "0123456789abcdef")
+	for _, n := range []int64{0, 1, 99, 1 << 40, math.MaxInt64} {
+		str, err = h.Encode(n)
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, len(str), 12)
+		res, err = h.Decode(str)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{n}, res)
+	}
+
+	//盐值不同无法解码
+	h2, _ := NewHashids("other", 12, "0123456789abcdef")
+	_, err = h2.Decode(str)
+	assert.NotNil(t, err)
+
+	_, err = h.Encode()
+	assert.NotNil(t, err)
+	_, err = h.Encode(-1)
+	assert.NotNil(t, err)
+	_, err = h.Decode("")
+	assert.NotNil(t, err)
+	_, err = h.Decode("xyz")
+	assert.NotNil(t, err)
+
+	_, err = NewHashids("", 0, "abc")
+	assert.NotNil(t, err)
+	_, err = NewHashids("", 0, "abcdefghijklmnop q")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkBaseN_Hashids(b *testing.B) {
+	h, _ := NewHashids("this is my salt", 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		str, _ := h.Encode(int64(i))
+		_, _ = h.Decode(str)
+	}
+}
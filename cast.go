@@ -0,0 +1,342 @@
+package kgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ToXxxE 为 ToXxx 的错误返回版本:无法转换时返回包含源类型和值的错误,而不是静默返回零值.
+// 支持指针(自动解引用)、命名类型(如 type MyInt int)、json.Number 及 []byte(视为字符串);
+// val为nil或空指针时返回零值且无错误.
+// 错误可用errors.Is判断: ErrConvert 值无法解析, ErrNumberOverflow 超出范围, ErrUnsupportedType 类型不支持.
+
+// ErrConvert 值无法转换,如将"abc"转为整数
+var ErrConvert = errors.New("[Convert]`unable to convert")
+
+// castError 生成转换错误,包含源值的类型和值.
+func castError(val interface{}, to string, err error) error {
+	return fmt.Errorf("%T(%#v) to %s: %w", val, val, to, err)
+}
+
+// castIndirect 解引用指针;val为nil或空指针时返回无效值.
+func castIndirect(val interface{}) reflect.Value {
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// castString 获取字符串或字节切片的字符串值.
+func castString(v reflect.Value) (string, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), true
+		}
+	}
+	return "", false
+}
+
+// ToIntE 将变量转换为整型.
+// 数值类型取整数部分,超出int范围时返回 ErrNumberOverflow ;布尔型的true为1,false为0;
+// 字符串须为十进制整数或浮点数(浮点数取整数部分),以及"true"/"false"等,规则同 Str2Int .
+func (kc *LkkConvert) ToIntE(val interface{}) (int, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return 0, nil
+	}
+
+	var res int64
+	var err error
+	if str, ok := castString(v); ok {
+		switch str {
+		case "true", "TRUE", "True":
+			return 1, nil
+		case "false", "FALSE", "False":
+			return 0, nil
+		}
+
+		res, err = strconv.ParseInt(str, 10, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			//浮点数字符串取整数部分
+			if !RegFloat.MatchString(str) {
+				return 0, castError(val, "int", ErrConvert)
+			}
+			var f float64
+			if f, err = strconv.ParseFloat(str, 64); err == nil {
+				res, err = float2Int64(f)
+			} else if !errors.Is(err, strconv.ErrRange) {
+				return 0, castError(val, "int", ErrConvert)
+			}
+		}
+	} else {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.Bool:
+			res, err = weakInt(v)
+		default:
+			return 0, castError(val, "int", ErrUnsupportedType)
+		}
+	}
+
+	//其余错误均为超出范围
+	if err != nil || int64(int(res)) != res {
+		return 0, castError(val, "int", ErrNumberOverflow)
+	}
+	return int(res), nil
+}
+
+// ToFloatE 将变量转换为64位浮点数.
+// 布尔型的true为1.0,false为0;字符串须为浮点数或"true"/"false"等,超出范围时返回 ErrNumberOverflow .
+func (kc *LkkConvert) ToFloatE(val interface{}) (float64, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return 0, nil
+	}
+
+	if str, ok := castString(v); ok {
+		switch str {
+		case "true", "TRUE", "True":
+			return 1, nil
+		case "false", "FALSE", "False":
+			return 0, nil
+		}
+
+		res, err := strconv.ParseFloat(str, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, castError(val, "float64", ErrNumberOverflow)
+		} else if err != nil {
+			return 0, castError(val, "float64", ErrConvert)
+		}
+		return res, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Bool:
+		return float64(bool2Int(v.Bool())), nil
+	}
+
+	return 0, castError(val, "float64", ErrUnsupportedType)
+}
+
+// ToBoolE 将变量转换为布尔值.
+// 数值类型将检查值是否>0;字符串须为1/0、t/f、true/false等,规则同 Str2Bool .
+func (kc *LkkConvert) ToBoolE(val interface{}) (bool, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return false, nil
+	}
+
+	if str, ok := castString(v); ok {
+		res, err := strconv.ParseBool(str)
+		if err != nil {
+			return false, castError(val, "bool", ErrConvert)
+		}
+		return res, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() > 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() > 0, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float() > 0, nil
+	}
+
+	return false, castError(val, "bool", ErrUnsupportedType)
+}
+
+// ToStrE 将变量转换为字符串.
+// 数值和布尔型的格式同 ToStr ;实现了error或fmt.Stringer接口的使用其返回值;
+// 切片(字节切片除外)、字典、结构体等返回 ErrUnsupportedType .
+func (kc *LkkConvert) ToStrE(val interface{}) (string, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return "", nil
+	}
+
+	if str, ok := castString(v); ok {
+		return str, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	switch o := val.(type) {
+	case error:
+		return o.Error(), nil
+	case fmt.Stringer:
+		return o.String(), nil
+	}
+	if v.CanInterface() {
+		switch o := v.Interface().(type) {
+		case error:
+			return o.Error(), nil
+		case fmt.Stringer:
+			return o.String(), nil
+		}
+	}
+
+	return "", castError(val, "string", ErrUnsupportedType)
+}
+
+// ToTimeE 将变量转换为时间.
+// 整数和浮点数视为Unix秒数;字符串可为Unix秒数或RFC3339、"2006-01-02 15:04:05"等格式(无时区的按本地时区).
+func (kc *LkkConvert) ToTimeE(val interface{}) (time.Time, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return time.Time{}, nil
+	} else if v.Type() == timeType {
+		return v.Interface().(time.Time), nil
+	}
+
+	if str, ok := castString(v); ok {
+		if str == "" {
+			return time.Time{}, castError(val, "time.Time", ErrConvert)
+		}
+		v = reflect.ValueOf(str)
+	}
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return time.Time{}, castError(val, "time.Time", ErrNumberOverflow)
+		}
+		fallthrough
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.String:
+		res, err := weakTime(v)
+		if err != nil {
+			return time.Time{}, castError(val, "time.Time", ErrConvert)
+		}
+		return res, nil
+	}
+
+	return time.Time{}, castError(val, "time.Time", ErrUnsupportedType)
+}
+
+// ToDurationE 将变量转换为时长.
+// 整数和浮点数视为纳秒数;字符串可为纳秒数或"1h30m"、"1.5s"等time.ParseDuration支持的格式.
+func (kc *LkkConvert) ToDurationE(val interface{}) (time.Duration, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return 0, nil
+	}
+
+	if str, ok := castString(v); ok {
+		if str == "" {
+			return 0, castError(val, "time.Duration", ErrConvert)
+		}
+		res, err := weakDuration(reflect.ValueOf(str))
+		if err != nil {
+			return 0, castError(val, "time.Duration", ErrConvert)
+		}
+		return res, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		res, err := weakDuration(v)
+		if err != nil {
+			return 0, castError(val, "time.Duration", ErrNumberOverflow)
+		}
+		return res, nil
+	}
+
+	return 0, castError(val, "time.Duration", ErrUnsupportedType)
+}
+
+// ToSliceE 将数组或切片转换为[]interface{};其他类型返回 ErrUnsupportedType .
+func (kc *LkkConvert) ToSliceE(val interface{}) ([]interface{}, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			res[i] = v.Index(i).Interface()
+		}
+		return res, nil
+	}
+
+	return nil, castError(val, "[]interface{}", ErrUnsupportedType)
+}
+
+// ToStringMapE 将变量转换为键为字符串的字典.
+// 字典的键使用 ToStrE 转换;结构体使用 Struct2Map 转换;字符串和字节切片按JSON对象解析.
+func (kc *LkkConvert) ToStringMapE(val interface{}) (map[string]interface{}, error) {
+	v := castIndirect(val)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if str, ok := castString(v); ok {
+		var res map[string]interface{}
+		if err := json.Unmarshal([]byte(str), &res); err != nil {
+			return nil, castError(val, "map[string]interface{}", ErrConvert)
+		}
+		return res, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		res := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := kc.ToStrE(iter.Key().Interface())
+			if err != nil {
+				return nil, castError(val, "map[string]interface{}", ErrUnsupportedType)
+			}
+			res[key] = iter.Value().Interface()
+		}
+		return res, nil
+	case reflect.Struct:
+		if v.Type() != timeType {
+			return struct2Map(v.Interface(), "")
+		}
+	}
+
+	return nil, castError(val, "map[string]interface{}", ErrUnsupportedType)
+}
@@ -0,0 +1,420 @@
+package kgo
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCastInt int
+type testCastStr string
+
+// castFuzzStr 随机生成类似数值的字符串
+type castFuzzStr string
+
+func (castFuzzStr) Generate(r *rand.Rand, size int) reflect.Value {
+	seeds := []string{"", "0", "-1", "+1", "1.5", "-0.5", "1e3", "1.5e3", "0x10", "NaN", "inf", "true", "True", "false", "t", "F",
+		"9223372036854775807", "9223372036854775808", "-9223372036854775809", "1.5x", " 1", "abc"}
+	if r.Intn(3) == 0 {
+		return reflect.ValueOf(castFuzzStr(seeds[r.Intn(len(seeds))]))
+	}
+
+	const chars = "0123456789.-+eExtTrufalsNI _"
+	b := make([]byte, r.Intn(size%24+1))
+	for i := range b {
+		b[i] = chars[r.Intn(len(chars))]
+	}
+	return reflect.ValueOf(castFuzzStr(b))
+}
+
+func TestCast_ToIntE(t *testing.T) {
+	var res int
+	var err error
+
+	res, err = KConv.ToIntE("0")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res)
+
+	res, err = KConv.ToIntE("abc")
+	assert.True(t, errors.Is(err, ErrConvert))
+	assert.Contains(t, err.Error(), "abc")
+	assert.Contains(t, err.Error(), "string")
+
+	res, err = KConv.ToIntE("")
+	assert.True(t, errors.Is(err, ErrConvert))
+
+	res, err = KConv.ToIntE("-12.9")
+	assert.Nil(t, err)
+	assert.Equal(t, -12, res)
+
+	res, err = KConv.ToIntE("True")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res)
+
+	res, err = KConv.ToIntE("99999999999999999999")
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	res, err = KConv.ToIntE(uint64(math.MaxUint64))
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	res, err = KConv.ToIntE(math.NaN())
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	res, err = KConv.ToIntE(json.Number("42"))
+	assert.Nil(t, err)
+	assert.Equal(t, 42, res)
+
+	res, err = KConv.ToIntE([]byte("7"))
+	assert.Nil(t, err)
+	assert.Equal(t, 7, res)
+
+	num := testCastInt(5)
+	res, err = KConv.ToIntE(&num)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, res)
+
+	res, err = KConv.ToIntE(true)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, res)
+
+	res, err = KConv.ToIntE(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res)
+
+	var np *int
+	res, err = KConv.ToIntE(np)
+	assert.Nil(t, err)
+
+	res, err = KConv.ToIntE([]int{1})
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToIntE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToIntE("123")
+	}
+}
+
+func TestCast_ToFloatE(t *testing.T) {
+	var res float64
+	var err error
+
+	res, err = KConv.ToFloatE("1.25")
+	assert.Nil(t, err)
+	assert.Equal(t, 1.25, res)
+
+	res, err = KConv.ToFloatE("abc")
+	assert.True(t, errors.Is(err, ErrConvert))
+
+	res, err = KConv.ToFloatE("1e400")
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	res, err = KConv.ToFloatE(json.Number("-3.5"))
+	assert.Nil(t, err)
+	assert.Equal(t, -3.5, res)
+
+	res, err = KConv.ToFloatE(uint8(3))
+	assert.Nil(t, err)
+	assert.Equal(t, 3.0, res)
+
+	res, err = KConv.ToFloatE(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, res)
+
+	res, err = KConv.ToFloatE(map[string]int{})
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToFloatE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToFloatE("123.45")
+	}
+}
+
+func TestCast_ToBoolE(t *testing.T) {
+	var res bool
+	var err error
+
+	res, err = KConv.ToBoolE("true")
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	res, err = KConv.ToBoolE("0")
+	assert.Nil(t, err)
+	assert.False(t, res)
+
+	res, err = KConv.ToBoolE("abc")
+	assert.True(t, errors.Is(err, ErrConvert))
+
+	res, err = KConv.ToBoolE(2.5)
+	assert.Nil(t, err)
+	assert.True(t, res)
+
+	res, err = KConv.ToBoolE(-1)
+	assert.Nil(t, err)
+	assert.False(t, res)
+
+	res, err = KConv.ToBoolE(struct{}{})
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToBoolE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToBoolE("true")
+	}
+}
+
+func TestCast_ToStrE(t *testing.T) {
+	var res string
+	var err error
+
+	res, err = KConv.ToStrE(12)
+	assert.Nil(t, err)
+	assert.Equal(t, "12", res)
+
+	res, err = KConv.ToStrE(float32(1.1))
+	assert.Nil(t, err)
+	assert.Equal(t, "1.1", res)
+
+	res, err = KConv.ToStrE([]byte("hello"))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", res)
+
+	str := testCastStr("kgo")
+	res, err = KConv.ToStrE(&str)
+	assert.Nil(t, err)
+	assert.Equal(t, "kgo", res)
+
+	res, err = KConv.ToStrE(errors.New("oops"))
+	assert.Nil(t, err)
+	assert.Equal(t, "oops", res)
+
+	tim := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	res, err = KConv.ToStrE(tim)
+	assert.Nil(t, err)
+	assert.Equal(t, tim.String(), res)
+
+	res, err = KConv.ToStrE([]int{1})
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	assert.Contains(t, err.Error(), "[]int")
+}
+
+func BenchmarkCast_ToStrE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToStrE(123.45)
+	}
+}
+
+func TestCast_ToTimeE(t *testing.T) {
+	var res time.Time
+	var err error
+
+	res, err = KConv.ToTimeE("2021-03-04 05:06:07")
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-03-04 05:06:07", res.Format("2006-01-02 15:04:05"))
+
+	res, err = KConv.ToTimeE("2021-03-04T05:06:07Z")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1614834367), res.Unix())
+
+	res, err = KConv.ToTimeE(int64(1614834367))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1614834367), res.Unix())
+
+	res, err = KConv.ToTimeE(json.Number("1614834367"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1614834367), res.Unix())
+
+	res, err = KConv.ToTimeE(1614834367.5)
+	assert.Nil(t, err)
+	assert.Equal(t, 500*time.Millisecond, time.Duration(res.Nanosecond()))
+
+	now := time.Now()
+	res, err = KConv.ToTimeE(&now)
+	assert.Nil(t, err)
+	assert.True(t, now.Equal(res))
+
+	_, err = KConv.ToTimeE("yesterday")
+	assert.True(t, errors.Is(err, ErrConvert))
+	_, err = KConv.ToTimeE("")
+	assert.True(t, errors.Is(err, ErrConvert))
+	_, err = KConv.ToTimeE(math.Inf(1))
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KConv.ToTimeE(true)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToTimeE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToTimeE("2021-03-04 05:06:07")
+	}
+}
+
+func TestCast_ToDurationE(t *testing.T) {
+	var res time.Duration
+	var err error
+
+	res, err = KConv.ToDurationE("1h30m")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, res)
+
+	res, err = KConv.ToDurationE("1000")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Microsecond, res)
+
+	res, err = KConv.ToDurationE([]byte("1.5s"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1500*time.Millisecond, res)
+
+	res, err = KConv.ToDurationE(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Second, res)
+
+	res, err = KConv.ToDurationE(int32(5))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(5), res)
+
+	_, err = KConv.ToDurationE("abc")
+	assert.True(t, errors.Is(err, ErrConvert))
+	_, err = KConv.ToDurationE(uint64(math.MaxUint64))
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KConv.ToDurationE(false)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToDurationE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToDurationE("1h30m")
+	}
+}
+
+func TestCast_ToSliceE(t *testing.T) {
+	var res []interface{}
+	var err error
+
+	res, err = KConv.ToSliceE([]int{1, 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, res)
+
+	arr := [2]string{"a", "b"}
+	res, err = KConv.ToSliceE(&arr)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, res)
+
+	res, err = KConv.ToSliceE(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+
+	_, err = KConv.ToSliceE("abc")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToSliceE(b *testing.B) {
+	s := []int{1, 2, 3}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToSliceE(s)
+	}
+}
+
+func TestCast_ToStringMapE(t *testing.T) {
+	var res map[string]interface{}
+	var err error
+
+	res, err = KConv.ToStringMapE(map[int]string{1: "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"1": "a"}, res)
+
+	res, err = KConv.ToStringMapE(`{"a":1}`)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, res["a"])
+
+	res, err = KConv.ToStringMapE([]byte(`{"b":true}`))
+	assert.Nil(t, err)
+	assert.Equal(t, true, res["b"])
+
+	res, err = KConv.ToStringMapE(&sPerson{Name: "kgo"})
+	assert.Nil(t, err)
+	assert.Equal(t, "kgo", res["Name"])
+
+	_, err = KConv.ToStringMapE("[1]")
+	assert.True(t, errors.Is(err, ErrConvert))
+	_, err = KConv.ToStringMapE(map[[2]int]int{{1, 2}: 3})
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.ToStringMapE(12)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkCast_ToStringMapE(b *testing.B) {
+	m := map[int]string{1: "a", 2: "b"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToStringMapE(m)
+	}
+}
+
+func TestCast_Lossy(t *testing.T) {
+	cfg := &quick.Config{MaxCount: 5000}
+	sameFloat := func(a, b float64) bool {
+		return a == b || (math.IsNaN(a) && math.IsNaN(b))
+	}
+
+	//转换成功时,结果须与不返回错误的版本一致
+	assert.Nil(t, quick.Check(func(s castFuzzStr) bool {
+		str := string(s)
+		if res, err := KConv.ToIntE(str); err == nil && res != KConv.ToInt(str) {
+			return false
+		}
+		if res, err := KConv.ToFloatE(str); err == nil && !sameFloat(res, KConv.ToFloat(str)) {
+			return false
+		}
+		if res, err := KConv.ToBoolE(str); err == nil && res != KConv.ToBool(str) {
+			return false
+		}
+		if res, err := KConv.ToStrE([]byte(str)); err != nil || res != KConv.ToStr([]byte(str)) {
+			return false
+		}
+		return true
+	}, cfg))
+
+	assert.Nil(t, quick.Check(func(i int64, u uint64, f float64, b bool) bool {
+		for _, v := range []interface{}{i, u, f, float32(f), b, int8(i), uint16(u)} {
+			if res, err := KConv.ToIntE(v); err == nil && res != KConv.ToInt(v) {
+				return false
+			}
+			if res, err := KConv.ToFloatE(v); err != nil || !sameFloat(res, KConv.ToFloat(v)) {
+				return false
+			}
+			if res, err := KConv.ToBoolE(v); err != nil || res != KConv.ToBool(v) {
+				return false
+			}
+			if res, err := KConv.ToStrE(v); err != nil || res != KConv.ToStr(v) {
+				return false
+			}
+		}
+		return true
+	}, cfg))
+
+	//字符串往返
+	assert.Nil(t, quick.Check(func(i int64, f float64) bool {
+		ri, err := KConv.ToIntE(KConv.ToStr(i))
+		if err != nil || int64(ri) != i {
+			return false
+		}
+		rf, err := KConv.ToFloatE(KConv.ToStr(f))
+		return err == nil && rf == f
+	}, cfg))
+}
@@ -0,0 +1,236 @@
+package kgo
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock 时钟接口,用于获取当前时间和定时;测试时可替换为FakeClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker 周期定时器接口.
+type Ticker interface {
+	Chan() <-chan time.Time
+	Stop()
+}
+
+// FakeClock 可手动推进的模拟时钟,定时器仅在Advance/Set时按时间顺序触发;零值可用,当前时间为time.Time{}.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{}
+}
+
+// realClock 系统时钟
+type realClock struct {
+}
+
+// realTicker 系统周期定时器
+type realTicker struct {
+	*time.Ticker
+}
+
+// fakeWaiter 模拟时钟的等待者
+type fakeWaiter struct {
+	until  time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// fakeTicker 模拟时钟的周期定时器
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+// systemClock 默认的系统时钟
+var systemClock Clock = realClock{}
+
+// Now 获取当前时间.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since 获取自t以来经过的时间.
+func (realClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+// Sleep 暂停当前协程d时长.
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// After 等待d时长后向通道发送当前时间.
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewTicker 创建周期为d的定时器.
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// Chan 获取定时器的通道.
+func (rt realTicker) Chan() <-chan time.Time {
+	return rt.C
+}
+
+// NewFakeClock 创建模拟时钟,t为初始时间.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now 获取模拟时钟的当前时间.
+func (fc *FakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+// Since 获取自t以来(模拟时钟)经过的时间.
+func (fc *FakeClock) Since(t time.Time) time.Duration {
+	return fc.Now().Sub(t)
+}
+
+// Sleep 阻塞直至模拟时钟被推进d时长.
+func (fc *FakeClock) Sleep(d time.Duration) {
+	<-fc.After(d)
+}
+
+// After 模拟时钟推进d时长后向通道发送当前时间;d<=0时立即发送.
+func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
+	return fc.addWaiter(d, 0).ch
+}
+
+// NewTicker 创建周期为d的模拟定时器;d<=0时将panic,与time.NewTicker一致.
+func (fc *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("[NewTicker]`non-positive interval for NewTicker")
+	}
+	return &fakeTicker{clock: fc, waiter: fc.addWaiter(d, d)}
+}
+
+// Advance 将模拟时钟推进d时长,并按时间顺序触发到期的定时器.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.Set(fc.Now().Add(d))
+}
+
+// Set 将模拟时钟设置为t(不可回退),并按时间顺序触发到期的定时器.
+func (fc *FakeClock) Set(t time.Time) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if t.Before(fc.now) {
+		return
+	}
+
+	for {
+		sort.SliceStable(fc.waiters, func(i, j int) bool {
+			return fc.waiters[i].until.Before(fc.waiters[j].until)
+		})
+		if len(fc.waiters) == 0 || fc.waiters[0].until.After(t) {
+			break
+		}
+
+		w := fc.waiters[0]
+		fc.now = w.until
+		fireTime(w.ch, w.until)
+		if w.period > 0 {
+			w.until = w.until.Add(w.period)
+		} else {
+			fc.waiters = fc.waiters[1:]
+		}
+	}
+
+	fc.now = t
+	fc.notify()
+}
+
+// Waiters 获取等待中的定时器数量(包括Sleep、After和Ticker).
+func (fc *FakeClock) Waiters() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return len(fc.waiters)
+}
+
+// BlockUntil 阻塞直至等待中的定时器数量达到n,用于同步其他协程中的Sleep.
+func (fc *FakeClock) BlockUntil(n int) {
+	for {
+		fc.mu.Lock()
+		if fc.changed == nil {
+			fc.changed = make(chan struct{})
+		}
+		num, changed := len(fc.waiters), fc.changed
+		fc.mu.Unlock()
+
+		if num >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+// addWaiter 添加等待者.
+func (fc *FakeClock) addWaiter(d, period time.Duration) *fakeWaiter {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	w := &fakeWaiter{until: fc.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	if d <= 0 && period == 0 {
+		fireTime(w.ch, fc.now)
+		return w
+	}
+
+	fc.waiters = append(fc.waiters, w)
+	fc.notify()
+	return w
+}
+
+// removeWaiter 移除等待者.
+func (fc *FakeClock) removeWaiter(w *fakeWaiter) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	for i, v := range fc.waiters {
+		if v == w {
+			fc.waiters = append(fc.waiters[:i], fc.waiters[i+1:]...)
+			break
+		}
+	}
+	fc.notify()
+}
+
+// notify 通知等待者数量已变化,须在持有锁时调用.
+func (fc *FakeClock) notify() {
+	if fc.changed != nil {
+		close(fc.changed)
+	}
+	fc.changed = make(chan struct{})
+}
+
+// fireTime 非阻塞地向通道发送时间,未被读取时丢弃,与time.Ticker一致.
+func fireTime(ch chan time.Time, t time.Time) {
+	select {
+	case ch <- t:
+	default:
+	}
+}
+
+// Chan 获取定时器的通道.
+func (ft *fakeTicker) Chan() <-chan time.Time {
+	return ft.waiter.ch
+}
+
+// Stop 停止定时器.
+func (ft *fakeTicker) Stop() {
+	ft.clock.removeWaiter(ft.waiter)
+}
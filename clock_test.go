@@ -0,0 +1,119 @@
+package kgo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestClock_RealClock(t *testing.T) {
+	var c Clock = realClock{}
+	now := c.Now()
+	assert.False(t, now.IsZero())
+	assert.GreaterOrEqual(t, int64(c.Since(now)), int64(0))
+
+	c.Sleep(time.Microsecond)
+	<-c.After(time.Microsecond)
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.Chan()
+	ticker.Stop()
+}
+
+func TestClock_FakeClockAfter(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := NewFakeClock(start)
+	assert.Equal(t, start, fc.Now())
+
+	ch1 := fc.After(2 * time.Second)
+	ch2 := fc.After(time.Second)
+	assert.Equal(t, 2, fc.Waiters())
+
+	fc.Advance(500 * time.Millisecond)
+	select {
+	case <-ch2:
+		t.Fatal("timer fired too early")
+	default:
+	}
+
+	fc.Advance(2 * time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ch2)
+	assert.Equal(t, start.Add(2*time.Second), <-ch1)
+	assert.Equal(t, 0, fc.Waiters())
+	assert.Equal(t, 2500*time.Millisecond, fc.Since(start))
+
+	//立即触发
+	assert.Equal(t, fc.Now(), <-fc.After(0))
+
+	//不可回退
+	now := fc.Now()
+	fc.Set(start)
+	assert.Equal(t, now, fc.Now())
+}
+
+func BenchmarkClock_FakeClockAfter(b *testing.B) {
+	fc := NewFakeClock(time.Now())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fc.After(time.Second)
+		fc.Advance(time.Second)
+	}
+}
+
+func TestClock_FakeClockTicker(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := NewFakeClock(start)
+	ticker := fc.NewTicker(time.Minute)
+
+	fc.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), <-ticker.Chan())
+
+	//未读取的tick被丢弃
+	fc.Advance(3 * time.Minute)
+	assert.Equal(t, start.Add(2*time.Minute), <-ticker.Chan())
+	select {
+	case <-ticker.Chan():
+		t.Fatal("unexpected tick")
+	default:
+	}
+
+	ticker.Stop()
+	assert.Equal(t, 0, fc.Waiters())
+
+	assert.Panics(t, func() {
+		fc.NewTicker(0)
+	})
+}
+
+func TestClock_FakeClockSleep(t *testing.T) {
+	fc := NewFakeClock(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	done := make(chan struct{})
+	go func() {
+		fc.Sleep(time.Hour)
+		close(done)
+	}()
+
+	fc.BlockUntil(1)
+	fc.Advance(time.Hour)
+	<-done
+}
+
+func TestClock_FakeClockZero(t *testing.T) {
+	var fc FakeClock
+	assert.True(t, fc.Now().IsZero())
+
+	ch := fc.After(time.Minute)
+	assert.Equal(t, 1, fc.Waiters())
+	fc.Advance(time.Minute)
+	assert.Equal(t, time.Time{}.Add(time.Minute), <-ch)
+
+	var fc2 FakeClock
+	done := make(chan struct{})
+	go func() {
+		fc2.Sleep(time.Second)
+		close(done)
+	}()
+	fc2.BlockUntil(1)
+	fc2.Advance(time.Second)
+	<-done
+}
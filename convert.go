@@ -10,9 +10,19 @@ import (
 	"strconv"
 )
 
+// Struct2MapOptions Struct2Map 的选项.
+type Struct2MapOptions struct {
+	Recursive bool   `json:"recursive"` //是否递归转换嵌套的结构体、指针及元素为结构体的切片/字典;实现了encoding.TextMarshaler的值转为字符串
+	Flatten   bool   `json:"flatten"`   //是否将嵌套字典展平为以Separator连接的键,如"addr.city";为true时自动递归
+	Separator string `json:"separator"` //展平时的键分隔符,默认为"."
+	keepTime  bool   //递归时保留time.Time值,不转为字符串,供 TomlEncode 使用
+}
+
 // Struct2Map 结构体转为字典;tagName为要导出的标签名,可以为空,为空时将导出所有字段.
-func (kc *LkkConvert) Struct2Map(obj interface{}, tagName string) (map[string]interface{}, error) {
-	return struct2Map(obj, tagName)
+// 支持标签选项:"-"忽略该字段,omitempty忽略零值字段,inline/squash将嵌套结构体的字段合并到当前层,如`json:"name,omitempty"`;
+// opts为可选的选项,可递归转换嵌套结构体或展平为"addr.city"形式的键,见 Struct2MapOptions .
+func (kc *LkkConvert) Struct2Map(obj interface{}, tagName string, opts ...Struct2MapOptions) (map[string]interface{}, error) {
+	return struct2Map(obj, tagName, opts...)
 }
 
 // Int2Str 将整数转换为字符串.
@@ -1,11 +1,13 @@
 package kgo
 
 import (
+	"errors"
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/stretchr/testify/assert"
 	"math"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestConvert_Struct2Map(t *testing.T) {
@@ -48,6 +50,161 @@ func BenchmarkConvert_Struct2Map_NoTag(b *testing.B) {
 	}
 }
 
+type testS2MLevel int
+
+func (l testS2MLevel) MarshalText() ([]byte, error) {
+	return []byte([]string{"debug", "info"}[l]), nil
+}
+
+type testS2MAddr struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type testS2MMeta struct {
+	Version int `json:"version"`
+}
+
+type testS2MUser struct {
+	Name    string                 `json:"name,omitempty"`
+	Secret  string                 `json:"-"`
+	Level   testS2MLevel           `json:"level"`
+	Created time.Time              `json:"created"`
+	Addr    testS2MAddr            `json:"addr"`
+	Backup  *testS2MAddr           `json:"backup"`
+	History []testS2MAddr          `json:"history"`
+	Tags    []string               `json:"tags"`
+	Extra   map[string]testS2MAddr `json:"extra"`
+	Meta    testS2MMeta            `json:",inline"`
+}
+
+type testS2MNode struct {
+	Name     string                 `json:"name"`
+	Parent   *testS2MNode           `json:"parent"`
+	Children []*testS2MNode         `json:"children"`
+	Attrs    map[string]interface{} `json:"attrs"`
+}
+
+func TestConvert_Struct2Map_Options(t *testing.T) {
+	var res map[string]interface{}
+	var err error
+
+	tim := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	user := testS2MUser{
+		Secret:  "x",
+		Level:   1,
+		Created: tim,
+		Addr:    testS2MAddr{City: "Beijing", Zip: "100000"},
+		History: []testS2MAddr{{City: "Shanghai"}},
+		Tags:    []string{"a"},
+		Extra:   map[string]testS2MAddr{"home": {City: "Hangzhou"}},
+		Meta:    testS2MMeta{Version: 2},
+	}
+
+	//标签选项
+	res, err = KConv.Struct2Map(user, "json")
+	assert.Nil(t, err)
+	_, ok := res["name"]
+	assert.False(t, ok)
+	_, ok = res["Secret"]
+	assert.False(t, ok)
+	assert.Equal(t, int64(2), res["version"])
+	assert.Equal(t, testS2MAddr{City: "Beijing", Zip: "100000"}, res["addr"])
+	assert.Equal(t, tim, res["created"])
+
+	//递归
+	res, err = KConv.Struct2Map(&user, "json", Struct2MapOptions{Recursive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "info", res["level"])
+	assert.Equal(t, "2021-03-04T05:06:07Z", res["created"])
+	assert.Equal(t, map[string]interface{}{"city": "Beijing", "zip": "100000"}, res["addr"])
+	assert.Nil(t, res["backup"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"city": "Shanghai"}}, res["history"])
+	assert.Equal(t, []string{"a"}, res["tags"])
+	assert.Equal(t, map[string]interface{}{"home": map[string]interface{}{"city": "Hangzhou"}}, res["extra"])
+
+	//展平
+	user.Backup = &testS2MAddr{City: "Shenzhen"}
+	res, err = KConv.Struct2Map(user, "json", Struct2MapOptions{Flatten: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "Beijing", res["addr.city"])
+	assert.Equal(t, "Shenzhen", res["backup.city"])
+	assert.Equal(t, "Hangzhou", res["extra.home.city"])
+	assert.Equal(t, int64(2), res["version"])
+	_, ok = res["addr"]
+	assert.False(t, ok)
+
+	res, err = KConv.Struct2Map(user, "json", Struct2MapOptions{Flatten: true, Separator: "_"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Beijing", res["addr_city"])
+
+	//可与Map2Struct互逆
+	var user2 testS2MUser
+	res, _ = KConv.Struct2Map(user, "json", Struct2MapOptions{Recursive: true})
+	res["level"] = 1
+	err = KConv.Map2Struct(res, &user2, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, user.Addr, user2.Addr)
+	assert.Equal(t, user.History, user2.History)
+	assert.Equal(t, user.Backup, user2.Backup)
+	assert.True(t, tim.Equal(user2.Created))
+
+	_, err = KConv.Struct2Map(1, "json", Struct2MapOptions{Recursive: true})
+	assert.NotNil(t, err)
+
+	//循环引用
+	node := &testS2MNode{Name: "self"}
+	node.Parent = node
+	_, err = KConv.Struct2Map(node, "json", Struct2MapOptions{Recursive: true})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	root := &testS2MNode{Name: "root"}
+	root.Children = []*testS2MNode{{Name: "child", Parent: root}}
+	_, err = KConv.Struct2Map(root, "json", Struct2MapOptions{Flatten: true})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	attrs := map[string]interface{}{}
+	attrs["self"] = attrs
+	_, err = KConv.Struct2Map(testS2MNode{Attrs: attrs}, "json", Struct2MapOptions{Recursive: true})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	//同一指针出现多次但无循环
+	leaf := &testS2MNode{Name: "leaf"}
+	res, err = KConv.Struct2Map(testS2MNode{Children: []*testS2MNode{leaf, leaf}}, "json", Struct2MapOptions{Recursive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res["children"].([]interface{})))
+
+	//内联字段的指针被其他字段共用
+	type shared struct {
+		Addr   *testS2MAddr `json:",inline"`
+		Backup *testS2MAddr `json:"backup"`
+		Num    *int         `json:"num,inline"`
+		Count  int          `json:"count"`
+	}
+	num := 3
+	addr := &testS2MAddr{City: "Beijing"}
+	res, err = KConv.Struct2Map(shared{Addr: addr, Backup: addr, Num: &num, Count: 2}, "json", Struct2MapOptions{Recursive: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "Beijing", res["city"])
+	assert.Equal(t, map[string]interface{}{"city": "Beijing"}, res["backup"])
+	assert.Equal(t, int64(3), res["num"])
+
+	//递归与否,数值的类型一致
+	assert.Equal(t, int64(2), res["count"])
+	res, err = KConv.Struct2Map(shared{Count: 2}, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res["count"])
+}
+
+func BenchmarkConvert_Struct2Map_Flatten(b *testing.B) {
+	user := testS2MUser{Addr: testS2MAddr{City: "Beijing"}, History: []testS2MAddr{{City: "Shanghai"}}}
+	opt := Struct2MapOptions{Flatten: true}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Struct2Map(user, "json", opt)
+	}
+}
+
 func TestConvert_Int2Str(t *testing.T) {
 	var res string
 
@@ -0,0 +1,206 @@
+package kgo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// CopyStructOptions CopyStructWith 的选项.
+type CopyStructOptions struct {
+	TagName     string `json:"tagName"`     //按该标签的值匹配源和目标字段,如"json";为空或无标签时按字段名匹配
+	IgnoreEmpty bool   `json:"ignoreEmpty"` //是否忽略源中的零值字段,即补丁语义,只覆盖有值的字段
+	Deep        bool   `json:"deep"`        //是否深拷贝指针、切片、字典等引用类型字段;否则与源共享引用
+}
+
+// deepCopyKey 深拷贝时已访问引用的键,用于处理循环引用
+type deepCopyKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// DeepCopy 深拷贝,递归复制指针、切片、数组、字典、结构体和接口中的值,可处理循环引用;
+// 结构体的未导出字段为浅拷贝,通道和函数不复制.
+func (ka *LkkArray) DeepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return deepCopyValue(reflect.ValueOf(v), make(map[deepCopyKey]reflect.Value)).Interface()
+}
+
+// deepCopyValue 深拷贝反射值,visited记录已复制的引用.
+func deepCopyValue(v reflect.Value, visited map[deepCopyKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := visited[key]; ok {
+			return c
+		}
+
+		c := reflect.New(v.Type().Elem())
+		visited[key] = c
+		c.Elem().Set(deepCopyValue(v.Elem(), visited))
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+		if c, ok := visited[key]; ok {
+			return c
+		}
+
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		visited[key] = c
+		for _, k := range v.MapKeys() {
+			c.SetMapIndex(deepCopyValue(k, visited), deepCopyValue(v.MapIndex(k), visited))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}
+		if c, ok := visited[key]; ok {
+			return c
+		}
+
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		visited[key] = c
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i), visited))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i), visited))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopyValue(v.Field(i), visited))
+			}
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopyValue(v.Elem(), visited))
+		return c
+	}
+
+	return v
+}
+
+// copyStructFields 获取结构体可导出字段的名称及索引;无标签的匿名嵌入结构体展开,标签为"-"的忽略.
+// 返回追加了字段名的names,按字段的声明顺序.
+func copyStructFields(t reflect.Type, tagName string, index []int, res map[string][]int, names []string) []string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Name
+		tagged := false
+		if tagName != "" {
+			if tag := field.Tag.Get(tagName); tag == "-" {
+				continue
+			} else if tag = splitTagName(tag); tag != "" {
+				name, tagged = tag, true
+			}
+		}
+
+		idx := append(append([]int{}, index...), i)
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			names = copyStructFields(field.Type, tagName, idx, res, names)
+		} else if field.PkgPath == "" {
+			if _, ok := res[name]; !ok {
+				res[name] = idx
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// splitTagName 获取标签值中的名称部分,去掉omitempty等选项.
+func splitTagName(tag string) string {
+	for i := 0; i < len(tag); i++ {
+		if tag[i] == ',' {
+			return tag[:i]
+		}
+	}
+	return tag
+}
+
+// CopyStructWith 按选项将resources(结构体或结构体指针)的字段值拷贝到dest结构体指针,后面的源覆盖前面的.
+// 类型相同时直接赋值(Deep为true时深拷贝);类型不同时进行弱类型转换,
+// 如字符串与数值互转、字符串/时间戳转time.Time、结构体转为其他结构体等,规则同 Map2Struct .
+// 返回无法拷贝的字段列表;dest不是结构体指针时返回错误.
+func (ka *LkkArray) CopyStructWith(dest interface{}, opt CopyStructOptions, resources ...interface{}) ([]*FieldError, error) {
+	dVal := reflect.ValueOf(dest)
+	if dVal.Kind() != reflect.Ptr || dVal.IsNil() || dVal.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("[CopyStructWith]`dest must be a non-nil pointer to struct")
+	}
+	dVal = dVal.Elem()
+
+	dFields := make(map[string][]int)
+	copyStructFields(dVal.Type(), opt.TagName, nil, dFields, nil)
+
+	sd := &structDecoder{tagName: opt.TagName}
+	for n, resource := range resources {
+		rVal := reflect.ValueOf(resource)
+		for rVal.Kind() == reflect.Ptr && !rVal.IsNil() {
+			rVal = rVal.Elem()
+		}
+		if rVal.Kind() != reflect.Struct {
+			sd.fail(fmt.Sprintf("resources[%d]", n), fmt.Errorf("%w: %s", ErrUnsupportedType, rVal.Kind()))
+			continue
+		}
+
+		rFields := make(map[string][]int)
+		names := copyStructFields(rVal.Type(), opt.TagName, nil, rFields, nil)
+		for _, name := range names {
+			ridx := rFields[name]
+			didx, ok := dFields[name]
+			if !ok {
+				continue
+			}
+
+			sv := rVal.FieldByIndex(ridx)
+			if opt.IgnoreEmpty && sv.IsZero() {
+				continue
+			}
+
+			dv := dVal.FieldByIndex(didx)
+			if sv.Type().AssignableTo(dv.Type()) {
+				if opt.Deep {
+					sv = deepCopyValue(sv, make(map[deepCopyKey]reflect.Value))
+				}
+				dv.Set(sv)
+				continue
+			}
+
+			//类型不同,结构体先转为字典再解码
+			data := sv.Interface()
+			if iv := reflect.Indirect(sv); iv.Kind() == reflect.Struct && iv.Type() != timeType {
+				mp, err := struct2MapValue(iv, opt.TagName, &Struct2MapOptions{Recursive: true}, nil)
+				if err != nil {
+					sd.fail(name, err)
+					continue
+				}
+				data = mp
+			}
+			sd.decode(name, data, dv)
+		}
+	}
+
+	return sd.errs, nil
+}
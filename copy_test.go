@@ -0,0 +1,194 @@
+package kgo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCopyNode struct {
+	Name     string
+	Next     *testCopyNode
+	Children []*testCopyNode
+	Attrs    map[string]interface{}
+	secret   int
+}
+
+func TestCopy_DeepCopy(t *testing.T) {
+	assert.Nil(t, KArr.DeepCopy(nil))
+	assert.Equal(t, 3, KArr.DeepCopy(3))
+	assert.Equal(t, "kgo", KArr.DeepCopy("kgo"))
+
+	//切片和字典
+	src := map[string][]int{"a": {1, 2}}
+	dst := KArr.DeepCopy(src).(map[string][]int)
+	assert.Equal(t, src, dst)
+	dst["a"][0] = 100
+	assert.Equal(t, 1, src["a"][0])
+
+	arr := [2][]string{{"x"}, {"y"}}
+	arr2 := KArr.DeepCopy(arr).([2][]string)
+	arr2[0][0] = "z"
+	assert.Equal(t, "x", arr[0][0])
+
+	//结构体和指针
+	node := &testCopyNode{Name: "root", Attrs: map[string]interface{}{"tags": []string{"a"}}, secret: 7}
+	node.Children = []*testCopyNode{{Name: "child"}}
+	res := KArr.DeepCopy(node).(*testCopyNode)
+	assert.Equal(t, node, res)
+	assert.NotSame(t, node, res)
+	assert.NotSame(t, node.Children[0], res.Children[0])
+	assert.Equal(t, 7, res.secret)
+	res.Attrs["tags"].([]string)[0] = "b"
+	assert.Equal(t, "a", node.Attrs["tags"].([]string)[0])
+
+	//循环引用
+	node.Next = node
+	node.Children[0].Next = node
+	res = KArr.DeepCopy(node).(*testCopyNode)
+	assert.Same(t, res, res.Next)
+	assert.Same(t, res, res.Children[0].Next)
+	assert.NotSame(t, node, res.Next)
+
+	loop := []interface{}{1, nil}
+	loop[1] = loop
+	res2 := KArr.DeepCopy(loop).([]interface{})
+	assert.Equal(t, 1, res2[0])
+
+	//nil值
+	var np *testCopyNode
+	assert.Nil(t, KArr.DeepCopy(np).(*testCopyNode))
+	var nm map[string]int
+	assert.Nil(t, KArr.DeepCopy(nm).(map[string]int))
+}
+
+func BenchmarkCopy_DeepCopy(b *testing.B) {
+	node := &testCopyNode{Name: "root", Children: []*testCopyNode{{Name: "a"}, {Name: "b"}}, Attrs: map[string]interface{}{"x": 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KArr.DeepCopy(node)
+	}
+}
+
+type testCopyAddr struct {
+	City string `json:"city"`
+}
+
+type testCopySrc struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"user_name"`
+	Age     int            `json:"age"`
+	Score   string         `json:"score"`
+	Created string         `json:"created"`
+	Tags    []string       `json:"tags"`
+	Addr    *testCopyAddr  `json:"addr"`
+	Extra   map[string]int `json:"extra"`
+	Bad     []int          `json:"bad"`
+}
+
+type testCopyDstAddr struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type testCopyDst struct {
+	ID       int             `json:"id"`
+	UserName string          `json:"user_name"`
+	Age      string          `json:"age"`
+	Score    float64         `json:"score"`
+	Created  time.Time       `json:"created"`
+	Tags     []string        `json:"tags"`
+	Addr     testCopyDstAddr `json:"addr"`
+	Extra    map[string]int  `json:"extra"`
+	Bad      bool            `json:"bad"`
+	Keep     string          `json:"keep"`
+}
+
+func TestCopy_CopyStructWith(t *testing.T) {
+	var errs []*FieldError
+	var err error
+
+	src := testCopySrc{
+		ID:      "42",
+		Name:    "kgo",
+		Age:     18,
+		Score:   "9.5",
+		Created: "2021-03-04 05:06:07",
+		Tags:    []string{"a"},
+		Addr:    &testCopyAddr{City: "Beijing"},
+		Extra:   map[string]int{"x": 1},
+		Bad:     []int{1},
+	}
+
+	//按标签映射并转换类型
+	dst := testCopyDst{Keep: "keep"}
+	errs, err = KArr.CopyStructWith(&dst, CopyStructOptions{TagName: "json"}, src)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "bad", errs[0].Field)
+	assert.Equal(t, 42, dst.ID)
+	assert.Equal(t, "kgo", dst.UserName)
+	assert.Equal(t, "18", dst.Age)
+	assert.Equal(t, 9.5, dst.Score)
+	assert.Equal(t, "2021-03-04 05:06:07", dst.Created.Format("2006-01-02 15:04:05"))
+	assert.Equal(t, testCopyDstAddr{City: "Beijing"}, dst.Addr)
+	assert.Equal(t, "keep", dst.Keep)
+
+	//浅拷贝共享引用
+	dst.Tags[0] = "b"
+	assert.Equal(t, "b", src.Tags[0])
+	src.Tags[0] = "a"
+
+	//深拷贝
+	dst = testCopyDst{}
+	_, _ = KArr.CopyStructWith(&dst, CopyStructOptions{TagName: "json", Deep: true}, &src)
+	dst.Tags[0] = "b"
+	dst.Extra["x"] = 2
+	assert.Equal(t, "a", src.Tags[0])
+	assert.Equal(t, 1, src.Extra["x"])
+
+	//补丁语义,忽略零值
+	dst = testCopyDst{UserName: "old", ID: 1}
+	_, _ = KArr.CopyStructWith(&dst, CopyStructOptions{TagName: "json", IgnoreEmpty: true}, testCopySrc{ID: "2"})
+	assert.Equal(t, 2, dst.ID)
+	assert.Equal(t, "old", dst.UserName)
+
+	dst = testCopyDst{UserName: "old"}
+	_, _ = KArr.CopyStructWith(&dst, CopyStructOptions{TagName: "json"}, testCopySrc{ID: "2"})
+	assert.Equal(t, "", dst.UserName)
+
+	//无标签按字段名;多个源后者覆盖前者
+	dst = testCopyDst{}
+	errs, err = KArr.CopyStructWith(&dst, CopyStructOptions{}, testCopySrc{ID: "1", Age: 1}, testCopySrc{ID: "3"}, 123)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, dst.ID)
+	assert.Equal(t, "0", dst.Age)
+	assert.Equal(t, "resources[2]", errs[len(errs)-1].Field)
+	assert.True(t, errors.Is(errs[len(errs)-1], ErrUnsupportedType))
+
+	//失败的字段按声明顺序返回
+	for i := 0; i < 20; i++ {
+		errs, err = KArr.CopyStructWith(&testCopyDst{}, CopyStructOptions{TagName: "json"}, testCopySrc{ID: "x", Score: "y", Bad: []int{1}})
+		assert.Nil(t, err)
+		fields := make([]string, len(errs))
+		for j, fe := range errs {
+			fields[j] = fe.Field
+		}
+		assert.Equal(t, []string{"id", "score", "bad"}, fields)
+	}
+
+	_, err = KArr.CopyStructWith(dst, CopyStructOptions{}, src)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkCopy_CopyStructWith(b *testing.B) {
+	src := testCopySrc{ID: "42", Name: "kgo", Age: 18, Tags: []string{"a"}}
+	opt := CopyStructOptions{TagName: "json", Deep: true}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst testCopyDst
+		_, _ = KArr.CopyStructWith(&dst, opt, src)
+	}
+}
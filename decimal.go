@@ -0,0 +1,350 @@
+package kgo
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal 任意精度的十进制数,值为 value * 10^(-scale);零值表示0.
+// 适用于金额等不能有浮点误差的计算,所有运算都返回新值,不修改原值.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+var (
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+	bigTen  = big.NewInt(10)
+
+	// ErrDecimalDivZero 除数为零
+	ErrDecimalDivZero = errors.New("[Decimal]`division by zero")
+)
+
+// DecimalMaxScale 从字符串解析时允许的最大小数位数(绝对值),防止"1e-2147483647"等输入耗尽CPU和内存
+const DecimalMaxScale = 10000
+
+// NewDecimal 创建十进制数,值为 value * 10^(-scale);如NewDecimal(12345, 2)为123.45 .
+func NewDecimal(value int64, scale int32) Decimal {
+	return newDecimalBig(big.NewInt(value), scale)
+}
+
+// NewDecimalFromInt 从整数创建十进制数.
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{value: big.NewInt(value)}
+}
+
+// NewDecimalFromString 从字符串创建十进制数,支持"-123.45"、"+1"、"1.2e-3"等格式;
+// 指数或结果的小数位数的绝对值超过 DecimalMaxScale 时返回错误.
+func NewDecimalFromString(str string) (Decimal, error) {
+	errInvalid := fmt.Errorf("[NewDecimalFromString]`invalid decimal: %q", str)
+	s := strings.TrimSpace(str)
+
+	var exp int64
+	if pos := strings.IndexAny(s, "eE"); pos >= 0 {
+		e, err := strconv.ParseInt(s[pos+1:], 10, 32)
+		if err != nil || e > DecimalMaxScale || e < -DecimalMaxScale {
+			return Decimal{}, errInvalid
+		}
+		exp = e
+		s = s[:pos]
+	}
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if pos := strings.IndexByte(s, '.'); pos >= 0 {
+		intPart, fracPart = s[:pos], s[pos+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" {
+		return Decimal{}, errInvalid
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, errInvalid
+		}
+	}
+
+	value, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		value.Neg(value)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > DecimalMaxScale || scale < -DecimalMaxScale {
+		return Decimal{}, errInvalid
+	} else if scale < 0 {
+		value.Mul(value, pow10Big(int32(-scale)))
+		scale = 0
+	}
+
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+// NewDecimalFromFloat 从浮点数创建十进制数,取能还原该浮点数的最短十进制表示,如0.1为"0.1".
+func NewDecimalFromFloat(value float64) (Decimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}, fmt.Errorf("[NewDecimalFromFloat]`invalid float: %v", value)
+	}
+	return NewDecimalFromString(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// newDecimalBig 由整数值和小数位数创建十进制数,小数位数为负时转换为整数.
+func newDecimalBig(value *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{value: value.Mul(value, pow10Big(-scale))}
+	}
+	return Decimal{value: value, scale: scale}
+}
+
+// pow10Big 获取10的n次方.
+func pow10Big(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// roundQuo 计算num/den并按模式舍入为整数.
+func roundQuo(num, den *big.Int, mode LkkRoundMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	//结果的符号
+	sign := num.Sign() * den.Sign()
+	//余数的两倍与除数比较,判断是否过半
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch mode {
+	case ROUND_HALF_UP:
+		away = cmp >= 0
+	case ROUND_HALF_EVEN:
+		away = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+	case ROUND_HALF_DOWN:
+		away = cmp > 0
+	case ROUND_UP:
+		away = true
+	case ROUND_DOWN:
+		away = false
+	case ROUND_CEILING:
+		away = sign > 0
+	case ROUND_FLOOR:
+		away = sign < 0
+	}
+
+	if away {
+		if sign > 0 {
+			quo.Add(quo, bigOne)
+		} else {
+			quo.Sub(quo, bigOne)
+		}
+	}
+
+	return quo
+}
+
+// val 获取内部整数值,零值时为0.
+func (d Decimal) val() *big.Int {
+	if d.value == nil {
+		return bigZero
+	}
+	return d.value
+}
+
+// rescale 将小数位数调整为更大的scale(精确,不舍入).
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return new(big.Int).Set(d.val())
+	}
+	return new(big.Int).Mul(d.val(), pow10Big(scale-d.scale))
+}
+
+// Scale 获取小数位数.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign 获取符号,>0为1,<0为-1,0为0.
+func (d Decimal) Sign() int {
+	return d.val().Sign()
+}
+
+// IsZero 是否为0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Neg 取相反数.
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.val()), scale: d.scale}
+}
+
+// Abs 取绝对值.
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.val()), scale: d.scale}
+}
+
+// Add 加法,结果的小数位数为两者中较大的.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	scale := d.scale
+	if d2.scale > scale {
+		scale = d2.scale
+	}
+	return Decimal{value: new(big.Int).Add(d.rescale(scale), d2.rescale(scale)), scale: scale}
+}
+
+// Sub 减法,结果的小数位数为两者中较大的.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	return d.Add(d2.Neg())
+}
+
+// Mul 乘法,结果的小数位数为两者之和.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.val(), d2.val()), scale: d.scale + d2.scale}
+}
+
+// Div 除法,结果保留scale位小数并按mode舍入;除数为0时返回 ErrDecimalDivZero .
+func (d Decimal) Div(d2 Decimal, scale int32, mode LkkRoundMode) (Decimal, error) {
+	if d2.IsZero() {
+		return Decimal{}, ErrDecimalDivZero
+	}
+
+	//d/d2 = (v1*10^(scale+s2)) / (v2*10^s1) * 10^(-scale)
+	num := new(big.Int).Set(d.val())
+	den := new(big.Int).Set(d2.val())
+	exp := int64(scale) + int64(d2.scale) - int64(d.scale)
+	if exp >= 0 {
+		num.Mul(num, pow10Big(int32(exp)))
+	} else {
+		den.Mul(den, pow10Big(int32(-exp)))
+	}
+
+	return newDecimalBig(roundQuo(num, den, mode), scale), nil
+}
+
+// Round 按mode舍入到scale位小数;scale大于当前小数位数时补零,为负数时舍入到十位、百位等.
+func (d Decimal) Round(scale int32, mode LkkRoundMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{value: d.rescale(scale), scale: scale}
+	}
+	return newDecimalBig(roundQuo(d.val(), pow10Big(d.scale-scale), mode), scale)
+}
+
+// Cmp 比较大小,d<d2为-1,d==d2为0,d>d2为1.
+func (d Decimal) Cmp(d2 Decimal) int {
+	scale := d.scale
+	if d2.scale > scale {
+		scale = d2.scale
+	}
+	return d.rescale(scale).Cmp(d2.rescale(scale))
+}
+
+// Equal 是否数值相等(忽略小数位数,如1.0与1.00相等).
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+// GreaterThan 是否大于d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+// LessThan 是否小于d2.
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+// String 转为字符串,保留全部小数位数,如"123.450".
+func (d Decimal) String() string {
+	str := new(big.Int).Abs(d.val()).String()
+	if d.scale > 0 {
+		scale := int(d.scale)
+		if len(str) <= scale {
+			str = strings.Repeat("0", scale-len(str)+1) + str
+		}
+		str = str[:len(str)-scale] + "." + str[len(str)-scale:]
+	}
+	if d.Sign() < 0 {
+		str = "-" + str
+	}
+	return str
+}
+
+// StringFixed 按四舍五入保留places位小数后转为字符串.
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places, ROUND_HALF_UP).String()
+}
+
+// IntPart 获取整数部分(截断).
+func (d Decimal) IntPart() int64 {
+	return d.Round(0, ROUND_DOWN).val().Int64()
+}
+
+// Float64 转为浮点数;exact为转换后能否无损地还原为原数值.
+func (d Decimal) Float64() (res float64, exact bool) {
+	res, _ = strconv.ParseFloat(d.String(), 64)
+	res2, _ := NewDecimalFromFloat(res)
+	exact = res2.Equal(d)
+	return
+}
+
+// MarshalJSON 实现json.Marshaler接口,序列化为字符串,避免精度丢失.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON 实现json.Unmarshaler接口,支持字符串和数值.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		return nil
+	}
+
+	res, err := NewDecimalFromString(strings.Trim(str, `"`))
+	if err != nil {
+		return err
+	}
+	*d = res
+	return nil
+}
+
+// Value 实现driver.Valuer接口,以字符串写入数据库.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan 实现sql.Scanner接口,支持从字符串、字节切片、整数和浮点数读取.
+func (d *Decimal) Scan(value interface{}) (err error) {
+	var res Decimal
+	switch v := value.(type) {
+	case nil:
+		res = Decimal{}
+	case []byte:
+		res, err = NewDecimalFromString(string(v))
+	case string:
+		res, err = NewDecimalFromString(v)
+	case int64:
+		res = NewDecimalFromInt(v)
+	case float64:
+		res, err = NewDecimalFromFloat(v)
+	default:
+		err = fmt.Errorf("[Decimal.Scan]`unsupported type: %T", value)
+	}
+
+	if err == nil {
+		*d = res
+	}
+	return
+}
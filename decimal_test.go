@@ -0,0 +1,236 @@
+package kgo
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestDecimal_NewDecimalFromString(t *testing.T) {
+	var tests = []struct {
+		param    string
+		expected string
+		scale    int32
+		ok       bool
+	}{
+		{"0", "0", 0, true},
+		{"123.4500", "123.4500", 4, true},
+		{"-0.05", "-0.05", 2, true},
+		{"+1", "1", 0, true},
+		{".5", "0.5", 1, true},
+		{"1.2e-3", "0.0012", 4, true},
+		{"1.5E3", "1500", 0, true},
+		{" 98765432109876543210.0123456789 ", "98765432109876543210.0123456789", 10, true},
+		{"", "", 0, false},
+		{".", "", 0, false},
+		{"1.2.3", "", 0, false},
+		{"abc", "", 0, false},
+		{"1e", "", 0, false},
+		{"1e99999999999", "", 0, false},
+		{"1e-10000", "", 10000, true},
+		{"1e10000", "", 0, true},
+		{"1e-2147483647", "", 0, false},
+		{"1e2147483647", "", 0, false},
+		{"1e-10001", "", 0, false},
+		{"0.1e-10000", "", 0, false},
+		{"1e10001", "", 0, false},
+	}
+	for _, test := range tests {
+		res, err := NewDecimalFromString(test.param)
+		if test.ok {
+			assert.Nil(t, err)
+			if test.expected != "" {
+				assert.Equal(t, test.expected, res.String())
+			}
+			assert.Equal(t, test.scale, res.Scale())
+		} else {
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+func BenchmarkDecimal_NewDecimalFromString(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewDecimalFromString("98765432109876543210.0123456789")
+	}
+}
+
+func TestDecimal_NewDecimal(t *testing.T) {
+	assert.Equal(t, "123.45", NewDecimal(12345, 2).String())
+	assert.Equal(t, "1200", NewDecimal(12, -2).String())
+	assert.Equal(t, "-7", NewDecimalFromInt(-7).String())
+	assert.Equal(t, "0", Decimal{}.String())
+
+	res, err := NewDecimalFromFloat(0.1)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.1", res.String())
+
+	_, err = NewDecimalFromFloat(math.NaN())
+	assert.NotNil(t, err)
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, _ := NewDecimalFromString("0.1")
+	b, _ := NewDecimalFromString("0.2")
+	c, _ := NewDecimalFromString("0.3")
+
+	assert.True(t, a.Add(b).Equal(c))
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.1", Decimal{}.Add(a).String())
+
+	res, err := NewDecimalFromInt(10).Div(NewDecimalFromInt(3), 4, ROUND_HALF_UP)
+	assert.Nil(t, err)
+	assert.Equal(t, "3.3333", res.String())
+
+	res, err = NewDecimalFromInt(-2).Div(NewDecimalFromInt(3), 2, ROUND_HALF_UP)
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.67", res.String())
+
+	res, err = NewDecimalFromInt(1234).Div(NewDecimal(1, 3), -2, ROUND_HALF_UP)
+	assert.Nil(t, err)
+	assert.Equal(t, "1234000", res.String())
+
+	_, err = a.Div(Decimal{}, 2, ROUND_HALF_UP)
+	assert.Equal(t, ErrDecimalDivZero, err)
+
+	assert.Equal(t, "0.1", a.Abs().String())
+	assert.Equal(t, "-0.1", a.Neg().String())
+	assert.Equal(t, "0.1", a.Neg().Abs().String())
+}
+
+func BenchmarkDecimal_Div(b *testing.B) {
+	x, y := NewDecimalFromInt(10), NewDecimalFromInt(3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = x.Div(y, 8, ROUND_HALF_EVEN)
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	var tests = []struct {
+		param    string
+		scale    int32
+		mode     LkkRoundMode
+		expected string
+	}{
+		{"2.345", 2, ROUND_HALF_UP, "2.35"},
+		{"-2.345", 2, ROUND_HALF_UP, "-2.35"},
+		{"2.345", 2, ROUND_HALF_EVEN, "2.34"},
+		{"2.355", 2, ROUND_BANKERS, "2.36"},
+		{"2.3451", 2, ROUND_HALF_EVEN, "2.35"},
+		{"2.345", 2, ROUND_HALF_DOWN, "2.34"},
+		{"2.341", 2, ROUND_UP, "2.35"},
+		{"-2.341", 2, ROUND_UP, "-2.35"},
+		{"2.349", 2, ROUND_DOWN, "2.34"},
+		{"-2.341", 2, ROUND_CEILING, "-2.34"},
+		{"2.341", 2, ROUND_CEILING, "2.35"},
+		{"-2.341", 2, ROUND_FLOOR, "-2.35"},
+		{"2.5", 4, ROUND_HALF_UP, "2.5000"},
+		{"1250", -2, ROUND_HALF_EVEN, "1200"},
+	}
+	for _, test := range tests {
+		d, _ := NewDecimalFromString(test.param)
+		assert.Equal(t, test.expected, d.Round(test.scale, test.mode).String())
+	}
+
+	d, _ := NewDecimalFromString("-12.345")
+	assert.Equal(t, "-12.35", d.StringFixed(2))
+	assert.Equal(t, int64(-12), d.IntPart())
+}
+
+func BenchmarkDecimal_Round(b *testing.B) {
+	d, _ := NewDecimalFromString("2.345")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.Round(2, ROUND_HALF_EVEN)
+	}
+}
+
+func TestDecimal_Cmp(t *testing.T) {
+	a, _ := NewDecimalFromString("1.0")
+	b, _ := NewDecimalFromString("1.00")
+	c, _ := NewDecimalFromString("1.01")
+
+	assert.True(t, a.Equal(b))
+	assert.True(t, c.GreaterThan(a))
+	assert.True(t, a.LessThan(c))
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.True(t, Decimal{}.IsZero())
+	assert.Equal(t, -1, c.Neg().Sign())
+}
+
+func TestDecimal_Float64(t *testing.T) {
+	d, _ := NewDecimalFromString("0.25")
+	res, exact := d.Float64()
+	assert.Equal(t, 0.25, res)
+	assert.True(t, exact)
+
+	d, _ = NewDecimalFromString("0.12345678901234567890")
+	_, exact = d.Float64()
+	assert.False(t, exact)
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type invoice struct {
+		Amount Decimal `json:"amount"`
+	}
+
+	d, _ := NewDecimalFromString("1234.50")
+	res, err := json.Marshal(invoice{Amount: d})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"amount":"1234.50"}`, string(res))
+
+	var inv invoice
+	err = json.Unmarshal([]byte(`{"amount":12.3}`), &inv)
+	assert.Nil(t, err)
+	assert.Equal(t, "12.3", inv.Amount.String())
+
+	err = json.Unmarshal([]byte(`{"amount":"0.01"}`), &inv)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.01", inv.Amount.String())
+
+	err = json.Unmarshal([]byte(`{"amount":null}`), &inv)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.01", inv.Amount.String())
+
+	err = json.Unmarshal([]byte(`{"amount":"abc"}`), &inv)
+	assert.NotNil(t, err)
+	err = json.Unmarshal([]byte(`{"amount":1e-2147483647}`), &inv)
+	assert.NotNil(t, err)
+}
+
+func TestDecimal_SQL(t *testing.T) {
+	var d Decimal
+	var err error
+
+	val, err := NewDecimal(12345, 2).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "123.45", val)
+
+	err = d.Scan([]byte("9.99"))
+	assert.Nil(t, err)
+	assert.Equal(t, "9.99", d.String())
+
+	err = d.Scan("1.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "1.5", d.String())
+
+	err = d.Scan(int64(3))
+	assert.Nil(t, err)
+	assert.Equal(t, "3", d.String())
+
+	err = d.Scan(0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.5", d.String())
+
+	err = d.Scan(nil)
+	assert.Nil(t, err)
+	assert.True(t, d.IsZero())
+
+	err = d.Scan(true)
+	assert.NotNil(t, err)
+}
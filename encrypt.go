@@ -12,6 +12,7 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"crypto/x509"
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
@@ -82,6 +83,67 @@ func (ke *LkkEncrypt) Base64UrlDecode(str []byte) ([]byte, error) {
 	return nil, nil
 }
 
+// Base32Encode 使用RFC 4648标准字母表进行Base32编码,结尾以'='填充.
+func (ke *LkkEncrypt) Base32Encode(str []byte) []byte {
+	l := len(str)
+	if l > 0 {
+		buf := make([]byte, base32.StdEncoding.EncodedLen(l))
+		base32.StdEncoding.Encode(buf, str)
+		return buf
+	}
+
+	return nil
+}
+
+// Base32Decode 对RFC 4648标准Base32编码的数据进行解码.
+func (ke *LkkEncrypt) Base32Decode(str []byte) ([]byte, error) {
+	l := len(str)
+	if l > 0 {
+		dbuf := make([]byte, base32.StdEncoding.DecodedLen(l))
+		n, err := base32.StdEncoding.Decode(dbuf, str)
+		return dbuf[:n], err
+	}
+
+	return nil, nil
+}
+
+// Base32CrockfordEncode 使用Crockford字母表进行Base32编码,无填充;适用于ID、序列号等需人工抄录的场景.
+func (ke *LkkEncrypt) Base32CrockfordEncode(str []byte) []byte {
+	l := len(str)
+	if l > 0 {
+		buf := make([]byte, base32Crockford.EncodedLen(l))
+		base32Crockford.Encode(buf, str)
+		return buf
+	}
+
+	return nil
+}
+
+// Base32CrockfordDecode 对Crockford Base32编码的数据进行解码;不区分大小写,I/L视为1,O视为0,忽略连字符'-'.
+func (ke *LkkEncrypt) Base32CrockfordDecode(str []byte) ([]byte, error) {
+	l := len(str)
+	if l > 0 {
+		norm := make([]byte, 0, l)
+		for _, c := range bytes.ToUpper(str) {
+			switch c {
+			case '-':
+				continue
+			case 'I', 'L':
+				c = '1'
+			case 'O':
+				c = '0'
+			}
+			norm = append(norm, c)
+		}
+
+		dbuf := make([]byte, base32Crockford.DecodedLen(len(norm)))
+		n, err := base32Crockford.Decode(dbuf, norm)
+		return dbuf[:n], err
+	}
+
+	return nil, nil
+}
+
 // AuthCode 授权码编码或解码;
 // encode为true时编码,为false解码;
 // expiry为加密时的有效期,单位秒,为0时代表永久(100年);
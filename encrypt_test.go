@@ -89,6 +89,79 @@ func BenchmarkEncrypt_Base64UrlDecode(b *testing.B) {
 	}
 }
 
+func TestEncrypt_Base32Encode(t *testing.T) {
+	var res []byte
+
+	res = KEncr.Base32Encode(bytEmpty)
+	assert.Nil(t, res)
+
+	res = KEncr.Base32Encode([]byte("foobar"))
+	assert.Equal(t, "MZXW6YTBOI======", string(res))
+}
+
+func BenchmarkEncrypt_Base32Encode(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KEncr.Base32Encode(bytsHello)
+	}
+}
+
+func TestEncrypt_Base32Decode(t *testing.T) {
+	var res []byte
+	var err error
+
+	res, err = KEncr.Base32Decode(bytEmpty)
+	assert.Nil(t, res)
+	assert.Nil(t, err)
+
+	res, err = KEncr.Base32Decode([]byte("MZXW6YTBOI======"))
+	assert.Nil(t, err)
+	assert.Equal(t, "foobar", string(res))
+
+	//不合法
+	_, err = KEncr.Base32Decode([]byte("#iu3498r"))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkEncrypt_Base32Decode(b *testing.B) {
+	b.ResetTimer()
+	bs := []byte("MZXW6YTBOI======")
+	for i := 0; i < b.N; i++ {
+		_, _ = KEncr.Base32Decode(bs)
+	}
+}
+
+func TestEncrypt_Base32Crockford(t *testing.T) {
+	var res []byte
+	var err error
+
+	assert.Nil(t, KEncr.Base32CrockfordEncode(bytEmpty))
+	res, err = KEncr.Base32CrockfordDecode(bytEmpty)
+	assert.Nil(t, res)
+	assert.Nil(t, err)
+
+	res = KEncr.Base32CrockfordEncode([]byte("foobar"))
+	assert.Equal(t, "CSQPYRK1E8", string(res))
+
+	res, err = KEncr.Base32CrockfordDecode([]byte("csqp-yrkle8"))
+	assert.Nil(t, err)
+	assert.Equal(t, "foobar", string(res))
+
+	res, err = KEncr.Base32CrockfordDecode(KEncr.Base32CrockfordEncode(bytsHello))
+	assert.Nil(t, err)
+	assert.Equal(t, bytsHello, res)
+
+	_, err = KEncr.Base32CrockfordDecode([]byte("UUUU"))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkEncrypt_Base32Crockford(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KEncr.Base32CrockfordDecode(KEncr.Base32CrockfordEncode(bytsHello))
+	}
+}
+
 func TestEncrypt_AuthCode(t *testing.T) {
 	var res, res2 []byte
 	var exp int64
@@ -0,0 +1,319 @@
+package kgo
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LoadEnvOptions LoadEnv 的选项.
+type LoadEnvOptions struct {
+	Prefix    string   `json:"prefix"`    //环境变量名的前缀,如"APP_",字段的env标签为"PORT"时读取"APP_PORT"
+	Files     []string `json:"files"`     //要读取的.env文件,后面的文件覆盖前面的;不会修改进程的环境变量
+	Override  bool     `json:"override"`  //.env文件中的变量是否优先于进程的环境变量,默认进程的环境变量优先
+	Separator string   `json:"separator"` //切片和字典元素的分隔符,默认为","
+}
+
+// EnvError LoadEnv 的错误,包含所有缺少或无效的环境变量.
+type EnvError struct {
+	Fields []*FieldError `json:"fields"` //失败的变量,Field为环境变量名
+}
+
+// ErrEnvRequired 必需的环境变量未设置
+var ErrEnvRequired = errors.New("[Env]`required variable is not set")
+
+// Error 实现error接口.
+func (ee *EnvError) Error() string {
+	msgs := make([]string, len(ee.Fields))
+	for i, fe := range ee.Fields {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("[LoadEnv]`%d variable(s) failed: %s", len(ee.Fields), strings.Join(msgs, "; "))
+}
+
+// ParseDotEnv 解析.env格式的内容,返回变量字典.
+// 每行为KEY=VALUE,可带"export "前缀;空行和以#开头的行将被忽略;
+// 单引号内的值原样保留;双引号内的值支持\n、\t、\"、\\、\$转义;结束引号后只允许空白和#注释,否则返回错误;
+// 无引号的值去除首尾空格及" #"后的注释.
+// 除单引号的值外,${VAR}、${VAR:-default}和$VAR将被替换为前面已定义的变量,未定义时使用进程的环境变量.
+func (ko *LkkOS) ParseDotEnv(str []byte) (map[string]string, error) {
+	return parseDotEnv(str)
+}
+
+// parseDotEnv 解析.env格式的内容.
+func parseDotEnv(str []byte) (map[string]string, error) {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(str, []byte("\xef\xbb\xbf"))))
+	lookup := func(name string) string {
+		if val, ok := res[name]; ok {
+			return val
+		}
+		return os.Getenv(name)
+	}
+
+	for num := 1; scanner.Scan(); num++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("[ParseDotEnv]`line %d: missing '='", num)
+		}
+		key, val := strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		if strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("[ParseDotEnv]`line %d: invalid key %q", num, key)
+		}
+
+		switch {
+		case val == "":
+		case val[0] == '\'':
+			end := strings.IndexByte(val[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("[ParseDotEnv]`line %d: unterminated quoted value", num)
+			} else if !envTrailingOk(val[end+2:]) {
+				return nil, fmt.Errorf("[ParseDotEnv]`line %d: unexpected content after quoted value", num)
+			}
+			val = val[1 : end+1]
+		case val[0] == '"':
+			var rest string
+			var err error
+			if val, rest, err = envUnquote(val, lookup); err != nil {
+				return nil, fmt.Errorf("[ParseDotEnv]`line %d: %s", num, err)
+			} else if !envTrailingOk(rest) {
+				return nil, fmt.Errorf("[ParseDotEnv]`line %d: unexpected content after quoted value", num)
+			}
+		default:
+			if j := strings.Index(val, " #"); j >= 0 {
+				val = strings.TrimSpace(val[:j])
+			}
+			val = envExpand(val, lookup)
+		}
+		res[key] = val
+	}
+
+	return res, scanner.Err()
+}
+
+// envTrailingOk 引号后的内容rest是否只有空白或以#开头的注释.
+func envTrailingOk(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || rest[0] == '#'
+}
+
+// envUnquote 解析双引号的值,处理转义并展开变量;rest为结束引号后的内容.
+func envUnquote(val string, lookup func(string) string) (string, string, error) {
+	var sb, part strings.Builder
+	for i := 1; i < len(val); i++ {
+		c := val[i]
+		switch {
+		case c == '"':
+			sb.WriteString(envExpand(part.String(), lookup))
+			return sb.String(), val[i+1:], nil
+		case c == '\\' && i+1 < len(val):
+			i++
+			switch val[i] {
+			case 'n':
+				part.WriteByte('\n')
+			case 't':
+				part.WriteByte('\t')
+			case 'r':
+				part.WriteByte('\r')
+			case '$':
+				//转义的$不参与变量展开
+				sb.WriteString(envExpand(part.String(), lookup))
+				sb.WriteByte('$')
+				part.Reset()
+			default:
+				part.WriteByte(val[i])
+			}
+		default:
+			part.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated quoted value")
+}
+
+// envExpand 展开str中的${VAR}、${VAR:-default}和$VAR.
+func envExpand(str string, lookup func(string) string) string {
+	return os.Expand(str, func(name string) string {
+		if i := strings.Index(name, ":-"); i > 0 {
+			if val := lookup(name[:i]); val != "" {
+				return val
+			}
+			return name[i+2:]
+		}
+		return lookup(name)
+	})
+}
+
+// LoadDotEnv 读取.env文件并设置到进程的环境变量,files为空时读取当前目录的".env";
+// override为false时不覆盖已存在的环境变量.文件格式见 ParseDotEnv .
+func (ko *LkkOS) LoadDotEnv(override bool, files ...string) error {
+	vars, err := readDotEnv(files)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range vars {
+		if _, ok := os.LookupEnv(k); ok && !override {
+			continue
+		}
+		if err = os.Setenv(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readDotEnv 读取并合并多个.env文件,后面的文件覆盖前面的.
+func readDotEnv(files []string) (map[string]string, error) {
+	if len(files) == 0 {
+		files = []string{".env"}
+	}
+
+	res := make(map[string]string)
+	for _, file := range files {
+		cont, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		vars, err := parseDotEnv(cont)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for k, v := range vars {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+// LoadEnv 将环境变量绑定到结构体指针dst的字段,opts为可选的选项,见 LoadEnvOptions .
+// 字段标签:env为环境变量名,default为未设置时的默认值,required:"true"为必需的变量,如`env:"PORT" default:"8080" required:"true"`;
+// 值为空串的变量视为未设置,同 Getenv .
+// 值的转换同 Map2Struct ,支持数值、布尔、time.Duration、time.Time、指针等;
+// 切片按分隔符拆分,如"a,b,c";字典为"k1:v1,k2:v2"形式.
+// 无env标签的嵌套结构体将递归绑定;嵌套结构体的env标签作为其字段的前缀,如`env:"DB_"`.
+// 有变量缺少或无效时返回 *EnvError ,其中列出所有失败的变量,其他字段仍会被赋值.
+func (ko *LkkOS) LoadEnv(dst interface{}, opts ...LoadEnvOptions) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("[LoadEnv]`dst must be a non-nil pointer to struct")
+	}
+
+	var opt LoadEnvOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Separator == "" {
+		opt.Separator = ","
+	}
+
+	var files map[string]string
+	if len(opt.Files) > 0 {
+		var err error
+		if files, err = readDotEnv(opt.Files); err != nil {
+			return err
+		}
+	}
+	lookup := func(name string) string {
+		val, ok := files[name]
+		if ok && opt.Override {
+			return val
+		} else if env := os.Getenv(name); env != "" {
+			return env
+		}
+		return val
+	}
+
+	sd := &structDecoder{}
+	envBindStruct(sd, dv.Elem(), opt.Prefix, opt.Separator, lookup)
+	if len(sd.errs) > 0 {
+		return &EnvError{Fields: sd.errs}
+	}
+	return nil
+}
+
+// envBindStruct 将环境变量绑定到结构体sv的字段.
+func envBindStruct(sd *structDecoder, sv reflect.Value, prefix, sep string, lookup func(string) string) {
+	t := sv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := sv.Field(i)
+		name, tagged := field.Tag.Lookup("env")
+		if name == "-" || (!fv.CanSet() && !(field.Anonymous && fv.Kind() == reflect.Struct)) {
+			//未导出的匿名嵌入结构体,其导出字段仍可设置
+			continue
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(ft))
+				}
+				fv = fv.Elem()
+			}
+			envBindStruct(sd, fv, prefix+name, sep, lookup)
+			continue
+		} else if !tagged || name == "" {
+			continue
+		}
+
+		name = prefix + name
+		val := lookup(name)
+		if val == "" {
+			val = field.Tag.Get("default")
+		}
+		if val == "" {
+			if required, _ := strconv.ParseBool(field.Tag.Get("required")); required {
+				sd.fail(name, ErrEnvRequired)
+			}
+			continue
+		}
+
+		sd.decode(name, envSplit(val, ft, sep), fv)
+	}
+}
+
+// envSplit 将切片和字典类型的值按分隔符拆分,其他类型原样返回.
+func envSplit(val string, t reflect.Type, sep string) interface{} {
+	switch t.Kind() {
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return val
+		}
+		items := strings.Split(val, sep)
+		res := make([]interface{}, len(items))
+		for i, item := range items {
+			res[i] = strings.TrimSpace(item)
+		}
+		return res
+	case reflect.Map:
+		res := make(map[string]interface{})
+		for _, item := range strings.Split(val, sep) {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			kv := strings.SplitN(item, ":", 2)
+			if len(kv) == 1 {
+				kv = append(kv, "")
+			}
+			res[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+		return res
+	}
+	return val
+}
@@ -0,0 +1,236 @@
+package kgo
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEnvDb struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"3306"`
+}
+
+type testEnvMeta struct {
+	Region string `env:"REGION" default:"cn"`
+}
+
+type testEnvConfig struct {
+	Port     int               `env:"PORT" default:"8080" required:"true"`
+	Debug    bool              `env:"DEBUG"`
+	Timeout  time.Duration     `env:"TIMEOUT" default:"30s"`
+	Rate     float64           `env:"RATE"`
+	Hosts    []string          `env:"HOSTS"`
+	Ports    []uint16          `env:"PORTS"`
+	Labels   map[string]string `env:"LABELS"`
+	Weights  map[string]int    `env:"WEIGHTS"`
+	Secret   string            `env:"SECRET" required:"true"`
+	Level    *int              `env:"LEVEL"`
+	Ignored  string            `env:"-"`
+	Untagged string
+	Db       testEnvDb  `env:"DB_"`
+	Cache    *testEnvDb `env:"CACHE_"`
+	testEnvMeta
+	private string `env:"PRIVATE"`
+}
+
+func testEnvSet(t *testing.T, vars map[string]string) {
+	for k, v := range vars {
+		_ = os.Setenv(k, v)
+		k := k
+		t.Cleanup(func() {
+			_ = os.Unsetenv(k)
+		})
+	}
+}
+
+func TestEnv_ParseDotEnv(t *testing.T) {
+	testEnvSet(t, map[string]string{"KGO_ENV_HOME": "/home/kgo"})
+	cont := []byte("\xef\xbb\xbf# comment\n\n" +
+		"NAME=kgo\n" +
+		"export EMPTY=\n" +
+		"PLAIN = hello world  # inline comment\n" +
+		"SINGLE='raw $NAME \\n # not comment'\n" +
+		"SINGLE2='a b'   # comment\n" +
+		"DOUBLE=\"line1\\nline2 \\\"q\\\" \\$NAME ${NAME}\" # comment\n" +
+		"HASH=a#b\n" +
+		"PATH_DIR=${KGO_ENV_HOME}/bin:$NAME\n" +
+		"DEF=${KGO_ENV_NOT_SET:-fallback}-${NAME:-x}\n" +
+		"EQ=a=b\n")
+
+	res, err := KOS.ParseDotEnv(cont)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"NAME":     "kgo",
+		"EMPTY":    "",
+		"PLAIN":    "hello world",
+		"SINGLE":   "raw $NAME \\n # not comment",
+		"SINGLE2":  "a b",
+		"DOUBLE":   "line1\nline2 \"q\" $NAME kgo",
+		"HASH":     "a#b",
+		"PATH_DIR": "/home/kgo/bin:kgo",
+		"DEF":      "fallback-kgo",
+		"EQ":       "a=b",
+	}, res)
+
+	invalid := []string{"NAME", "=value", "A B=1", "A='x", "A=\"x", "B='abc' trailing", "B=\"abc\"x"}
+	for _, str := range invalid {
+		_, err = KOS.ParseDotEnv([]byte(str))
+		assert.NotNil(t, err, str)
+	}
+	_, err = KOS.ParseDotEnv([]byte("A=1\nB='abc' trailing\n"))
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func BenchmarkEnv_ParseDotEnv(b *testing.B) {
+	cont := []byte("NAME=kgo\nPLAIN=hello # comment\nDOUBLE=\"a\\nb ${NAME}\"\nSINGLE='raw'\n")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.ParseDotEnv(cont)
+	}
+}
+
+func TestEnv_LoadDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "a.env")
+	file2 := filepath.Join(dir, "b.env")
+	_ = ioutil.WriteFile(file1, []byte("KGO_DOTENV_A=1\nKGO_DOTENV_B=1\n"), 0644)
+	_ = ioutil.WriteFile(file2, []byte("KGO_DOTENV_B=2\nKGO_DOTENV_C=2\n"), 0644)
+	testEnvSet(t, map[string]string{"KGO_DOTENV_C": "env"})
+	t.Cleanup(func() {
+		_ = os.Unsetenv("KGO_DOTENV_A")
+		_ = os.Unsetenv("KGO_DOTENV_B")
+	})
+
+	err := KOS.LoadDotEnv(false, file1, file2)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", os.Getenv("KGO_DOTENV_A"))
+	assert.Equal(t, "2", os.Getenv("KGO_DOTENV_B"))
+	assert.Equal(t, "env", os.Getenv("KGO_DOTENV_C"))
+
+	err = KOS.LoadDotEnv(true, file2)
+	assert.Nil(t, err)
+	assert.Equal(t, "2", os.Getenv("KGO_DOTENV_C"))
+
+	err = KOS.LoadDotEnv(false, filepath.Join(dir, "none.env"))
+	assert.NotNil(t, err)
+
+	bad := filepath.Join(dir, "bad.env")
+	_ = ioutil.WriteFile(bad, []byte("BAD"), 0644)
+	err = KOS.LoadDotEnv(false, bad)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkEnv_LoadDotEnv(b *testing.B) {
+	file := filepath.Join(b.TempDir(), ".env")
+	_ = ioutil.WriteFile(file, []byte("KGO_DOTENV_BENCH=1\n"), 0644)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KOS.LoadDotEnv(false, file)
+	}
+	_ = os.Unsetenv("KGO_DOTENV_BENCH")
+}
+
+func TestEnv_LoadEnv(t *testing.T) {
+	var err error
+
+	testEnvSet(t, map[string]string{
+		"APP_DEBUG":      "on",
+		"APP_TIMEOUT":    "1m30s",
+		"APP_RATE":       "0.75",
+		"APP_HOSTS":      "a.com, b.com,c.com",
+		"APP_PORTS":      "80,443",
+		"APP_LABELS":     "env:prod, team:ops",
+		"APP_WEIGHTS":    "a:1,b:2",
+		"APP_SECRET":     "s3cret",
+		"APP_LEVEL":      "3",
+		"APP_DB_HOST":    "db.local",
+		"APP_CACHE_PORT": "6379",
+		"APP_REGION":     "us",
+		"APP_PRIVATE":    "x",
+		"Untagged":       "x",
+	})
+
+	cfg := testEnvConfig{Ignored: "keep"}
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "APP_"})
+	assert.Nil(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, 90*time.Second, cfg.Timeout)
+	assert.Equal(t, 0.75, cfg.Rate)
+	assert.Equal(t, []string{"a.com", "b.com", "c.com"}, cfg.Hosts)
+	assert.Equal(t, []uint16{80, 443}, cfg.Ports)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "ops"}, cfg.Labels)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, cfg.Weights)
+	assert.Equal(t, "s3cret", cfg.Secret)
+	assert.Equal(t, 3, *cfg.Level)
+	assert.Equal(t, "keep", cfg.Ignored)
+	assert.Equal(t, "", cfg.Untagged)
+	assert.Equal(t, testEnvDb{Host: "db.local", Port: 3306}, cfg.Db)
+	assert.Equal(t, &testEnvDb{Host: "localhost", Port: 6379}, cfg.Cache)
+	assert.Equal(t, "us", cfg.Region)
+	assert.Equal(t, "", cfg.private)
+
+	//.env文件,默认进程的环境变量优先
+	file := filepath.Join(t.TempDir(), ".env")
+	_ = ioutil.WriteFile(file, []byte("APP_PORT=9000\nAPP_SECRET=from-file\nAPP_DB_PORT=${APP_PORT}\n"), 0644)
+	cfg = testEnvConfig{}
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "APP_", Files: []string{file}})
+	assert.Nil(t, err)
+	assert.Equal(t, 9000, cfg.Port)
+	assert.Equal(t, "s3cret", cfg.Secret)
+	assert.Equal(t, 9000, cfg.Db.Port)
+
+	cfg = testEnvConfig{}
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "APP_", Files: []string{file}, Override: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "from-file", cfg.Secret)
+
+	//自定义分隔符
+	cfg = testEnvConfig{}
+	testEnvSet(t, map[string]string{"SEP_HOSTS": "a;b", "SEP_SECRET": "x"})
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "SEP_", Separator: ";"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+
+	//汇总所有缺少和无效的变量
+	testEnvSet(t, map[string]string{
+		"BAD_PORT":    "abc",
+		"BAD_PORTS":   "80,70000",
+		"BAD_TIMEOUT": "soon",
+		"BAD_DEBUG":   "maybe",
+	})
+	cfg = testEnvConfig{}
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "BAD_"})
+	var envErr *EnvError
+	assert.True(t, errors.As(err, &envErr))
+	fields := make([]string, len(envErr.Fields))
+	for i, fe := range envErr.Fields {
+		fields[i] = fe.Field
+	}
+	assert.Equal(t, []string{"BAD_PORT", "BAD_DEBUG", "BAD_TIMEOUT", "BAD_PORTS[1]", "BAD_SECRET"}, fields)
+	assert.True(t, errors.Is(envErr.Fields[3], ErrNumberOverflow))
+	assert.True(t, errors.Is(envErr.Fields[4], ErrEnvRequired))
+	assert.Contains(t, err.Error(), "[LoadEnv]`5 variable(s) failed")
+	assert.Equal(t, "localhost", cfg.Db.Host)
+
+	err = KOS.LoadEnv(cfg)
+	assert.NotNil(t, err)
+	err = KOS.LoadEnv(&cfg, LoadEnvOptions{Files: []string{filepath.Join(t.TempDir(), "none")}})
+	assert.NotNil(t, err)
+}
+
+func BenchmarkEnv_LoadEnv(b *testing.B) {
+	_ = os.Setenv("BENCH_SECRET", "x")
+	_ = os.Setenv("BENCH_HOSTS", "a,b,c")
+	var cfg testEnvConfig
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KOS.LoadEnv(&cfg, LoadEnvOptions{Prefix: "BENCH_"})
+	}
+}
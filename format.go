@@ -0,0 +1,387 @@
+package kgo
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YamlEncode 对val变量进行 YAML 编码.
+// 依赖库gopkg.in/yaml.v3.
+func (ks *LkkString) YamlEncode(val interface{}) ([]byte, error) {
+	return yaml.Marshal(val)
+}
+
+// YamlDecode 对 YAML 格式的str字符串进行解码,注意res使用指针.
+// 依赖库gopkg.in/yaml.v3.
+func (ks *LkkString) YamlDecode(str []byte, res interface{}) error {
+	return yaml.Unmarshal(str, res)
+}
+
+// Yaml2Json 将 YAML 转为 JSON;非字符串的键将转为字符串,多文档时只转换第一个.
+func (ks *LkkString) Yaml2Json(str []byte) ([]byte, error) {
+	var val interface{}
+	if err := yaml.Unmarshal(str, &val); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(yamlNormalize(val))
+}
+
+// yamlNormalize 将YAML解码结果中的map[interface{}]interface{}转为map[string]interface{}.
+func yamlNormalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = yamlNormalize(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			res[toStr(k)] = yamlNormalize(item)
+		}
+		return res
+	case []interface{}:
+		for i, item := range v {
+			v[i] = yamlNormalize(item)
+		}
+	}
+	return val
+}
+
+// Json2Yaml 将 JSON 转为块格式的 YAML,保持原有键的顺序.
+func (ks *LkkString) Json2Yaml(str []byte) ([]byte, error) {
+	if !json.Valid(str) {
+		return nil, errors.New("[Json2Yaml]`invalid json")
+	}
+
+	//JSON的"\/"等转义在YAML中不合法,须按JSON逐个读取后构建节点
+	dec := json.NewDecoder(bytes.NewReader(str))
+	dec.UseNumber()
+	node, err := json2YamlNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(node)
+}
+
+// json2YamlNode 从dec读取一个JSON值并转为YAML节点,保持对象的键顺序.
+func json2YamlNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(key)})
+			}
+			child, err := json2YamlNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		//结束的]或}
+		_, err = dec.Token()
+		return node, err
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
+
+// Xml2Map 将 XML 转为字典,根元素名为字典唯一的键.
+// 属性的键名为"-"加属性名,元素同时有属性/子元素和文本时,文本的键名为"#text";
+// 只有文本的元素转为字符串,重复的子元素转为切片;忽略命名空间前缀.
+func (ks *LkkString) Xml2Map(str []byte) (map[string]interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(str))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, errors.New("[Xml2Map]`no root element")
+		} else if err != nil {
+			return nil, err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			val, err := xmlDecodeElement(dec, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: val}, nil
+		}
+	}
+}
+
+// xmlDecodeElement 解码start元素的属性、子元素和文本.
+func xmlDecodeElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	res := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		res["-"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := xmlDecodeElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+
+			name := t.Name.Local
+			if old, ok := res[name]; !ok {
+				res[name] = child
+			} else if list, ok := old.([]interface{}); ok {
+				res[name] = append(list, child)
+			} else {
+				res[name] = []interface{}{old, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			str := strings.TrimSpace(text.String())
+			if len(res) == 0 {
+				return str, nil
+			} else if str != "" {
+				res["#text"] = str
+			}
+			return res, nil
+		}
+	}
+}
+
+// Map2Xml 将字典转为 XML,为 Xml2Map 的逆操作.
+// 若指定root,则以root为根元素包裹m;否则m须只有一个键,作为根元素.
+// 键名以"-"开头的为属性,"#text"为文本,切片将转为重复的元素;同级元素按键名排序;
+// 元素名或属性名不是合法的XML名称时返回 ErrInvalidParam .
+func (ks *LkkString) Map2Xml(m map[string]interface{}, root ...string) ([]byte, error) {
+	var name string
+	var val interface{} = m
+	if len(root) > 0 && root[0] != "" {
+		name = root[0]
+	} else if len(m) == 1 {
+		for k, v := range m {
+			name, val = k, v
+		}
+	} else {
+		return nil, errors.New("[Map2Xml]`map must have exactly one key when root is empty")
+	}
+
+	var buf bytes.Buffer
+	if err := xmlEncodeElement(&buf, name, reflect.ValueOf(val)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// xmlValidName 检查name是否符合XML的Name产生式,如不能为空、不能以数字开头、不能包含空白、<、>、"、=等字符.
+func xmlValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if unicode.IsLetter(r) || r == '_' || r == ':' {
+			continue
+		} else if i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.' || r == '\u00B7' || unicode.In(r, unicode.Mn, unicode.Mc)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// xmlEncodeElement 将v编码为名称为name的元素.
+func xmlEncodeElement(buf *bytes.Buffer, name string, v reflect.Value) error {
+	if !xmlValidName(name) {
+		return fmt.Errorf("[Map2Xml]`invalid element name %q: %w", name, ErrInvalidParam)
+	}
+
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			break
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				if err := xmlEncodeElement(buf, name, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("[Map2Xml]`%s: %w", v.Type(), ErrUnsupportedType)
+		}
+
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if strings.HasPrefix(k, "-") && !xmlValidName(k[1:]) {
+				return fmt.Errorf("[Map2Xml]`invalid attribute name %q: %w", k[1:], ErrInvalidParam)
+			}
+		}
+
+		buf.WriteString("<" + name)
+		for _, k := range keys {
+			if strings.HasPrefix(k, "-") {
+				buf.WriteString(" " + k[1:] + `="`)
+				_ = xml.EscapeText(buf, []byte(toStr(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())).Interface())))
+				buf.WriteString(`"`)
+			}
+		}
+		buf.WriteString(">")
+
+		for _, k := range keys {
+			item := v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key()))
+			if k == "#text" {
+				_ = xml.EscapeText(buf, []byte(toStr(item.Interface())))
+			} else if !strings.HasPrefix(k, "-") {
+				if err := xmlEncodeElement(buf, k, item); err != nil {
+					return err
+				}
+			}
+		}
+		buf.WriteString("</" + name + ">")
+		return nil
+	}
+
+	buf.WriteString("<" + name + ">")
+	if v.IsValid() && !(v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		_ = xml.EscapeText(buf, []byte(toStr(v.Interface())))
+	}
+	buf.WriteString("</" + name + ">")
+	return nil
+}
+
+// Csv2Maps 将 CSV 转为字典切片.
+// 若指定header,则以其为列名,所有行均为数据;否则第一行为列名(去除首尾空格和UTF-8 BOM).
+// 列数少于列名的行,缺少的列为空串;列数多于列名或列名重复时返回错误.
+func (ks *LkkString) Csv2Maps(str []byte, header ...string) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(str, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) == 0 {
+		if len(records) == 0 {
+			return []map[string]string{}, nil
+		}
+		header = records[0]
+		records = records[1:]
+		for i := range header {
+			header[i] = strings.TrimSpace(header[i])
+		}
+	}
+
+	seen := make(map[string]bool, len(header))
+	for _, h := range header {
+		if seen[h] {
+			return nil, fmt.Errorf("[Csv2Maps]`duplicate header %q", h)
+		}
+		seen[h] = true
+	}
+
+	res := make([]map[string]string, 0, len(records))
+	for n, record := range records {
+		if len(record) > len(header) {
+			return nil, fmt.Errorf("[Csv2Maps]`record %d has %d fields, header has %d", n+1, len(record), len(header))
+		}
+
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(record) {
+				row[h] = record[i]
+			} else {
+				row[h] = ""
+			}
+		}
+		res = append(res, row)
+	}
+
+	return res, nil
+}
+
+// Maps2Csv 将字典切片转为 CSV,第一行为列名.
+// 若指定header,则按其顺序输出这些列;否则为所有行的键的并集,按名称排序.
+func (ks *LkkString) Maps2Csv(rows []map[string]string, header ...string) ([]byte, error) {
+	if len(header) == 0 {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			for k := range row {
+				if !seen[k] {
+					seen[k] = true
+					header = append(header, k)
+				}
+			}
+		}
+		sort.Strings(header)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i, h := range header {
+			record[i] = row[h]
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
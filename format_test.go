@@ -0,0 +1,265 @@
+package kgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat_YamlEncode_YamlDecode(t *testing.T) {
+	var res map[string]interface{}
+	data := map[string]interface{}{"name": "kgo", "tags": []interface{}{"a", "b"}}
+
+	out, err := KStr.YamlEncode(data)
+	assert.Nil(t, err)
+	assert.Contains(t, string(out), "name: kgo")
+
+	err = KStr.YamlDecode(out, &res)
+	assert.Nil(t, err)
+	assert.Equal(t, data, res)
+
+	err = KStr.YamlDecode([]byte("a: [1"), &res)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkFormat_YamlEncode(b *testing.B) {
+	data := map[string]interface{}{"name": "kgo", "tags": []interface{}{"a", "b"}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.YamlEncode(data)
+	}
+}
+
+func BenchmarkFormat_YamlDecode(b *testing.B) {
+	var res map[string]interface{}
+	data := []byte("name: kgo\ntags:\n  - a\n  - b\n")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KStr.YamlDecode(data, &res)
+	}
+}
+
+func TestFormat_Yaml2Json(t *testing.T) {
+	yml := `
+name: kgo
+version: 1.5
+debug: false
+1: one
+servers:
+  - host: a.com
+    port: 80
+  - host: b.com
+    ports: {http: 80, 443: https}
+`
+	res, err := KStr.Yaml2Json([]byte(yml))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"1":"one","debug":false,"name":"kgo","servers":[{"host":"a.com","port":80},{"host":"b.com","ports":{"443":"https","http":80}}],"version":1.5}`, string(res))
+
+	res, err = KStr.Yaml2Json([]byte("a: [1"))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkFormat_Yaml2Json(b *testing.B) {
+	data := []byte("name: kgo\ntags:\n  - a\n  - b\n")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Yaml2Json(data)
+	}
+}
+
+func TestFormat_Json2Yaml(t *testing.T) {
+	js := `{"name":"kgo","code":"007","list":[1,true,null,{"x":"yes"}],"text":"a\nb"}`
+	res, err := KStr.Json2Yaml([]byte(js))
+	assert.Nil(t, err)
+	assert.Equal(t, "name: kgo\ncode: \"007\"\nlist:\n    - 1\n    - true\n    - null\n    - x: yes\ntext: |-\n    a\n    b\n", string(res))
+
+	//往返
+	back, err := KStr.Yaml2Json(res)
+	assert.Nil(t, err)
+	assert.JSONEq(t, js, string(back))
+
+	//PHP的json_encode默认转义斜杠
+	res, err = KStr.Json2Yaml([]byte(`{"k":"a\/b","u":"\u00e9\t","n":-1.5e3,"e":{},"l":[]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "k: a/b\nu: \"é\\t\"\nn: -1.5e3\ne: {}\nl: []\n", string(res))
+
+	_, err = KStr.Json2Yaml([]byte("name: kgo"))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkFormat_Json2Yaml(b *testing.B) {
+	data := []byte(`{"name":"kgo","list":[1,2,3]}`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Json2Yaml(data)
+	}
+}
+
+func TestFormat_Xml2Map(t *testing.T) {
+	xmlStr := `<?xml version="1.0" encoding="UTF-8"?>
+<!-- comment -->
+<catalog lang="zh">
+	<book id="1"><title>Go &amp; You</title><price>9.9</price></book>
+	<book id="2"><title>Kgo</title></book>
+	<note>hello</note>
+	<empty/>
+</catalog>`
+	res, err := KStr.Xml2Map([]byte(xmlStr))
+	assert.Nil(t, err)
+	catalog := res["catalog"].(map[string]interface{})
+	assert.Equal(t, "zh", catalog["-lang"])
+	assert.Equal(t, "hello", catalog["note"])
+	assert.Equal(t, "", catalog["empty"])
+
+	books := catalog["book"].([]interface{})
+	assert.Equal(t, 2, len(books))
+	assert.Equal(t, map[string]interface{}{"-id": "1", "title": "Go & You", "price": "9.9"}, books[0])
+
+	res, err = KStr.Xml2Map([]byte(`<a x="1">text</a>`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"-x": "1", "#text": "text"}}, res)
+
+	_, err = KStr.Xml2Map([]byte(`<a><b></a>`))
+	assert.NotNil(t, err)
+	_, err = KStr.Xml2Map([]byte(``))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkFormat_Xml2Map(b *testing.B) {
+	data := []byte(`<catalog><book id="1"><title>Go</title></book><book id="2"><title>Kgo</title></book></catalog>`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Xml2Map(data)
+	}
+}
+
+func TestFormat_Map2Xml(t *testing.T) {
+	m := map[string]interface{}{
+		"catalog": map[string]interface{}{
+			"-lang": "zh",
+			"book": []interface{}{
+				map[string]interface{}{"-id": 1, "title": "Go & You"},
+				map[string]string{"-id": "2", "title": "Kgo"},
+			},
+			"note":  "hello",
+			"count": 2,
+			"none":  nil,
+		},
+	}
+	res, err := KStr.Map2Xml(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `<catalog lang="zh"><book id="1"><title>Go &amp; You</title></book><book id="2"><title>Kgo</title></book><count>2</count><none></none><note>hello</note></catalog>`, string(res))
+
+	//往返
+	back, err := KStr.Xml2Map(res)
+	assert.Nil(t, err)
+	assert.Equal(t, "Go & You", back["catalog"].(map[string]interface{})["book"].([]interface{})[0].(map[string]interface{})["title"])
+
+	res, err = KStr.Map2Xml(map[string]interface{}{"a": 1, "b": "x"}, "root")
+	assert.Nil(t, err)
+	assert.Equal(t, `<root><a>1</a><b>x</b></root>`, string(res))
+
+	res, err = KStr.Map2Xml(map[string]interface{}{"#text": "hi", "-k": `"v"`}, "root")
+	assert.Nil(t, err)
+	assert.Equal(t, `<root k="&#34;v&#34;">hi</root>`, string(res))
+
+	_, err = KStr.Map2Xml(map[string]interface{}{"a": 1, "b": 2})
+	assert.NotNil(t, err)
+	_, err = KStr.Map2Xml(map[string]interface{}{"a": map[int]int{1: 1}})
+	assert.NotNil(t, err)
+
+	//非法的元素名和属性名
+	invalid := []map[string]interface{}{
+		{"a><script": 1},
+		{"ok": map[string]interface{}{`x="1" y`: 1}},
+		{"ok": map[string]interface{}{"-x=\"1\" y": 1}},
+		{"ok": map[string]interface{}{"-": 1}},
+		{"ok": []interface{}{map[string]interface{}{"1a": 1}}},
+		{"a b": 1},
+		{"": 1},
+	}
+	for _, m := range invalid {
+		_, err = KStr.Map2Xml(m)
+		assert.True(t, errors.Is(err, ErrInvalidParam), m)
+	}
+	_, err = KStr.Map2Xml(map[string]interface{}{"a": 1}, "r<t")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	res, err = KStr.Map2Xml(map[string]interface{}{"ns:名称": map[string]interface{}{"-data-id.x": 1, "b_1": "v"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `<ns:名称 data-id.x="1"><b_1>v</b_1></ns:名称>`, string(res))
+}
+
+func BenchmarkFormat_Map2Xml(b *testing.B) {
+	m := map[string]interface{}{"a": 1, "b": []string{"x", "y"}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Map2Xml(m, "root")
+	}
+}
+
+func TestFormat_Csv2Maps(t *testing.T) {
+	csvStr := "\xef\xbb\xbf id , name ,note\n1,kgo,\"a,b\"\n2,php\n"
+	res, err := KStr.Csv2Maps([]byte(csvStr))
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]string{
+		{"id": "1", "name": "kgo", "note": "a,b"},
+		{"id": "2", "name": "php", "note": ""},
+	}, res)
+
+	res, err = KStr.Csv2Maps([]byte("1,kgo\n2,php\n"), "id", "name")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, "php", res[1]["name"])
+
+	res, err = KStr.Csv2Maps([]byte(""))
+	assert.Nil(t, err)
+	assert.Empty(t, res)
+
+	_, err = KStr.Csv2Maps([]byte("id,id\n1,2\n"))
+	assert.NotNil(t, err)
+	_, err = KStr.Csv2Maps([]byte("id\n1,2\n"))
+	assert.NotNil(t, err)
+	_, err = KStr.Csv2Maps([]byte("id\n\"1\n"))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkFormat_Csv2Maps(b *testing.B) {
+	data := []byte("id,name\n1,kgo\n2,php\n")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Csv2Maps(data)
+	}
+}
+
+func TestFormat_Maps2Csv(t *testing.T) {
+	rows := []map[string]string{
+		{"id": "1", "name": "kgo", "note": "a,b"},
+		{"id": "2", "name": "php", "extra": "x"},
+	}
+	res, err := KStr.Maps2Csv(rows)
+	assert.Nil(t, err)
+	assert.Equal(t, "extra,id,name,note\n,1,kgo,\"a,b\"\nx,2,php,\n", string(res))
+
+	res, err = KStr.Maps2Csv(rows, "name", "id")
+	assert.Nil(t, err)
+	assert.Equal(t, "name,id\nkgo,1\nphp,2\n", string(res))
+
+	//往返
+	back, err := KStr.Csv2Maps(res)
+	assert.Nil(t, err)
+	assert.Equal(t, "kgo", back[0]["name"])
+
+	res, err = KStr.Maps2Csv(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "\n", string(res))
+}
+
+func BenchmarkFormat_Maps2Csv(b *testing.B) {
+	rows := []map[string]string{{"id": "1", "name": "kgo"}, {"id": "2", "name": "php"}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.Maps2Csv(rows)
+	}
+}
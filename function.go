@@ -6,6 +6,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
@@ -448,28 +449,231 @@ func structFields(obj interface{}, all bool) ([]reflect.StructField, error) {
 }
 
 // struct2Map 结构体转为字典;tagName为要导出的标签名,可以为空,为空时将导出所有字段.
-func struct2Map(obj interface{}, tagName string) (map[string]interface{}, error) {
+// opts为可选的选项,见 Struct2MapOptions .
+func struct2Map(obj interface{}, tagName string, opts ...Struct2MapOptions) (map[string]interface{}, error) {
+	var opt Struct2MapOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Flatten {
+		opt.Recursive = true
+		if opt.Separator == "" {
+			opt.Separator = "."
+		}
+	}
+
 	v, e := structVal(obj)
 	if e != nil {
 		return nil, e
 	}
 
+	res, e := struct2MapValue(v, tagName, &opt, nil)
+	if e != nil {
+		return nil, e
+	}
+
+	if opt.Flatten {
+		flat := make(map[string]interface{}, len(res))
+		flattenMap(flat, "", opt.Separator, res)
+		res = flat
+	}
+
+	return res, nil
+}
+
+// struct2MapValue 将结构体反射值转为字典,处理标签选项omitempty、inline;
+// visiting记录递归路径上的指针和字典,用于检测循环引用,可为nil.
+func struct2MapValue(v reflect.Value, tagName string, opt *Struct2MapOptions, visiting map[deepCopyKey]bool) (map[string]interface{}, error) {
+	if visiting == nil {
+		visiting = make(map[deepCopyKey]bool)
+	}
+
 	t := v.Type()
 	var res = make(map[string]interface{})
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
+		name := field.Name
+		var omitEmpty, inline bool
 		if tagName != "" {
-			if tagValue := field.Tag.Get(tagName); tagValue != "" {
-				res[tagValue] = reflect2Itf(v.Field(i))
+			tagValue := field.Tag.Get(tagName)
+			if tagValue == "" || tagValue == "-" {
+				continue
+			}
+
+			parts := strings.Split(tagValue, ",")
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, o := range parts[1:] {
+				switch o {
+				case "omitempty":
+					omitEmpty = true
+				case "inline", "squash":
+					inline = true
+				}
 			}
+		}
+
+		fv := v.Field(i)
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		//内联,将嵌套结构体的字段合并到当前层
+		if inline {
+			sv := fv
+			var marked []deepCopyKey
+			for sv.Kind() == reflect.Ptr && !sv.IsNil() {
+				key := deepCopyKey{ptr: sv.Pointer(), typ: sv.Type()}
+				if visiting[key] {
+					return nil, fmt.Errorf("[struct2Map]`circular reference %s: %w", sv.Type(), ErrInvalidParam)
+				}
+				visiting[key] = true
+				marked = append(marked, key)
+				sv = sv.Elem()
+			}
+			var sub map[string]interface{}
+			var err error
+			if sv.Kind() == reflect.Struct {
+				sub, err = struct2MapValue(sv, tagName, opt, visiting)
+			}
+			for _, key := range marked {
+				delete(visiting, key)
+			}
+
+			if err != nil {
+				return nil, err
+			} else if sv.Kind() == reflect.Struct {
+				for k, val := range sub {
+					if _, ok := res[k]; !ok {
+						res[k] = val
+					}
+				}
+				continue
+			} else if sv.Kind() == reflect.Ptr {
+				continue
+			}
+		}
+
+		if opt.Recursive && fv.CanInterface() {
+			val, err := struct2MapItem(fv, tagName, opt, visiting)
+			if err != nil {
+				return nil, fmt.Errorf("[struct2Map]`field %s: %w", field.Name, err)
+			}
+			res[name] = val
 		} else {
-			res[field.Name] = reflect2Itf(v.Field(i))
+			res[name] = reflect2Itf(fv)
 		}
 	}
 
 	return res, nil
 }
 
+// struct2MapItem 递归转换字段值:实现了encoding.TextMarshaler的转为字符串,结构体转为字典,
+// 元素为结构体(指针)的切片/字典转为[]interface{}/map[string]interface{},其他同非递归时经 reflect2Itf 转换;
+// 遇到循环引用的指针或字典时返回 ErrInvalidParam .
+func struct2MapItem(v reflect.Value, tagName string, opt *Struct2MapOptions, visiting map[deepCopyKey]bool) (interface{}, error) {
+	if opt.keepTime && v.Kind() == reflect.Ptr && v.Type().Elem() == timeType && !v.IsNil() {
+		v = v.Elem()
+	}
+	if opt.keepTime && v.Type() == timeType {
+		return v.Interface(), nil
+	} else if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, nil
+		}
+		txt, err := tm.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(txt), nil
+	} else if v.CanAddr() {
+		if tm, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+			txt, err := tm.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return string(txt), nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		} else if v.Kind() == reflect.Ptr {
+			key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+			if visiting[key] {
+				return nil, fmt.Errorf("[struct2Map]`circular reference %s: %w", v.Type(), ErrInvalidParam)
+			}
+			visiting[key] = true
+			defer delete(visiting, key)
+		}
+		return struct2MapItem(v.Elem(), tagName, opt, visiting)
+	case reflect.Struct:
+		return struct2MapValue(v, tagName, opt, visiting)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() || !needStruct2Map(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+
+		res := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := struct2MapItem(v.Index(i), tagName, opt, visiting)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = item
+		}
+		return res, nil
+	case reflect.Map:
+		if v.IsNil() || !needStruct2Map(v.Type().Elem()) {
+			return v.Interface(), nil
+		}
+
+		key := deepCopyKey{ptr: v.Pointer(), typ: v.Type()}
+		if visiting[key] {
+			return nil, fmt.Errorf("[struct2Map]`circular reference %s: %w", v.Type(), ErrInvalidParam)
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+
+		res := make(map[string]interface{}, v.Len())
+		for _, k := range v.MapKeys() {
+			item, err := struct2MapItem(v.MapIndex(k), tagName, opt, visiting)
+			if err != nil {
+				return nil, err
+			}
+			res[toStr(k.Interface())] = item
+		}
+		return res, nil
+	}
+
+	return reflect2Itf(v), nil
+}
+
+// needStruct2Map 切片/字典的元素类型是否需要递归转换.
+func needStruct2Map(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Interface || t.Kind() == reflect.Slice || t.Kind() == reflect.Map
+}
+
+// flattenMap 将嵌套的字典展平到res中,键以sep连接.
+func flattenMap(res map[string]interface{}, prefix, sep string, mp map[string]interface{}) {
+	for k, v := range mp {
+		if prefix != "" {
+			k = prefix + sep + k
+		}
+		if sub, ok := v.(map[string]interface{}); ok && len(sub) > 0 {
+			flattenMap(res, k, sep, sub)
+		} else {
+			res[k] = v
+		}
+	}
+}
+
 // creditChecksum 计算身份证校验码,其中id为身份证号码.
 func creditChecksum(id string) byte {
 	//∑(ai×Wi)(mod 11)
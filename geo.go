@@ -0,0 +1,383 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// GeoPoint 地理坐标点
+type GeoPoint struct {
+	Lng float64 `json:"lng"` //经度:-180~180
+	Lat float64 `json:"lat"` //纬度:-90~90
+}
+
+// GeoBounds 地理矩形范围
+type GeoBounds struct {
+	MinLng float64 `json:"min_lng"` //最小经度
+	MinLat float64 `json:"min_lat"` //最小纬度
+	MaxLng float64 `json:"max_lng"` //最大经度
+	MaxLat float64 `json:"max_lat"` //最大纬度
+}
+
+const (
+	// earthRadius 地球平均半径/米
+	earthRadius = 6371000.0
+	// wgs84A WGS-84椭球长半轴/米
+	wgs84A = 6378137.0
+	// wgs84F WGS-84椭球扁率
+	wgs84F = 1 / 298.257223563
+	// gcjA GCJ-02使用的克拉索夫斯基椭球长半轴/米
+	gcjA = 6378245.0
+	// gcjEE GCJ-02使用的克拉索夫斯基椭球第一偏心率平方
+	gcjEE = 0.00669342162296594323
+	// bdXPi BD-09的偏移参数
+	bdXPi = math.Pi * 3000.0 / 180.0
+	// geoHashBase32 GeoHash使用的base32字符表
+	geoHashBase32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+)
+
+// ErrVincentyNoConverge Vincenty公式未收敛(两点接近对跖点)
+var ErrVincentyNoConverge = errors.New("[VincentyDistance]`formula failed to converge")
+
+// deg2rad 角度转弧度.
+func deg2rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// rad2deg 弧度转角度.
+func rad2deg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// normalizeLng 将经度规范到-180~180.
+func normalizeLng(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// HaversineDistance 使用半正矢公式获取两点间的球面距离/米;在近距离时比GeoDistance更精确.
+// 参数分别为两点的经度和纬度:lat:-90~90,lng:-180~180.
+func (kn *LkkNumber) HaversineDistance(lng1, lat1, lng2, lat2 float64) float64 {
+	phi1, phi2 := deg2rad(lat1), deg2rad(lat2)
+	dPhi := phi2 - phi1
+	dLambda := deg2rad(lng2 - lng1)
+
+	a := math.Pow(math.Sin(dPhi/2), 2) + math.Cos(phi1)*math.Cos(phi2)*math.Pow(math.Sin(dLambda/2), 2)
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// VincentyDistance 使用Vincenty公式获取两点在WGS-84椭球上的距离/米,精度可达毫米级.
+// 两点接近对跖点时可能不收敛,返回 ErrVincentyNoConverge .
+func (kn *LkkNumber) VincentyDistance(lng1, lat1, lng2, lat2 float64) (float64, error) {
+	b := wgs84A * (1 - wgs84F)
+	L := deg2rad(lng2 - lng1)
+	U1 := math.Atan((1 - wgs84F) * math.Tan(deg2rad(lat1)))
+	U2 := math.Atan((1 - wgs84F) * math.Tan(deg2rad(lat2)))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cos2Alpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Sqrt(math.Pow(cosU2*sinLambda, 2) + math.Pow(cosU1*sinU2-sinU1*cosU2*cosLambda, 2))
+		if sinSigma == 0 {
+			//重合点
+			return 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cos2Alpha != 0 {
+			//赤道线上cos2Alpha为0
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		C := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = L + (1-C)*wgs84F*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) < 1e-12 {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, ErrVincentyNoConverge
+	}
+
+	uSq := cos2Alpha * (wgs84A*wgs84A - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * A * (sigma - deltaSigma), nil
+}
+
+// GeoBearing 获取从点1到点2的初始方位角/度,范围0~360,正北为0,顺时针增加.
+func (kn *LkkNumber) GeoBearing(lng1, lat1, lng2, lat2 float64) float64 {
+	phi1, phi2 := deg2rad(lat1), deg2rad(lat2)
+	dLambda := deg2rad(lng2 - lng1)
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	return math.Mod(rad2deg(math.Atan2(y, x))+360, 360)
+}
+
+// GeoDestination 获取从起点沿方位角bearing(度)移动distance米后的目的地坐标(球面模型).
+func (kn *LkkNumber) GeoDestination(lng, lat, distance, bearing float64) (float64, float64) {
+	delta := distance / earthRadius
+	theta := deg2rad(bearing)
+	phi1, lambda1 := deg2rad(lat), deg2rad(lng)
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1), math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+
+	return normalizeLng(rad2deg(lambda2)), rad2deg(phi2)
+}
+
+// GeoBoundingBox 获取以某点为中心、半径为distance米的外接矩形范围,可用于数据库的范围预筛选.
+// 范围跨越极点时经度取全部;跨越180度经线时MinLng将大于MaxLng.
+func (kn *LkkNumber) GeoBoundingBox(lng, lat, distance float64) GeoBounds {
+	delta := rad2deg(distance / earthRadius)
+	res := GeoBounds{
+		MinLat: lat - delta,
+		MaxLat: lat + delta,
+	}
+
+	if res.MinLat <= -90 || res.MaxLat >= 90 {
+		res.MinLat = math.Max(res.MinLat, -90)
+		res.MaxLat = math.Min(res.MaxLat, 90)
+		res.MinLng, res.MaxLng = -180, 180
+		return res
+	}
+
+	dLng := rad2deg(math.Asin(math.Sin(distance/earthRadius) / math.Cos(deg2rad(lat))))
+	res.MinLng = normalizeLng(lng - dLng)
+	res.MaxLng = normalizeLng(lng + dLng)
+	return res
+}
+
+// Contains 点是否在矩形范围内(包括边界),支持跨越180度经线的范围.
+func (gb GeoBounds) Contains(lng, lat float64) bool {
+	if lat < gb.MinLat || lat > gb.MaxLat {
+		return false
+	}
+	if gb.MinLng <= gb.MaxLng {
+		return lng >= gb.MinLng && lng <= gb.MaxLng
+	}
+	return lng >= gb.MinLng || lng <= gb.MaxLng
+}
+
+// Center 获取矩形范围的中心点.
+func (gb GeoBounds) Center() (lng, lat float64) {
+	return (gb.MinLng + gb.MaxLng) / 2, (gb.MinLat + gb.MaxLat) / 2
+}
+
+// PointInPolygon 点是否在多边形内(射线法),polygon为多边形顶点,首尾无需重复.
+func (kn *LkkNumber) PointInPolygon(lng, lat float64, polygon []GeoPoint) (res bool) {
+	length := len(polygon)
+	if length < 3 {
+		return
+	}
+
+	for i, j := 0, length-1; i < length; j, i = i, i+1 {
+		pi, pj := polygon[i], polygon[j]
+		if (pi.Lat > lat) != (pj.Lat > lat) && lng < (pj.Lng-pi.Lng)*(lat-pi.Lat)/(pj.Lat-pi.Lat)+pi.Lng {
+			res = !res
+		}
+	}
+
+	return
+}
+
+// GeoHashEncode 获取坐标的GeoHash编码,precision为编码长度(1~12).
+func (kn *LkkNumber) GeoHashEncode(lng, lat float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	} else if precision > 12 {
+		precision = 12
+	}
+
+	lngRange := [2]float64{-180, 180}
+	latRange := [2]float64{-90, 90}
+	var sb strings.Builder
+	var bit, ch int
+	even := true
+	for sb.Len() < precision {
+		var val float64
+		var rng *[2]float64
+		if even {
+			val, rng = lng, &lngRange
+		} else {
+			val, rng = lat, &latRange
+		}
+
+		mid := (rng[0] + rng[1]) / 2
+		ch <<= 1
+		if val >= mid {
+			ch |= 1
+			rng[0] = mid
+		} else {
+			rng[1] = mid
+		}
+		even = !even
+
+		if bit++; bit == 5 {
+			sb.WriteByte(geoHashBase32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// GeoHashDecode 解码GeoHash,返回其对应的矩形范围;可用Center获取中心点坐标.
+func (kn *LkkNumber) GeoHashDecode(hash string) (res GeoBounds, err error) {
+	if hash == "" {
+		err = errors.New("[GeoHashDecode]`hash is empty")
+		return
+	}
+
+	res = GeoBounds{MinLng: -180, MaxLng: 180, MinLat: -90, MaxLat: 90}
+	even := true
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(geoHashBase32, c)
+		if idx < 0 {
+			err = errors.New("[GeoHashDecode]`invalid character in hash")
+			return GeoBounds{}, err
+		}
+
+		for i := 4; i >= 0; i-- {
+			bit := idx >> uint(i) & 1
+			if even {
+				mid := (res.MinLng + res.MaxLng) / 2
+				if bit == 1 {
+					res.MinLng = mid
+				} else {
+					res.MaxLng = mid
+				}
+			} else {
+				mid := (res.MinLat + res.MaxLat) / 2
+				if bit == 1 {
+					res.MinLat = mid
+				} else {
+					res.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return
+}
+
+// GeoHashNeighbors 获取GeoHash相邻的8个格子,顺序为:北,东北,东,东南,南,西南,西,西北.
+// 在两极处不存在的邻居为空字符串;经度方向跨越180度经线时自动环绕.
+func (kn *LkkNumber) GeoHashNeighbors(hash string) ([]string, error) {
+	bounds, err := kn.GeoHashDecode(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	lng, lat := bounds.Center()
+	width, height := bounds.MaxLng-bounds.MinLng, bounds.MaxLat-bounds.MinLat
+	offsets := [8][2]float64{{0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}, {-1, 0}, {-1, 1}}
+
+	res := make([]string, len(offsets))
+	for i, o := range offsets {
+		nlat := lat + o[1]*height
+		if nlat > 90 || nlat < -90 {
+			continue
+		}
+		res[i] = kn.GeoHashEncode(normalizeLng(lng+o[0]*width), nlat, len(hash))
+	}
+
+	return res, nil
+}
+
+// outOfChina 坐标是否在中国境外(境外不做偏移).
+func outOfChina(lng, lat float64) bool {
+	return lng < 72.004 || lng > 137.8347 || lat < 0.8293 || lat > 55.8271
+}
+
+// gcjDelta 计算WGS-84转GCJ-02的偏移量.
+func gcjDelta(lng, lat float64) (float64, float64) {
+	x, y := lng-105.0, lat-35.0
+
+	dLat := -100.0 + 2.0*x + 3.0*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x))
+	dLat += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	dLat += (20.0*math.Sin(y*math.Pi) + 40.0*math.Sin(y/3.0*math.Pi)) * 2.0 / 3.0
+	dLat += (160.0*math.Sin(y/12.0*math.Pi) + 320*math.Sin(y*math.Pi/30.0)) * 2.0 / 3.0
+
+	dLng := 300.0 + x + 2.0*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x))
+	dLng += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	dLng += (20.0*math.Sin(x*math.Pi) + 40.0*math.Sin(x/3.0*math.Pi)) * 2.0 / 3.0
+	dLng += (150.0*math.Sin(x/12.0*math.Pi) + 300.0*math.Sin(x/30.0*math.Pi)) * 2.0 / 3.0
+
+	radLat := deg2rad(lat)
+	magic := 1 - gcjEE*math.Pow(math.Sin(radLat), 2)
+	sqrtMagic := math.Sqrt(magic)
+	dLat = (dLat * 180.0) / ((gcjA * (1 - gcjEE)) / (magic * sqrtMagic) * math.Pi)
+	dLng = (dLng * 180.0) / (gcjA / sqrtMagic * math.Cos(radLat) * math.Pi)
+
+	return dLng, dLat
+}
+
+// WGS84ToGCJ02 WGS-84坐标(GPS)转为GCJ-02坐标(火星坐标,高德/腾讯地图);中国境外不做转换.
+func (kn *LkkNumber) WGS84ToGCJ02(lng, lat float64) (float64, float64) {
+	if outOfChina(lng, lat) {
+		return lng, lat
+	}
+	dLng, dLat := gcjDelta(lng, lat)
+	return lng + dLng, lat + dLat
+}
+
+// GCJ02ToWGS84 GCJ-02坐标转为WGS-84坐标,使用迭代法,误差小于0.01米;中国境外不做转换.
+func (kn *LkkNumber) GCJ02ToWGS84(lng, lat float64) (float64, float64) {
+	if outOfChina(lng, lat) {
+		return lng, lat
+	}
+
+	wLng, wLat := lng, lat
+	for i := 0; i < 10; i++ {
+		gLng, gLat := kn.WGS84ToGCJ02(wLng, wLat)
+		dLng, dLat := gLng-lng, gLat-lat
+		wLng, wLat = wLng-dLng, wLat-dLat
+		if math.Abs(dLng) < 1e-9 && math.Abs(dLat) < 1e-9 {
+			break
+		}
+	}
+
+	return wLng, wLat
+}
+
+// GCJ02ToBD09 GCJ-02坐标转为BD-09坐标(百度地图).
+func (kn *LkkNumber) GCJ02ToBD09(lng, lat float64) (float64, float64) {
+	z := math.Sqrt(lng*lng+lat*lat) + 0.00002*math.Sin(lat*bdXPi)
+	theta := math.Atan2(lat, lng) + 0.000003*math.Cos(lng*bdXPi)
+	return z*math.Cos(theta) + 0.0065, z*math.Sin(theta) + 0.006
+}
+
+// BD09ToGCJ02 BD-09坐标(百度地图)转为GCJ-02坐标.
+func (kn *LkkNumber) BD09ToGCJ02(lng, lat float64) (float64, float64) {
+	x, y := lng-0.0065, lat-0.006
+	z := math.Sqrt(x*x+y*y) - 0.00002*math.Sin(y*bdXPi)
+	theta := math.Atan2(y, x) - 0.000003*math.Cos(x*bdXPi)
+	return z * math.Cos(theta), z * math.Sin(theta)
+}
+
+// WGS84ToBD09 WGS-84坐标(GPS)转为BD-09坐标(百度地图).
+func (kn *LkkNumber) WGS84ToBD09(lng, lat float64) (float64, float64) {
+	return kn.GCJ02ToBD09(kn.WGS84ToGCJ02(lng, lat))
+}
+
+// BD09ToWGS84 BD-09坐标(百度地图)转为WGS-84坐标(GPS).
+func (kn *LkkNumber) BD09ToWGS84(lng, lat float64) (float64, float64) {
+	return kn.GCJ02ToWGS84(kn.BD09ToGCJ02(lng, lat))
+}
@@ -0,0 +1,278 @@
+package kgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeo_HaversineDistance(t *testing.T) {
+	var res float64
+
+	//北京天安门 - 上海人民广场
+	res = KNum.HaversineDistance(116.397428, 39.90923, 121.473701, 31.230416)
+	assert.InDelta(t, 1067000.0, res, 2000)
+
+	res = KNum.HaversineDistance(116.397428, 39.90923, 116.397428, 39.90923)
+	assert.Equal(t, 0.0, res)
+
+	//与余弦公式一致
+	assert.InDelta(t, KNum.GeoDistance(45, 30, 90, 40), KNum.HaversineDistance(45, 30, 90, 40), 1e-3)
+}
+
+func BenchmarkGeo_HaversineDistance(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.HaversineDistance(116.397428, 39.90923, 121.473701, 31.230416)
+	}
+}
+
+func TestGeo_VincentyDistance(t *testing.T) {
+	var res float64
+	var err error
+
+	//Flinders Peak - Buninyong
+	res, err = KNum.VincentyDistance(144.42486789, -37.95103342, 143.92649554, -37.65282114)
+	assert.Nil(t, err)
+	assert.InDelta(t, 54972.271, res, 1e-3)
+
+	res, err = KNum.VincentyDistance(10, 20, 10, 20)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, res)
+
+	//赤道上
+	res, err = KNum.VincentyDistance(0, 0, 1, 0)
+	assert.Nil(t, err)
+	assert.InDelta(t, 111319.491, res, 1e-3)
+
+	//对跖点
+	_, err = KNum.VincentyDistance(0, 0, 179.7, 0.5)
+	assert.Equal(t, ErrVincentyNoConverge, err)
+}
+
+func BenchmarkGeo_VincentyDistance(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.VincentyDistance(144.42486789, -37.95103342, 143.92649554, -37.65282114)
+	}
+}
+
+func TestGeo_GeoBearing(t *testing.T) {
+	assert.InDelta(t, 0.0, KNum.GeoBearing(0, 0, 0, 10), 1e-9)
+	assert.InDelta(t, 90.0, KNum.GeoBearing(0, 0, 10, 0), 1e-9)
+	assert.InDelta(t, 180.0, KNum.GeoBearing(0, 10, 0, 0), 1e-9)
+	assert.InDelta(t, 270.0, KNum.GeoBearing(10, 0, 0, 0), 1e-9)
+}
+
+func BenchmarkGeo_GeoBearing(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GeoBearing(116.397428, 39.90923, 121.473701, 31.230416)
+	}
+}
+
+func TestGeo_GeoDestination(t *testing.T) {
+	lng1, lat1 := 116.397428, 39.90923
+	lng2, lat2 := 121.473701, 31.230416
+	dist := KNum.HaversineDistance(lng1, lat1, lng2, lat2)
+	bearing := KNum.GeoBearing(lng1, lat1, lng2, lat2)
+
+	lng, lat := KNum.GeoDestination(lng1, lat1, dist, bearing)
+	assert.InDelta(t, lng2, lng, 1e-9)
+	assert.InDelta(t, lat2, lat, 1e-9)
+
+	//跨越180度经线
+	lng, _ = KNum.GeoDestination(179.9, 0, 22239, 90)
+	assert.InDelta(t, -179.9, lng, 1e-3)
+}
+
+func BenchmarkGeo_GeoDestination(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GeoDestination(116.397428, 39.90923, 1000, 45)
+	}
+}
+
+func TestGeo_GeoBoundingBox(t *testing.T) {
+	var res GeoBounds
+	lng, lat := 116.397428, 39.90923
+
+	res = KNum.GeoBoundingBox(lng, lat, 1000)
+	assert.True(t, res.Contains(lng, lat))
+	for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		lng2, lat2 := KNum.GeoDestination(lng, lat, 999, bearing)
+		assert.True(t, res.Contains(lng2, lat2))
+	}
+	assert.False(t, res.Contains(lng+0.02, lat))
+
+	//跨越180度经线
+	res = KNum.GeoBoundingBox(179.99, 0, 5000)
+	assert.Greater(t, res.MinLng, res.MaxLng)
+	assert.True(t, res.Contains(-179.99, 0))
+	assert.False(t, res.Contains(0, 0))
+
+	//包含极点
+	res = KNum.GeoBoundingBox(0, 89.99, 5000)
+	assert.Equal(t, 90.0, res.MaxLat)
+	assert.Equal(t, -180.0, res.MinLng)
+}
+
+func BenchmarkGeo_GeoBoundingBox(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GeoBoundingBox(116.397428, 39.90923, 1000)
+	}
+}
+
+func TestGeo_PointInPolygon(t *testing.T) {
+	square := []GeoPoint{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	assert.True(t, KNum.PointInPolygon(5, 5, square))
+	assert.False(t, KNum.PointInPolygon(15, 5, square))
+
+	//凹多边形
+	concave := []GeoPoint{{0, 0}, {10, 0}, {10, 10}, {5, 5}, {0, 10}}
+	assert.True(t, KNum.PointInPolygon(2, 5, concave))
+	assert.False(t, KNum.PointInPolygon(5, 8, concave))
+
+	assert.False(t, KNum.PointInPolygon(0, 0, square[:2]))
+}
+
+func BenchmarkGeo_PointInPolygon(b *testing.B) {
+	square := []GeoPoint{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.PointInPolygon(5, 5, square)
+	}
+}
+
+func TestGeo_GeoHashEncode(t *testing.T) {
+	assert.Equal(t, "ezs42", KNum.GeoHashEncode(-5.6, 42.6, 5))
+	assert.Equal(t, "wx4g09", KNum.GeoHashEncode(116.397428, 39.90923, 6))
+	assert.Equal(t, "e", KNum.GeoHashEncode(-5.6, 42.6, 0))
+	assert.Equal(t, 12, len(KNum.GeoHashEncode(-5.6, 42.6, 20)))
+}
+
+func BenchmarkGeo_GeoHashEncode(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GeoHashEncode(116.397428, 39.90923, 9)
+	}
+}
+
+func TestGeo_GeoHashDecode(t *testing.T) {
+	var res GeoBounds
+	var err error
+
+	res, err = KNum.GeoHashDecode("ezs42")
+	assert.Nil(t, err)
+	lng, lat := res.Center()
+	assert.InDelta(t, -5.603, lng, 1e-3)
+	assert.InDelta(t, 42.605, lat, 1e-3)
+	assert.True(t, res.Contains(-5.6, 42.6))
+
+	res, _ = KNum.GeoHashDecode("EZS42")
+	assert.True(t, res.Contains(-5.6, 42.6))
+
+	_, err = KNum.GeoHashDecode("")
+	assert.NotNil(t, err)
+	_, err = KNum.GeoHashDecode("ezs4a")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkGeo_GeoHashDecode(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.GeoHashDecode("wx4g09np6")
+	}
+}
+
+func TestGeo_GeoHashNeighbors(t *testing.T) {
+	var res []string
+	var err error
+
+	res, err = KNum.GeoHashNeighbors("ezs42")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ezs48", "ezs49", "ezs43", "ezs41", "ezs40", "ezefp", "ezefr", "ezefx"}, res)
+
+	//北极附近
+	res, _ = KNum.GeoHashNeighbors("zzzz")
+	assert.Equal(t, "", res[0])
+	assert.Equal(t, "bpbp", res[2])
+
+	_, err = KNum.GeoHashNeighbors("a")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkGeo_GeoHashNeighbors(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.GeoHashNeighbors("wx4g09np6")
+	}
+}
+
+func TestGeo_WGS84ToGCJ02(t *testing.T) {
+	var lng, lat float64
+
+	lng, lat = KNum.WGS84ToGCJ02(116.397428, 39.90923)
+	assert.InDelta(t, 116.403672, lng, 1e-5)
+	assert.InDelta(t, 39.910632, lat, 1e-5)
+
+	//境外不转换
+	lng, lat = KNum.WGS84ToGCJ02(-0.1275, 51.507222)
+	assert.Equal(t, -0.1275, lng)
+	assert.Equal(t, 51.507222, lat)
+}
+
+func BenchmarkGeo_WGS84ToGCJ02(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.WGS84ToGCJ02(116.397428, 39.90923)
+	}
+}
+
+func TestGeo_GCJ02ToWGS84(t *testing.T) {
+	lng, lat := KNum.GCJ02ToWGS84(KNum.WGS84ToGCJ02(116.397428, 39.90923))
+	assert.InDelta(t, 116.397428, lng, 1e-8)
+	assert.InDelta(t, 39.90923, lat, 1e-8)
+}
+
+func BenchmarkGeo_GCJ02ToWGS84(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GCJ02ToWGS84(116.403672, 39.910632)
+	}
+}
+
+func TestGeo_GCJ02ToBD09(t *testing.T) {
+	lng, lat := KNum.GCJ02ToBD09(116.404, 39.915)
+	assert.InDelta(t, 116.41036949, lng, 1e-8)
+	assert.InDelta(t, 39.92133699, lat, 1e-8)
+
+	lng, lat = KNum.BD09ToGCJ02(lng, lat)
+	assert.InDelta(t, 116.404, lng, 1e-5)
+	assert.InDelta(t, 39.915, lat, 1e-5)
+}
+
+func BenchmarkGeo_GCJ02ToBD09(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.GCJ02ToBD09(116.403672, 39.910632)
+	}
+}
+
+func TestGeo_WGS84ToBD09(t *testing.T) {
+	lng, lat := KNum.WGS84ToBD09(116.397428, 39.90923)
+	assert.InDelta(t, 116.410044, lng, 1e-5)
+	assert.InDelta(t, 39.916973, lat, 1e-5)
+
+	lng, lat = KNum.BD09ToWGS84(lng, lat)
+	assert.InDelta(t, 116.397428, lng, 1e-6)
+	assert.InDelta(t, 39.90923, lat, 1e-6)
+}
+
+func BenchmarkGeo_WGS84ToBD09(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.WGS84ToBD09(116.397428, 39.90923)
+	}
+}
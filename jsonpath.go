@@ -0,0 +1,824 @@
+package kgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonPatchOp RFC 6902 JSON Patch的操作
+type jsonPatchOp struct {
+	Op    string          `json:"op"`    //操作:add、remove、replace、move、copy、test
+	Path  string          `json:"path"`  //目标位置,JSON Pointer
+	From  *string         `json:"from"`  //来源位置,用于move和copy
+	Value json.RawMessage `json:"value"` //值,用于add、replace和test
+}
+
+var (
+	// ErrJsonPathNotFound JSON路径不存在
+	ErrJsonPathNotFound = errors.New("[Json]`path not found")
+	// ErrJsonPatchTest JSON Patch的test操作失败
+	ErrJsonPatchTest = errors.New("[Json]`patch test failed")
+)
+
+// jsonDecode 解码JSON,数值保留为json.Number.
+func jsonDecode(str []byte) (interface{}, error) {
+	var res interface{}
+	dec := json.NewDecoder(bytes.NewReader(str))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		return nil, err
+	} else if _, err = dec.Token(); err != io.EOF {
+		return nil, errors.New("[Json]`invalid character after top-level value")
+	}
+	return res, nil
+}
+
+// jsonEncode 编码JSON,不转义HTML字符.
+func jsonEncode(val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// jsonNumber2Float 将结果中的json.Number递归转为float64.
+func jsonNumber2Float(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = jsonNumber2Float(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonNumber2Float(item)
+		}
+	}
+	return val
+}
+
+// jsonEqual 比较两个JSON值是否相等,数值按大小比较.
+func jsonEqual(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, _, errx := big.ParseFloat(string(x), 10, 256, big.ToNearestEven)
+		fy, _, erry := big.ParseFloat(string(y), 10, 256, big.ToNearestEven)
+		if errx != nil || erry != nil {
+			return x == y
+		}
+		return fx.Cmp(fy) == 0
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, item := range x {
+			if other, ok := y[k]; !ok || !jsonEqual(item, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// jsonSplitPath 将点分隔的路径拆分为各段,"\."表示键中的点.
+func jsonSplitPath(path string) []string {
+	var res []string
+	var seg strings.Builder
+	depth := 0
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path):
+			i++
+			seg.WriteByte(path[i])
+			continue
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '.' && depth == 0:
+			res = append(res, seg.String())
+			seg.Reset()
+			continue
+		}
+		seg.WriteByte(c)
+	}
+	return append(res, seg.String())
+}
+
+// JsonGet 按路径获取JSON中的值,路径不存在时返回 ErrJsonPathNotFound .
+// 路径以"."分隔,如"users.0.name";键中的"."用"\."表示;
+// "#"为数组长度,"users.#.name"获取数组每个元素的name;
+// "users.#(age>=18).name"获取第一个满足条件的元素的name,"users.#(age>=18)#.name"获取所有满足条件的;
+// 条件的运算符有==、!=、<、<=、>、>=,值为JSON字面量(如"str"、1、true)或不带引号的字符串.
+// 结果同JsonDecode解码到interface{},数值为float64.
+func (ks *LkkString) JsonGet(str []byte, path string) (interface{}, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok, err := jsonGetPath(doc, jsonSplitPath(path))
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("%s: %w", path, ErrJsonPathNotFound)
+	}
+	return jsonNumber2Float(res), nil
+}
+
+// jsonGetPath 按路径段获取值.
+func jsonGetPath(val interface{}, segs []string) (interface{}, bool, error) {
+	if len(segs) == 0 {
+		return val, true, nil
+	}
+
+	seg, rest := segs[0], segs[1:]
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if item, ok := v[seg]; ok {
+			return jsonGetPath(item, rest)
+		}
+	case []interface{}:
+		if seg == "#" {
+			if len(rest) == 0 {
+				return json.Number(strconv.Itoa(len(v))), true, nil
+			}
+			return jsonGetEach(v, rest)
+		} else if strings.HasPrefix(seg, "#(") {
+			all := strings.HasSuffix(seg, ")#")
+			cond := strings.TrimSuffix(strings.TrimSuffix(seg[2:], "#"), ")")
+			matches, err := jsonQuery(v, cond, all)
+			if err != nil {
+				return nil, false, err
+			} else if all {
+				return jsonGetEach(matches, rest)
+			} else if len(matches) == 0 {
+				return nil, false, nil
+			}
+			return jsonGetPath(matches[0], rest)
+		}
+
+		idx, err := strconv.Atoi(seg)
+		if err == nil && idx >= 0 && idx < len(v) {
+			return jsonGetPath(v[idx], rest)
+		}
+	}
+	return nil, false, nil
+}
+
+// jsonGetEach 对数组的每个元素获取路径的值,忽略不存在的.
+func jsonGetEach(arr []interface{}, segs []string) (interface{}, bool, error) {
+	res := make([]interface{}, 0, len(arr))
+	for _, item := range arr {
+		val, ok, err := jsonGetPath(item, segs)
+		if err != nil {
+			return nil, false, err
+		} else if ok {
+			res = append(res, val)
+		}
+	}
+	return res, true, nil
+}
+
+// jsonQuery 查找数组中满足条件cond的元素;all为false时只返回第一个.
+func jsonQuery(arr []interface{}, cond string, all bool) ([]interface{}, error) {
+	var key, op, literal string
+	for _, o := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if i := strings.Index(cond, o); i > 0 {
+			key, op, literal = strings.TrimSpace(cond[:i]), o, strings.TrimSpace(cond[i+len(o):])
+			break
+		}
+	}
+	if op == "" {
+		return nil, fmt.Errorf("[JsonGet]`invalid query %q", cond)
+	}
+
+	want, err := jsonDecode([]byte(literal))
+	if err != nil {
+		want = literal
+	}
+
+	var res []interface{}
+	for _, item := range arr {
+		val, ok, err := jsonGetPath(item, jsonSplitPath(key))
+		if err != nil {
+			return nil, err
+		} else if ok && jsonCompare(val, op, want) {
+			res = append(res, item)
+			if !all {
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// jsonCompare 按运算符op比较a和b;数值按大小比较,字符串按字典序比较.
+func jsonCompare(a interface{}, op string, b interface{}) bool {
+	switch op {
+	case "==":
+		return jsonEqual(a, b)
+	case "!=":
+		return !jsonEqual(a, b)
+	}
+
+	var cmp int
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, _ := x.Float64()
+		fy, _ := y.Float64()
+		if fx < fy {
+			cmp = -1
+		} else if fx > fy {
+			cmp = 1
+		}
+	} else if x, ok := a.(string); ok {
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(x, y)
+	} else {
+		return false
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// JsonSet 按路径设置JSON中的值,返回新的JSON(对象的键按名称排序).
+// 路径格式同 JsonGet ,但不支持"#";不存在的中间路径将创建为对象;
+// 数组的索引为其长度或-1时追加元素,超出长度时以null填充.
+func (ks *LkkString) JsonSet(str []byte, path string, value interface{}) ([]byte, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc, err = jsonSetPath(doc, jsonSplitPath(path), value); err != nil {
+		return nil, err
+	}
+	return jsonEncode(doc)
+}
+
+// jsonSetPath 按路径段设置值,返回更新后的容器.
+func jsonSetPath(val interface{}, segs []string, value interface{}) (interface{}, error) {
+	if len(segs) == 0 {
+		return value, nil
+	}
+
+	seg, rest := segs[0], segs[1:]
+	if strings.HasPrefix(seg, "#") {
+		return nil, fmt.Errorf("[JsonSet]`unsupported path segment %q", seg)
+	}
+
+	var err error
+	switch v := val.(type) {
+	case nil:
+		if seg == "-1" {
+			item, err := jsonSetPath(nil, rest, value)
+			return []interface{}{item}, err
+		}
+		item, err := jsonSetPath(nil, rest, value)
+		return map[string]interface{}{seg: item}, err
+	case map[string]interface{}:
+		v[seg], err = jsonSetPath(v[seg], rest, value)
+		return v, err
+	case []interface{}:
+		idx, e := strconv.Atoi(seg)
+		if e != nil || idx < -1 {
+			return nil, fmt.Errorf("[JsonSet]`invalid array index %q", seg)
+		} else if idx == -1 {
+			idx = len(v)
+		}
+		for len(v) <= idx {
+			v = append(v, nil)
+		}
+		v[idx], err = jsonSetPath(v[idx], rest, value)
+		return v, err
+	}
+	return nil, fmt.Errorf("[JsonSet]`cannot set %q on %T", seg, val)
+}
+
+// JsonDelete 按路径删除JSON中的值,返回新的JSON(对象的键按名称排序);路径不存在时不作修改.
+// 路径格式同 JsonSet .
+func (ks *LkkString) JsonDelete(str []byte, path string) ([]byte, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonEncode(jsonDeletePath(doc, jsonSplitPath(path)))
+}
+
+// jsonDeletePath 按路径段删除值,返回更新后的容器.
+func jsonDeletePath(val interface{}, segs []string) interface{} {
+	if len(segs) == 0 {
+		return val
+	}
+
+	seg, last := segs[0], len(segs) == 1
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if item, ok := v[seg]; !ok {
+			break
+		} else if last {
+			delete(v, seg)
+		} else {
+			v[seg] = jsonDeletePath(item, segs[1:])
+		}
+	case []interface{}:
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx >= len(v) {
+			break
+		} else if last {
+			return append(v[:idx], v[idx+1:]...)
+		}
+		v[idx] = jsonDeletePath(v[idx], segs[1:])
+	}
+	return val
+}
+
+// jsonPointer 解析RFC 6901 JSON Pointer,如"/a/b~1c/0".
+func jsonPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	} else if pointer[0] != '/' {
+		return nil, fmt.Errorf("[JsonPatch]`invalid pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// jsonPointerEscape 转义JSON Pointer中的键.
+func jsonPointerEscape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// jsonArrayIndex 解析数组索引,须小于size;allowEnd为true时可等于size或为"-".
+func jsonArrayIndex(token string, size int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return size, nil
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q: %w", token, ErrJsonPathNotFound)
+	} else if idx > size || (idx == size && !allowEnd) {
+		return 0, fmt.Errorf("array index %d out of range: %w", idx, ErrJsonPathNotFound)
+	}
+	return idx, nil
+}
+
+// jsonPointerGet 获取JSON Pointer指向的值.
+func jsonPointerGet(doc interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			item, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("key %q: %w", token, ErrJsonPathNotFound)
+			}
+			doc = item
+		case []interface{}:
+			idx, err := jsonArrayIndex(token, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = v[idx]
+		default:
+			return nil, fmt.Errorf("key %q: %w", token, ErrJsonPathNotFound)
+		}
+	}
+	return doc, nil
+}
+
+// jsonPointerApply 沿tokens找到父容器,对最后一个键执行fn,返回更新后的文档.
+func jsonPointerApply(doc interface{}, tokens []string, fn func(container interface{}, key string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+
+	token := tokens[0]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		item, ok := v[token]
+		if !ok {
+			return nil, fmt.Errorf("key %q: %w", token, ErrJsonPathNotFound)
+		}
+		item, err := jsonPointerApply(item, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		v[token] = item
+		return v, nil
+	case []interface{}:
+		idx, err := jsonArrayIndex(token, len(v), false)
+		if err != nil {
+			return nil, err
+		}
+		if v[idx], err = jsonPointerApply(v[idx], tokens[1:], fn); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %q: %w", token, ErrJsonPathNotFound)
+}
+
+// jsonPointerAdd 在JSON Pointer处添加值,数组中为插入.
+func jsonPointerAdd(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return jsonPointerApply(doc, tokens, func(container interface{}, key string) (interface{}, error) {
+		switch v := container.(type) {
+		case map[string]interface{}:
+			v[key] = value
+			return v, nil
+		case []interface{}:
+			idx, err := jsonArrayIndex(key, len(v), true)
+			if err != nil {
+				return nil, err
+			}
+			v = append(v, nil)
+			copy(v[idx+1:], v[idx:])
+			v[idx] = value
+			return v, nil
+		}
+		return nil, fmt.Errorf("key %q: %w", key, ErrJsonPathNotFound)
+	})
+}
+
+// jsonPointerRemove 删除JSON Pointer处的值,值须存在.
+func jsonPointerRemove(doc interface{}, tokens []string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return jsonPointerApply(doc, tokens, func(container interface{}, key string) (interface{}, error) {
+		switch v := container.(type) {
+		case map[string]interface{}:
+			if _, ok := v[key]; !ok {
+				return nil, fmt.Errorf("key %q: %w", key, ErrJsonPathNotFound)
+			}
+			delete(v, key)
+			return v, nil
+		case []interface{}:
+			idx, err := jsonArrayIndex(key, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(v[:idx], v[idx+1:]...), nil
+		}
+		return nil, fmt.Errorf("key %q: %w", key, ErrJsonPathNotFound)
+	})
+}
+
+// jsonPointerReplace 替换JSON Pointer处的值,值须存在.
+func jsonPointerReplace(doc interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return jsonPointerApply(doc, tokens, func(container interface{}, key string) (interface{}, error) {
+		switch v := container.(type) {
+		case map[string]interface{}:
+			if _, ok := v[key]; !ok {
+				return nil, fmt.Errorf("key %q: %w", key, ErrJsonPathNotFound)
+			}
+			v[key] = value
+			return v, nil
+		case []interface{}:
+			idx, err := jsonArrayIndex(key, len(v), false)
+			if err != nil {
+				return nil, err
+			}
+			v[idx] = value
+			return v, nil
+		}
+		return nil, fmt.Errorf("key %q: %w", key, ErrJsonPathNotFound)
+	})
+}
+
+// JsonPatch 对JSON文档应用RFC 6902 JSON Patch,返回新的JSON(对象的键按名称排序).
+// 支持add、remove、replace、move、copy、test操作;任一操作失败时返回错误,文档不作修改;
+// 路径不存在时错误可用errors.Is判断为 ErrJsonPathNotFound ,test失败时为 ErrJsonPatchTest .
+func (ks *LkkString) JsonPatch(str []byte, patch []byte) ([]byte, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []jsonPatchOp
+	dec := json.NewDecoder(bytes.NewReader(patch))
+	dec.UseNumber()
+	if err = dec.Decode(&ops); err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		if doc, err = jsonApplyOp(doc, op); err != nil {
+			return nil, fmt.Errorf("[JsonPatch]`operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return jsonEncode(doc)
+}
+
+// jsonApplyOp 应用一个JSON Patch操作.
+func jsonApplyOp(doc interface{}, op jsonPatchOp) (interface{}, error) {
+	path, err := jsonPointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		} else if value, err = jsonDecode(op.Value); err != nil {
+			return nil, err
+		}
+	case "move", "copy":
+		if op.From == nil {
+			return nil, errors.New("missing from")
+		}
+	}
+
+	switch op.Op {
+	case "add":
+		return jsonPointerAdd(doc, path, value)
+	case "remove":
+		return jsonPointerRemove(doc, path)
+	case "replace":
+		return jsonPointerReplace(doc, path, value)
+	case "test":
+		val, err := jsonPointerGet(doc, path)
+		if err != nil {
+			return nil, err
+		} else if !jsonEqual(val, value) {
+			return nil, ErrJsonPatchTest
+		}
+		return doc, nil
+	case "move", "copy":
+		from, err := jsonPointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		val, err := jsonPointerGet(doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "copy" {
+			val = KArr.DeepCopy(val)
+		} else if *op.From == op.Path {
+			return doc, nil
+		} else if strings.HasPrefix(op.Path, *op.From+"/") {
+			return nil, errors.New("cannot move a value into its own child")
+		} else if doc, err = jsonPointerRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(doc, path, val)
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+// JsonDiff 比较两个JSON文档,生成将a转换为b的RFC 6902 JSON Patch.
+// 对象逐键比较;数组逐个元素比较,长度不同时在末尾添加或删除元素.
+func (ks *LkkString) JsonDiff(a, b []byte) ([]byte, error) {
+	docA, err := jsonDecode(a)
+	if err != nil {
+		return nil, err
+	}
+	docB, err := jsonDecode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]map[string]interface{}, 0)
+	jsonDiff("", docA, docB, &ops)
+	return jsonEncode(ops)
+}
+
+// jsonDiff 比较path处的值a和b,将操作加入ops.
+func jsonDiff(path string, a, b interface{}, ops *[]map[string]interface{}) {
+	switch x := a.(type) {
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(x)+len(y))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		for k := range y {
+			if _, ok := x[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			sub := path + "/" + jsonPointerEscape(k)
+			vx, inX := x[k]
+			vy, inY := y[k]
+			if !inY {
+				*ops = append(*ops, map[string]interface{}{"op": "remove", "path": sub})
+			} else if !inX {
+				*ops = append(*ops, map[string]interface{}{"op": "add", "path": sub, "value": vy})
+			} else {
+				jsonDiff(sub, vx, vy, ops)
+			}
+		}
+		return
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+
+		n := len(x)
+		if len(y) < n {
+			n = len(y)
+		}
+		for i := 0; i < n; i++ {
+			jsonDiff(path+"/"+strconv.Itoa(i), x[i], y[i], ops)
+		}
+		for i := n; i < len(y); i++ {
+			*ops = append(*ops, map[string]interface{}{"op": "add", "path": path + "/" + strconv.Itoa(i), "value": y[i]})
+		}
+		for i := len(x) - 1; i >= n; i-- {
+			*ops = append(*ops, map[string]interface{}{"op": "remove", "path": path + "/" + strconv.Itoa(i)})
+		}
+		return
+	}
+
+	if !jsonEqual(a, b) {
+		*ops = append(*ops, map[string]interface{}{"op": "replace", "path": path, "value": b})
+	}
+}
+
+// JsonMergePatch 对JSON文档应用RFC 7386 JSON Merge Patch,返回新的JSON(对象的键按名称排序).
+// patch中值为null的键将被删除,对象递归合并,其他值直接替换.
+func (ks *LkkString) JsonMergePatch(str []byte, patch []byte) ([]byte, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+	p, err := jsonDecode(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonEncode(jsonMergePatch(doc, p))
+}
+
+// jsonMergePatch 将patch合并到target.
+func jsonMergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = jsonMergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+// JsonPretty 格式化JSON,保持键的顺序;indent为缩进,默认4个空格.
+func (ks *LkkString) JsonPretty(str []byte, indent ...string) ([]byte, error) {
+	ind := "    "
+	if len(indent) > 0 {
+		ind = indent[0]
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, str, "", ind); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JsonMinify 压缩JSON,去除所有空白,保持键的顺序.
+func (ks *LkkString) JsonMinify(str []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, str); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JsonCanonicalize 规范化JSON:对象的键按名称排序,去除空白,不转义HTML字符,
+// 数值按ECMAScript的格式输出(如1.0为1,1e21为1e+21),以便比较和签名.
+func (ks *LkkString) JsonCanonicalize(str []byte) ([]byte, error) {
+	doc, err := jsonDecode(str)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = jsonCanonicalNumber(doc)
+	if err != nil {
+		return nil, err
+	}
+	return jsonEncode(doc)
+}
+
+// jsonCanonicalNumber 将结果中的数值递归转为ECMAScript格式.
+func jsonCanonicalNumber(val interface{}) (interface{}, error) {
+	var err error
+	switch v := val.(type) {
+	case json.Number:
+		f, e := strconv.ParseFloat(string(v), 64)
+		if e != nil || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("[JsonCanonicalize]`%s: %w", v, ErrNumberOverflow)
+		}
+		return json.Number(jsonFormatNumber(f)), nil
+	case map[string]interface{}:
+		for k, item := range v {
+			if v[k], err = jsonCanonicalNumber(item); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if v[i], err = jsonCanonicalNumber(item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return val, nil
+}
+
+// jsonFormatNumber 按ECMAScript的Number.prototype.toString格式化浮点数.
+func jsonFormatNumber(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+
+	abs := math.Abs(f)
+	if abs >= 1e21 || abs < 1e-6 {
+		//指数形式,去掉指数前导的0,如1e-07为1e-7
+		res := strconv.FormatFloat(f, 'e', -1, 64)
+		mant, exp := res[:strings.IndexByte(res, 'e')], res[strings.IndexByte(res, 'e')+1:]
+		sign := exp[0]
+		exp = strings.TrimLeft(exp[1:], "0")
+		return mant + "e" + string(sign) + exp
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,374 @@
+package kgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testJsonDoc = `{
+	"name": {"first": "Tom", "last": "Anderson"},
+	"age": 37,
+	"children": ["Sara", "Alex", "Jack"],
+	"fav.movie": "Deer Hunter",
+	"friends": [
+		{"first": "Dale", "last": "Murphy", "age": 44, "nets": ["ig", "fb", "tw"]},
+		{"first": "Roger", "last": "Craig", "age": 68, "nets": ["fb", "tw"]},
+		{"first": "Jane", "last": "Murphy", "age": 47, "nets": ["ig", "tw"]}
+	]
+}`
+
+func TestJsonPath_JsonGet(t *testing.T) {
+	var res interface{}
+	var err error
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{"name.last", "Anderson"},
+		{"age", 37.0},
+		{"children", []interface{}{"Sara", "Alex", "Jack"}},
+		{"children.#", 3.0},
+		{"children.1", "Alex"},
+		{`fav\.movie`, "Deer Hunter"},
+		{"friends.#.first", []interface{}{"Dale", "Roger", "Jane"}},
+		{"friends.1.last", "Craig"},
+		{"friends.#(last==Murphy).first", "Dale"},
+		{`friends.#(last=="Murphy")#.first`, []interface{}{"Dale", "Jane"}},
+		{"friends.#(age>45)#.last", []interface{}{"Craig", "Murphy"}},
+		{"friends.#(first!=Dale)#.age", []interface{}{68.0, 47.0}},
+		{"friends.#(nets.0==fb).first", "Roger"},
+		{"friends.#(first>=Jane)#.first", []interface{}{"Roger", "Jane"}},
+		{"friends.#(age<=44)#.first", []interface{}{"Dale"}},
+		{"friends.#(age<0)#.first", []interface{}{}},
+	}
+	for _, test := range tests {
+		res, err = KStr.JsonGet([]byte(testJsonDoc), test.path)
+		assert.Nil(t, err, test.path)
+		assert.Equal(t, test.want, res, test.path)
+	}
+
+	notFound := []string{"name.middle", "children.5", "children.-1", "age.x", "friends.#(age>100).first", "friends.#(first==1).age"}
+	for _, path := range notFound {
+		_, err = KStr.JsonGet([]byte(testJsonDoc), path)
+		assert.True(t, errors.Is(err, ErrJsonPathNotFound), path)
+	}
+
+	_, err = KStr.JsonGet([]byte(testJsonDoc), "friends.#(age).first")
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrJsonPathNotFound))
+	_, err = KStr.JsonGet([]byte(`{"a":1} x`), "a")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonGet(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonGet(data, "friends.#(age>45)#.last")
+	}
+}
+
+func TestJsonPath_JsonSet(t *testing.T) {
+	var res []byte
+	var err error
+
+	doc := []byte(`{"a":{"b":1},"list":[1,2],"big":12345678901234567890}`)
+	res, err = KStr.JsonSet(doc, "a.c", "x<y")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1,"c":"x<y"},"big":12345678901234567890,"list":[1,2]}`, string(res))
+
+	res, err = KStr.JsonSet(doc, "list.-1", map[string]int{"n": 3})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1},"big":12345678901234567890,"list":[1,2,{"n":3}]}`, string(res))
+
+	res, err = KStr.JsonSet(doc, "list.3", true)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1},"big":12345678901234567890,"list":[1,2,null,true]}`, string(res))
+
+	res, err = KStr.JsonSet(doc, "new.deep.-1", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1},"big":12345678901234567890,"list":[1,2],"new":{"deep":[1]}}`, string(res))
+
+	res, err = KStr.JsonSet([]byte(`null`), "", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"":1}`, string(res))
+
+	_, err = KStr.JsonSet(doc, "a.b.c", 1)
+	assert.NotNil(t, err)
+	_, err = KStr.JsonSet(doc, "list.x", 1)
+	assert.NotNil(t, err)
+	_, err = KStr.JsonSet(doc, "list.#", 1)
+	assert.NotNil(t, err)
+	_, err = KStr.JsonSet(doc, "a", make(chan int))
+	assert.NotNil(t, err)
+	_, err = KStr.JsonSet([]byte(`{`), "a", 1)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonSet(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonSet(data, "name.middle", "K")
+	}
+}
+
+func TestJsonPath_JsonDelete(t *testing.T) {
+	var res []byte
+	var err error
+
+	doc := []byte(`{"a":{"b":1,"c":2},"list":[1,2,3]}`)
+	res, err = KStr.JsonDelete(doc, "a.b")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"c":2},"list":[1,2,3]}`, string(res))
+
+	res, err = KStr.JsonDelete(doc, "list.1")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1,"c":2},"list":[1,3]}`, string(res))
+
+	res, err = KStr.JsonDelete(doc, "x.y")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":1,"c":2},"list":[1,2,3]}`, string(res))
+
+	_, err = KStr.JsonDelete([]byte(`[`), "a")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonDelete(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonDelete(data, "friends.0")
+	}
+}
+
+func TestJsonPath_JsonPatch(t *testing.T) {
+	var res []byte
+	var err error
+
+	//RFC 6902 附录A的示例
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"child":{"grandchild":{}},"foo":"bar"}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"/":1,"~":2}`, `[{"op":"copy","from":"/~1","path":"/~0x"},{"op":"add","path":"/n","value":null}]`, `{"/":1,"n":null,"~":2,"~x":1}`},
+		{`{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+		{`{"a":1}`, `[{"op":"move","from":"/a","path":"/a"}]`, `{"a":1}`},
+	}
+	for _, test := range tests {
+		res, err = KStr.JsonPatch([]byte(test.doc), []byte(test.patch))
+		assert.Nil(t, err, test.patch)
+		assert.Equal(t, test.want, string(res), test.patch)
+	}
+
+	_, err = KStr.JsonPatch([]byte(`{"baz":"qux"}`), []byte(`[{"op":"test","path":"/baz","value":"bar"}]`))
+	assert.True(t, errors.Is(err, ErrJsonPatchTest))
+
+	notFound := []string{
+		`[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+		`[{"op":"remove","path":"/nope"}]`,
+		`[{"op":"replace","path":"/list/5","value":1}]`,
+		`[{"op":"add","path":"/list/01","value":1}]`,
+		`[{"op":"move","from":"/nope","path":"/a"}]`,
+		`[{"op":"test","path":"/list/-","value":1}]`,
+	}
+	for _, patch := range notFound {
+		_, err = KStr.JsonPatch([]byte(`{"foo":"bar","list":[1]}`), []byte(patch))
+		assert.True(t, errors.Is(err, ErrJsonPathNotFound), patch)
+	}
+
+	invalid := []string{
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"copy","path":"/a"}]`,
+		`[{"op":"jump","path":"/a"}]`,
+		`[{"op":"add","path":"a","value":1}]`,
+		`[{"op":"move","from":"/foo","path":"/foo/x"}]`,
+		`{"op":"add"}`,
+	}
+	for _, patch := range invalid {
+		_, err = KStr.JsonPatch([]byte(`{"foo":{}}`), []byte(patch))
+		assert.NotNil(t, err, patch)
+	}
+	_, err = KStr.JsonPatch([]byte(`{`), []byte(`[]`))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonPatch(b *testing.B) {
+	data := []byte(testJsonDoc)
+	patch := []byte(`[{"op":"replace","path":"/age","value":38},{"op":"add","path":"/children/-","value":"Tim"}]`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonPatch(data, patch)
+	}
+}
+
+func TestJsonPath_JsonDiff(t *testing.T) {
+	var res []byte
+	var err error
+
+	a := `{"a":1,"b":{"c":[1,2,3],"d":"x"},"e":null,"f":1.0}`
+	b := `{"a":2,"b":{"c":[1,5],"g":true},"e":null,"f":1,"h":[]}`
+	res, err = KStr.JsonDiff([]byte(a), []byte(b))
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"op":"replace","path":"/a","value":2},{"op":"replace","path":"/b/c/1","value":5},{"op":"remove","path":"/b/c/2"},{"op":"remove","path":"/b/d"},{"op":"add","path":"/b/g","value":true},{"op":"add","path":"/h","value":[]}]`, string(res))
+
+	//应用生成的补丁后与b相同
+	patched, err := KStr.JsonPatch([]byte(a), res)
+	assert.Nil(t, err)
+	canonA, _ := KStr.JsonCanonicalize(patched)
+	canonB, _ := KStr.JsonCanonicalize([]byte(b))
+	assert.Equal(t, string(canonB), string(canonA))
+
+	res, err = KStr.JsonDiff([]byte(`[1]`), []byte(`[1,{"x":null},2]`))
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"op":"add","path":"/1","value":{"x":null}},{"op":"add","path":"/2","value":2}]`, string(res))
+
+	res, err = KStr.JsonDiff([]byte(`{"a/b":1}`), []byte(`"str"`))
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"op":"replace","path":"","value":"str"}]`, string(res))
+
+	res, err = KStr.JsonDiff([]byte(`{"a~b":1}`), []byte(`{}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"op":"remove","path":"/a~0b"}]`, string(res))
+
+	res, err = KStr.JsonDiff([]byte(`{"a":1}`), []byte(`{"a":1}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(res))
+
+	_, err = KStr.JsonDiff([]byte(`{`), []byte(`{}`))
+	assert.NotNil(t, err)
+	_, err = KStr.JsonDiff([]byte(`{}`), []byte(`}`))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonDiff(b *testing.B) {
+	x := []byte(`{"a":1,"b":{"c":[1,2,3],"d":"x"}}`)
+	y := []byte(`{"a":2,"b":{"c":[1,5],"g":true}}`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonDiff(x, y)
+	}
+}
+
+func TestJsonPath_JsonMergePatch(t *testing.T) {
+	var res []byte
+	var err error
+
+	//RFC 7386 附录A的示例
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, test := range tests {
+		res, err = KStr.JsonMergePatch([]byte(test.doc), []byte(test.patch))
+		assert.Nil(t, err, test.patch)
+		assert.Equal(t, test.want, string(res), test.patch)
+	}
+
+	_, err = KStr.JsonMergePatch([]byte(`{`), []byte(`{}`))
+	assert.NotNil(t, err)
+	_, err = KStr.JsonMergePatch([]byte(`{}`), []byte(`{`))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonMergePatch(b *testing.B) {
+	data := []byte(testJsonDoc)
+	patch := []byte(`{"age":38,"name":{"middle":"K","last":null}}`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonMergePatch(data, patch)
+	}
+}
+
+func TestJsonPath_JsonPretty(t *testing.T) {
+	res, err := KStr.JsonPretty([]byte(`{"b":1,"a":[1,{}]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n    \"b\": 1,\n    \"a\": [\n        1,\n        {}\n    ]\n}", string(res))
+
+	res, err = KStr.JsonPretty([]byte(`{"b":1}`), "\t")
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n\t\"b\": 1\n}", string(res))
+
+	_, err = KStr.JsonPretty([]byte(`{"b":}`))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonPretty(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonPretty(data)
+	}
+}
+
+func TestJsonPath_JsonMinify(t *testing.T) {
+	res, err := KStr.JsonMinify([]byte("{\n  \"b\" : 1,\n  \"a\": [ 1, \"x y\" ]\n}"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":1,"a":[1,"x y"]}`, string(res))
+
+	_, err = KStr.JsonMinify([]byte(`[1,`))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkJsonPath_JsonMinify(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonMinify(data)
+	}
+}
+
+func TestJsonPath_JsonCanonicalize(t *testing.T) {
+	res, err := KStr.JsonCanonicalize([]byte(`{"b": [1.0, 1e21, 1.5e-7, 0.000001, -0.0, 100, 1E2], "a": "<&>", "c": {"z": true, "y": null}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"<&>","b":[1,1e+21,1.5e-7,0.000001,0,100,100],"c":{"y":null,"z":true}}`, string(res))
+
+	_, err = KStr.JsonCanonicalize([]byte(`[1e400]`))
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KStr.JsonCanonicalize([]byte(`[`))
+	assert.NotNil(t, err)
+
+	//顶层值之后的多余数据
+	for _, str := range []string{`{"a":1}}`, `[1]]`, `1 2`, `{"a":1}{}`} {
+		_, err = KStr.JsonCanonicalize([]byte(str))
+		assert.NotNil(t, err, str)
+	}
+	res, err = KStr.JsonCanonicalize([]byte("{\"a\":1} \n"))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1}`, string(res))
+}
+
+func BenchmarkJsonPath_JsonCanonicalize(b *testing.B) {
+	data := []byte(testJsonDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.JsonCanonicalize(data)
+	}
+}
@@ -1,6 +1,7 @@
 package kgo
 
 import (
+	"errors"
 	"net"
 	"regexp"
 	"time"
@@ -15,12 +16,15 @@ type (
 	}
 	// LkkNumber is the receiver of number utilities
 	LkkNumber struct {
+		source RandSource
 	}
 	// LkkArray is the receiver of array utilities
 	LkkArray struct {
+		source RandSource
 	}
 	// LkkTime is the receiver of time utilities
 	LkkTime struct {
+		clock Clock
 	}
 	// LkkConvert is the receiver of convert utilities
 	LkkConvert struct {
@@ -51,6 +55,20 @@ type (
 	LkkPKCSType int8
 	// LkkArrCompareType 枚举类型,数组比较方式
 	LkkArrCompareType uint8
+	// LkkLocale 枚举类型,语言区域
+	LkkLocale uint8
+	// LkkDurationStyle 枚举类型,时长格式化风格
+	LkkDurationStyle uint8
+	// LkkPeriodUnit 枚举类型,时间段的步长单位
+	LkkPeriodUnit uint8
+	// LkkRoundMode 枚举类型,小数舍入模式
+	LkkRoundMode uint8
+	// LkkQuantileMethod 枚举类型,分位数插值方法
+	LkkQuantileMethod uint8
+	// LkkByteStandard 枚举类型,字节大小的单位标准
+	LkkByteStandard uint8
+	// LkkUnitDimension 枚举类型,计量单位的量纲
+	LkkUnitDimension uint8
 
 	// FileFilter 文件过滤函数
 	FileFilter func(string) bool
@@ -160,6 +178,77 @@ const (
 	// COMPARE_BOTH_KEYVALUE 同时比较键和值
 	COMPARE_BOTH_KEYVALUE LkkArrCompareType = 2
 
+	// LOCALE_EN 语言区域,英文
+	LOCALE_EN LkkLocale = 0
+	// LOCALE_ZH_CN 语言区域,简体中文
+	LOCALE_ZH_CN LkkLocale = 1
+
+	// DURATION_SHORT 时长格式,简写,如"2d 4h 13m"
+	DURATION_SHORT LkkDurationStyle = 0
+	// DURATION_LONG 时长格式,英文全称,如"2 days 4 hours 13 minutes"
+	DURATION_LONG LkkDurationStyle = 1
+	// DURATION_CHINESE 时长格式,中文,如"2天4小时13分钟"
+	DURATION_CHINESE LkkDurationStyle = 2
+
+	// PERIOD_DURATION 时间段步长,按固定时长
+	PERIOD_DURATION LkkPeriodUnit = 0
+	// PERIOD_DAY 时间段步长,按天
+	PERIOD_DAY LkkPeriodUnit = 1
+	// PERIOD_WEEK 时间段步长,按周(周一为第一天)
+	PERIOD_WEEK LkkPeriodUnit = 2
+	// PERIOD_MONTH 时间段步长,按月
+	PERIOD_MONTH LkkPeriodUnit = 3
+	// PERIOD_QUARTER 时间段步长,按季度
+	PERIOD_QUARTER LkkPeriodUnit = 4
+	// PERIOD_YEAR 时间段步长,按年
+	PERIOD_YEAR LkkPeriodUnit = 5
+
+	// ROUND_HALF_UP 舍入模式,四舍五入(远离零)
+	ROUND_HALF_UP LkkRoundMode = 0
+	// ROUND_HALF_EVEN 舍入模式,四舍六入五成双
+	ROUND_HALF_EVEN LkkRoundMode = 1
+	// ROUND_BANKERS 舍入模式,银行家舍入,同ROUND_HALF_EVEN
+	ROUND_BANKERS = ROUND_HALF_EVEN
+	// ROUND_HALF_DOWN 舍入模式,五舍六入(趋向零)
+	ROUND_HALF_DOWN LkkRoundMode = 2
+	// ROUND_UP 舍入模式,远离零方向
+	ROUND_UP LkkRoundMode = 3
+	// ROUND_DOWN 舍入模式,趋向零方向(截断)
+	ROUND_DOWN LkkRoundMode = 4
+	// ROUND_CEILING 舍入模式,向正无穷方向
+	ROUND_CEILING LkkRoundMode = 5
+	// ROUND_FLOOR 舍入模式,向负无穷方向
+	ROUND_FLOOR LkkRoundMode = 6
+
+	// QUANTILE_LINEAR 分位数插值,线性插值(同Excel PERCENTILE.INC、numpy默认)
+	QUANTILE_LINEAR LkkQuantileMethod = 0
+	// QUANTILE_LOWER 分位数插值,取较小的相邻值
+	QUANTILE_LOWER LkkQuantileMethod = 1
+	// QUANTILE_HIGHER 分位数插值,取较大的相邻值
+	QUANTILE_HIGHER LkkQuantileMethod = 2
+	// QUANTILE_NEAREST 分位数插值,取最近的相邻值
+	QUANTILE_NEAREST LkkQuantileMethod = 3
+	// QUANTILE_MIDPOINT 分位数插值,取相邻两值的中点
+	QUANTILE_MIDPOINT LkkQuantileMethod = 4
+
+	// BYTE_JEDEC 字节单位标准,以1024进位,单位为KB、MB(传统写法)
+	BYTE_JEDEC LkkByteStandard = 0
+	// BYTE_SI 字节单位标准,以1000进位,单位为kB、MB
+	BYTE_SI LkkByteStandard = 1
+	// BYTE_IEC 字节单位标准,以1024进位,单位为KiB、MiB
+	BYTE_IEC LkkByteStandard = 2
+
+	// DIMENSION_LENGTH 量纲,长度,基准单位为米
+	DIMENSION_LENGTH LkkUnitDimension = 1
+	// DIMENSION_MASS 量纲,质量,基准单位为千克
+	DIMENSION_MASS LkkUnitDimension = 2
+	// DIMENSION_TEMPERATURE 量纲,温度,基准单位为开尔文
+	DIMENSION_TEMPERATURE LkkUnitDimension = 3
+	// DIMENSION_AREA 量纲,面积,基准单位为平方米
+	DIMENSION_AREA LkkUnitDimension = 4
+	// DIMENSION_DATA_RATE 量纲,数据传输速率,基准单位为比特每秒
+	DIMENSION_DATA_RATE LkkUnitDimension = 5
+
 	//默认浮点数精确小数位数
 	FLOAT_DECIMAL uint8 = 8
 
@@ -387,6 +476,19 @@ var (
 	// 常用中文字符集
 	commonChinese = []rune("们以我到他会作时要动国产的一是工就年阶义发成部民可出能方进在了不和有大这主中人上为来分生对于学下级地个用同行面说种过命度革而多子后自社加小机也经力线本电高量长党得实家定深法表着水理化争现所二起政三好十战无农使性前等反体合斗路图把结第里正新开论之物从当两些还天资事队批点育重其思与间内去因件日利相由压员气业代全组数果期导平各基或月毛然如应形想制心样干都向变关问比展那它最及外没看治提五解系林者米群头意只明四道马认次文通但条较克又公孔领军流入接席位情运器并飞原油放立题质指建区验活众很教决特此常石强极土少已根共直团统式转别造切九你取西持总料连任志观调七么山程百报更见必真保热委手改管处己将修支识病象几先老光专什六型具示复安带每东增则完风回南广劳轮科北打积车计给节做务被整联步类集号列温装即毫知轴研单色坚据速防史拉世设达尔场织历花受求传口断况采精金界品判参层止边清至万确究书术状厂须离再目海交权且儿青才证低越际八试规斯近注办布门铁需走议县兵固除般引齿千胜细影济白格效置推空配刀叶率述今选养德话查差半敌始片施响收华觉备名红续均药标记难存测士身紧液派准斤角降维板许破述技消底床田势端感往神便贺村构照容非搞亚磨族火段算适讲按值美态黄易彪服早班麦削信排台声该击素张密害侯草何树肥继右属市严径螺检左页抗苏显苦英快称坏移约巴材省黑武培著河帝仅针怎植京助升王眼她抓含苗副杂普谈围食射源例致酸旧却充足短划剂宣环落首尺波承粉践府鱼随考刻靠够满夫失包住促枝局菌杆周护岩师举曲春元超负砂封换太模贫减阳扬江析亩木言球朝医校古呢稻宋听唯输滑站另卫字鼓刚写刘微略范供阿块某功套友限项余倒卷创律雨让骨远帮初皮播优占死毒圈伟季训控激找叫云互跟裂粮粒母练塞钢顶策双留误础吸阻故寸盾晚丝女散焊功株亲院冷彻弹错散商视艺灭版烈零室轻血倍缺厘泵察绝富城冲喷壤简否柱李望盘磁雄似困巩益洲脱投送奴侧润盖挥距触星松送获兴独官混纪依未突架宽冬章湿偏纹吃执阀矿寨责熟稳夺硬价努翻奇甲预职评读背协损棉侵灰虽矛厚罗泥辟告卵箱掌氧恩爱停曾溶营终纲孟钱待尽俄缩沙退陈讨奋械载胞幼哪剥迫旋征槽倒握担仍呀鲜吧卡粗介钻逐弱脚怕盐末阴丰雾冠丙街莱贝辐肠付吉渗瑞惊顿挤秒悬姆烂森糖圣凹陶词迟蚕亿矩康遵牧遭幅园腔订香肉弟屋敏恢忘编印蜂急拿扩伤飞露核缘游振操央伍域甚迅辉异序免纸夜乡久隶缸夹念兰映沟乙吗儒杀汽磷艰晶插埃燃欢铁补咱芽永瓦倾阵碳演威附牙芽永瓦斜灌欧献顺猪洋腐请透司危括脉宜笑若尾束壮暴企菜穗楚汉愈绿拖牛份染既秋遍锻玉夏疗尖殖井费州访吹荣铜沿替滚客召旱悟刺脑措贯藏敢令隙炉壳硫煤迎铸粘探临薄旬善福纵择礼愿伏残雷延烟句纯渐耕跑泽慢栽鲁赤繁境潮横掉锥希池败船假亮谓托伙哲怀割摆贡呈劲财仪沉炼麻罪祖息车穿货销齐鼠抽画饲龙库守筑房歌寒喜哥洗蚀废纳腹乎录镜妇恶脂庄擦险赞钟摇典柄辩竹谷卖乱虚桥奥伯赶垂途额壁网截野遗静谋弄挂课镇妄盛耐援扎虑键归符庆聚绕摩忙舞遇索顾胶羊湖钉仁音迹碎伸灯避泛亡答勇频皇柳哈揭甘诺概宪浓岛袭谁洪谢炮浇斑讯懂灵蛋闭孩释乳巨徒私银伊景坦累匀霉杜乐勒隔弯绩招绍胡呼痛峰零柴簧午跳居尚丁秦稍追梁折耗碱殊岗挖氏刃剧堆赫荷胸衡勤膜篇登驻案刊秧缓凸役剪川雪链渔啦脸户洛孢勃盟买杨宗焦赛旗滤硅炭股坐蒸凝竟陷枪黎救冒暗洞犯筒您宋弧爆谬涂味津臂障褐陆啊健尊豆拔莫抵桑坡缝警挑污冰柬嘴啥饭塑寄赵喊垫丹渡耳刨虎笔稀昆浪萨茶滴浅拥穴覆伦娘吨浸袖珠雌妈紫戏塔锤震岁貌洁剖牢锋疑霸闪埔猛诉刷狠忽灾闹乔唐漏闻沈熔氯荒茎男凡抢像浆旁玻亦忠唱蒙予纷捕锁尤乘乌智淡允叛畜俘摸锈扫毕璃宝芯爷鉴秘净蒋钙肩腾枯抛轨堂拌爸循诱祝励肯酒绳穷塘燥泡袋朗喂铝软渠颗惯贸粪综墙趋彼届墨碍启逆卸航衣孙龄岭骗休借")
 
+	// 字节大小单位,值为[底数,指数]
+	byteSizeUnits = map[string][2]int64{
+		"": {1, 0}, "b": {1, 0}, "byte": {1, 0}, "bytes": {1, 0},
+		"k": {1000, 1}, "kb": {1000, 1}, "ki": {1024, 1}, "kib": {1024, 1},
+		"m": {1000, 2}, "mb": {1000, 2}, "mi": {1024, 2}, "mib": {1024, 2},
+		"g": {1000, 3}, "gb": {1000, 3}, "gi": {1024, 3}, "gib": {1024, 3},
+		"t": {1000, 4}, "tb": {1000, 4}, "ti": {1024, 4}, "tib": {1024, 4},
+		"p": {1000, 5}, "pb": {1000, 5}, "pi": {1024, 5}, "pib": {1024, 5},
+		"e": {1000, 6}, "eb": {1000, 6}, "ei": {1024, 6}, "eib": {1024, 6},
+		"z": {1000, 7}, "zb": {1000, 7}, "zi": {1024, 7}, "zib": {1024, 7},
+		"y": {1000, 8}, "yb": {1000, 8}, "yi": {1024, 8}, "yib": {1024, 8},
+	}
+
 	// html抽取文本要排除的标签
 	textHtmlExcludeTags = []string{"head", "title", "img", "form", "textarea", "input", "select", "button", "iframe", "script", "style", "option"}
 
@@ -419,3 +521,19 @@ var (
 		"zxcvbnm",
 	}
 )
+
+//公共错误,可用errors.Is判断
+var (
+	// ErrInvalidParam 参数无效
+	ErrInvalidParam = errors.New("[kgo]`invalid parameter")
+	// ErrEmptyInput 输入数据为空
+	ErrEmptyInput = errors.New("[kgo]`input is empty")
+	// ErrLengthMismatch 输入数据的长度不一致
+	ErrLengthMismatch = errors.New("[kgo]`length mismatch")
+	// ErrUnsupportedType 参数类型不支持,如要求数组/切片却传入了整数
+	ErrUnsupportedType = errors.New("[kgo]`unsupported type")
+	// ErrNumberOverflow 数值溢出,超出目标类型的范围
+	ErrNumberOverflow = errors.New("[kgo]`numeric overflow")
+	// ErrLimitExceeded 超出安全限制,如嵌套过深、数据过长或数量过多
+	ErrLimitExceeded = errors.New("[kgo]`limit exceeded")
+)
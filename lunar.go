@@ -0,0 +1,585 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LunarDate 农历日期
+type LunarDate struct {
+	Year   int  `json:"year"`    //农历年
+	Month  int  `json:"month"`   //农历月,1~12
+	Day    int  `json:"day"`     //农历日,1~30
+	IsLeap bool `json:"is_leap"` //是否闰月
+}
+
+// SolarTerm 节气
+type SolarTerm struct {
+	Index int       `json:"index"` //序号,0~23,从小寒开始
+	Name  string    `json:"name"`  //名称
+	Time  time.Time `json:"time"`  //交节时刻,北京时间
+}
+
+var (
+	// lunarInfo 1900~2100年的农历数据.
+	// 低4位为闰月月份(0为无闰月);第5~16位依次为1~12月的大小(1为30天,0为29天);第17位为闰月大小.
+	lunarInfo = []int{
+		0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, //1900-1909
+		0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, //1910-1919
+		0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, //1920-1929
+		0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, //1930-1939
+		0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, //1940-1949
+		0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, //1950-1959
+		0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, //1960-1969
+		0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, //1970-1979
+		0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, //1980-1989
+		0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, //1990-1999
+		0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, //2000-2009
+		0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, //2010-2019
+		0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, //2020-2029
+		0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, //2030-2039
+		0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, //2040-2049
+		0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, //2050-2059
+		0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, //2060-2069
+		0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, //2070-2079
+		0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, //2080-2089
+		0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, //2090-2099
+		0x0d520, //2100
+	}
+
+	// lunarMinYear 农历数据的最小年份
+	lunarMinYear = 1900
+	// lunarMaxYear 农历数据的最大年份
+	lunarMaxYear = 2100
+	// lunarBaseDate 农历1900年正月初一对应的公历日期
+	lunarBaseDate = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+	// lunarMaxDate 支持转换的最大公历日期
+	lunarMaxDate = time.Date(2100, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// chinaLocation 北京时间,节气以北京时间为准
+	chinaLocation = time.FixedZone("CST", 8*3600)
+
+	// heavenlyStems 天干
+	heavenlyStems = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	// earthlyBranches 地支
+	earthlyBranches = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	// chineseZodiacs 生肖
+	chineseZodiacs = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	// solarTermNames 二十四节气,从小寒开始
+	solarTermNames = []string{
+		"小寒", "大寒", "立春", "雨水", "惊蛰", "春分", "清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+		"小暑", "大暑", "立秋", "处暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+	}
+	// lunarMonthNames 农历月份名称
+	lunarMonthNames = []string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	// lunarDayNames 农历日名称
+	lunarDayNames = []string{
+		"初一", "初二", "初三", "初四", "初五", "初六", "初七", "初八", "初九", "初十",
+		"十一", "十二", "十三", "十四", "十五", "十六", "十七", "十八", "十九", "二十",
+		"廿一", "廿二", "廿三", "廿四", "廿五", "廿六", "廿七", "廿八", "廿九", "三十",
+	}
+	// lunarYearDigits 农历年份数字
+	lunarYearDigits = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	// lunarFestivals 农历传统节日,键为"月-日"
+	lunarFestivals = map[string]string{
+		"1-1":   "春节",
+		"1-15":  "元宵节",
+		"2-2":   "龙抬头",
+		"5-5":   "端午节",
+		"7-7":   "七夕节",
+		"7-15":  "中元节",
+		"8-15":  "中秋节",
+		"9-9":   "重阳节",
+		"12-8":  "腊八节",
+		"12-23": "小年",
+	}
+	// termFestivals 属于传统节日的节气
+	termFestivals = map[string]bool{"清明": true, "冬至": true}
+
+	// earthL 地球VSOP87日心黄经周期项(截断),每组为A,B,C
+	earthL = [][]float64{
+		{175347046, 0, 0, 3341656, 4.6692568, 6283.07585, 34894, 4.6261, 12566.1517, 3497, 2.7441, 5753.3849,
+			3418, 2.8289, 3.5231, 3136, 3.6277, 77713.7715, 2676, 4.4181, 7860.4194, 2343, 6.1352, 3930.2097,
+			1324, 0.7425, 11506.7698, 1273, 2.0371, 529.691, 1199, 1.1096, 1577.3435, 990, 5.233, 5884.927,
+			902, 2.045, 26.298, 857, 3.508, 398.149, 780, 1.179, 5223.694, 753, 2.533, 5507.553,
+			505, 4.583, 18849.228, 492, 4.205, 775.523, 357, 2.92, 0.067, 317, 5.849, 11790.629,
+			284, 1.899, 796.298, 271, 0.315, 10977.079, 243, 0.345, 5486.778, 206, 4.806, 2544.314,
+			205, 1.869, 5573.143, 202, 2.458, 6069.777, 156, 0.833, 213.299, 132, 3.411, 2942.463,
+			126, 1.083, 20.775, 115, 0.645, 0.98, 103, 0.636, 4694.003, 102, 0.976, 15720.839,
+			102, 4.267, 7.114, 99, 6.21, 2146.17, 98, 0.68, 155.42, 86, 5.98, 161000.69,
+			85, 1.3, 6275.96, 85, 3.67, 71430.7, 80, 1.81, 17260.15, 79, 3.04, 12036.46,
+			75, 1.76, 5088.63, 74, 3.5, 3154.69, 74, 4.68, 801.82, 70, 0.83, 9437.76,
+			62, 3.98, 8827.39, 61, 1.82, 7084.9, 57, 2.78, 6286.6, 56, 4.39, 14143.5,
+			56, 3.47, 6279.55, 52, 0.19, 12139.55, 52, 1.33, 1748.02, 51, 0.28, 5856.48,
+			49, 0.49, 1194.45, 41, 5.37, 8429.24, 41, 2.4, 19651.05, 39, 6.17, 10447.39,
+			37, 6.04, 10213.29, 37, 2.57, 1059.38, 36, 1.71, 2352.87, 36, 1.78, 6812.77,
+			33, 0.59, 17789.85, 30, 0.44, 83996.85, 30, 2.74, 1349.87, 25, 3.16, 4690.48},
+		{628331966747, 0, 0, 206059, 2.678235, 6283.07585, 4303, 2.6351, 12566.1517, 425, 1.59, 3.523,
+			119, 5.796, 26.298, 109, 2.966, 1577.344, 93, 2.59, 18849.23, 72, 1.14, 529.69,
+			68, 1.87, 398.15, 67, 4.41, 5507.55, 59, 2.89, 5223.69, 56, 2.17, 155.42,
+			45, 0.4, 796.3, 36, 0.47, 775.52, 29, 2.65, 7.11, 21, 5.34, 0.98,
+			19, 1.85, 5486.78, 19, 4.97, 213.3, 17, 2.99, 6275.96, 16, 0.03, 2544.31,
+			16, 1.43, 2146.17, 15, 1.21, 10977.08, 12, 2.83, 1748.02, 12, 3.26, 5088.63,
+			12, 5.27, 1194.45, 12, 2.08, 4694, 11, 0.77, 553.57, 10, 1.3, 6286.6,
+			10, 4.24, 1349.87, 9, 2.7, 242.73, 9, 5.64, 951.72, 8, 5.3, 2352.87,
+			6, 2.65, 9437.76, 6, 4.67, 4690.48},
+		{52919, 0, 0, 8720, 1.0721, 6283.0758, 309, 0.867, 12566.152, 27, 0.05, 3.52,
+			16, 5.19, 26.3, 16, 3.68, 155.42, 10, 0.76, 18849.23, 9, 2.06, 77713.77,
+			7, 0.83, 775.52, 5, 4.66, 1577.34, 4, 1.03, 7.11, 4, 3.44, 5573.14,
+			3, 5.14, 796.3, 3, 6.05, 5507.55, 3, 1.19, 242.73, 3, 6.12, 529.69,
+			3, 0.31, 398.15, 3, 2.28, 553.57, 2, 4.38, 5223.69, 2, 3.75, 0.98},
+		{289, 5.844, 6283.076, 35, 0, 0, 17, 5.49, 12566.15, 3, 5.2, 155.42,
+			1, 4.72, 3.52, 1, 5.3, 18849.23, 1, 5.97, 242.73},
+		{114, 3.142, 0, 8, 4.13, 6283.08, 1, 3.84, 12566.15},
+		{1, 3.14, 0},
+	}
+
+	// earthR 地球VSOP87日地距离周期项(截断)
+	earthR = [][]float64{
+		{100013989, 0, 0, 1670700, 3.0984635, 6283.07585, 13956, 3.05525, 12566.1517,
+			3084, 5.1985, 77713.7715, 1628, 1.1739, 5753.3849, 1576, 2.8469, 7860.4194},
+		{103019, 1.10749, 6283.07585, 1721, 1.0644, 12566.1517},
+	}
+)
+
+// String 返回农历日期的中文表示,如"二〇二三年闰二月初一".
+func (ld LunarDate) String() string {
+	return ld.YearName() + "年" + ld.MonthName() + "月" + ld.DayName()
+}
+
+// YearName 返回农历年份的中文数字,如"二〇二三".
+func (ld LunarDate) YearName() string {
+	var sb strings.Builder
+	for _, c := range strconv.Itoa(ld.Year) {
+		if c >= '0' && c <= '9' {
+			sb.WriteString(lunarYearDigits[c-'0'])
+		}
+	}
+	return sb.String()
+}
+
+// MonthName 返回农历月份名称,如"正"、"闰二"、"腊".
+func (ld LunarDate) MonthName() string {
+	if ld.Month < 1 || ld.Month > 12 {
+		return ""
+	}
+
+	res := lunarMonthNames[ld.Month-1]
+	if ld.IsLeap {
+		res = "闰" + res
+	}
+	return res
+}
+
+// DayName 返回农历日名称,如"初一"、"廿九".
+func (ld LunarDate) DayName() string {
+	if ld.Day < 1 || ld.Day > 30 {
+		return ""
+	}
+	return lunarDayNames[ld.Day-1]
+}
+
+// lunarYearInfo 获取农历年份的数据.
+func lunarYearInfo(year int) int {
+	return lunarInfo[year-lunarMinYear]
+}
+
+// lunarLeapMonth 获取农历年的闰月月份,无闰月为0.
+func lunarLeapMonth(year int) int {
+	return lunarYearInfo(year) & 0xf
+}
+
+// lunarLeapDays 获取农历年闰月的天数,无闰月为0.
+func lunarLeapDays(year int) int {
+	if lunarLeapMonth(year) == 0 {
+		return 0
+	} else if lunarYearInfo(year)&0x10000 != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarMonthDays 获取农历年某个(非闰)月的天数.
+func lunarMonthDays(year, month int) int {
+	if lunarYearInfo(year)&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarYearDays 获取农历年的总天数.
+func lunarYearDays(year int) int {
+	sum := 348
+	info := lunarYearInfo(year)
+	for i := 0x8000; i > 0x8; i >>= 1 {
+		if info&i != 0 {
+			sum++
+		}
+	}
+	return sum + lunarLeapDays(year)
+}
+
+// civilDate 取时间的年月日,返回UTC零点的日期.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// julianDayNumber 获取公历日期的儒略日数(整数).
+func julianDayNumber(t time.Time) int {
+	return int(civilDate(t).Unix()/86400) + 2440588
+}
+
+// ganZhiName 获取六十甲子序号对应的干支名称.
+func ganZhiName(index int) string {
+	index = ((index % 60) + 60) % 60
+	return heavenlyStems[index%10] + earthlyBranches[index%12]
+}
+
+// deltaT 估算力学时与世界时之差,秒.
+func deltaT(year float64) float64 {
+	var t float64
+	switch {
+	case year < 1920:
+		t = year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case year < 1941:
+		t = year - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case year < 1961:
+		t = year - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case year < 1986:
+		t = year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t = year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case year < 2050:
+		t = year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		t = (year - 1820) / 100
+		return -20 + 32*t*t - 0.5628*(2150-year)
+	}
+}
+
+// vsopSeries 计算VSOP87周期项之和.
+func vsopSeries(series [][]float64, tau float64) float64 {
+	var res, pow float64 = 0, 1
+	for _, terms := range series {
+		var sum float64
+		for i := 0; i+2 < len(terms); i += 3 {
+			sum += terms[i] * math.Cos(terms[i+1]+terms[i+2]*tau)
+		}
+		res += sum * pow
+		pow *= tau
+	}
+	return res / 1e8
+}
+
+// sunApparentLongitude 计算儒略历书日jde时太阳的视黄经,度.
+func sunApparentLongitude(jde float64) float64 {
+	tau := (jde - 2451545.0) / 365250
+	t := tau * 10
+	rad := math.Pi / 180
+
+	lng := vsopSeries(earthL, tau)*180/math.Pi + 180
+	dist := vsopSeries(earthR, tau)
+
+	//章动
+	omega := (125.04452 - 1934.136261*t) * rad
+	ls := (280.4665 + 36000.7698*t) * rad
+	lm := (218.3165 + 481267.8813*t) * rad
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+
+	//FK5修正,章动,光行差
+	lng += (-0.09033 + nutation - 20.4898/dist) / 3600
+
+	return math.Mod(math.Mod(lng, 360)+360, 360)
+}
+
+// solarTermTime 计算某年第index个节气(0为小寒)的交节时刻.
+func solarTermTime(year, index int) time.Time {
+	target := math.Mod(float64(285+15*index), 360)
+	//上一年冬至附近作为初始值
+	start := time.Date(year-1, 12, 22, 0, 0, 0, 0, time.UTC)
+	jde := float64(start.Unix())/86400 + 2440587.5 + float64(index+1)*365.2422/24
+
+	for i := 0; i < 20; i++ {
+		diff := target - sunApparentLongitude(jde)
+		diff = math.Mod(diff+540, 360) - 180
+		jde += diff * 365.2422 / 360
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+
+	//力学时转世界时
+	jd := jde - deltaT(float64(year)+float64(index)/24)/86400
+	sec := (jd - 2440587.5) * 86400
+	whole := math.Floor(sec)
+	return time.Unix(int64(whole), int64((sec-whole)*1e9)).Round(time.Second).In(chinaLocation)
+}
+
+// yearSolarTerms 计算某年的24节气.
+func yearSolarTerms(year int) []SolarTerm {
+	res := make([]SolarTerm, 24)
+	for i := range res {
+		res[i] = SolarTerm{
+			Index: i,
+			Name:  solarTermNames[i],
+			Time:  solarTermTime(year, i),
+		}
+	}
+	return res
+}
+
+// Solar2Lunar 公历转农历.支持1900-01-31至2100-12-31,以date所在时区的日期为准.
+func (kt *LkkTime) Solar2Lunar(date time.Time) (res LunarDate, err error) {
+	day := civilDate(date)
+	if day.Before(lunarBaseDate) || day.After(lunarMaxDate) {
+		err = errors.New("[Solar2Lunar]`date out of range 1900-01-31 ~ 2100-12-31")
+		return
+	}
+
+	offset := int(day.Sub(lunarBaseDate).Hours() / 24)
+	year := lunarMinYear
+	for ; year <= lunarMaxYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+
+	leap := lunarLeapMonth(year)
+	month := 1
+	for ; month <= 12; month++ {
+		days := lunarMonthDays(year, month)
+		if offset < days {
+			break
+		}
+		offset -= days
+
+		if month == leap {
+			days = lunarLeapDays(year)
+			if offset < days {
+				res.IsLeap = true
+				break
+			}
+			offset -= days
+		}
+	}
+
+	res.Year = year
+	res.Month = month
+	res.Day = offset + 1
+	return
+}
+
+// Lunar2Solar 农历转公历.isLeap为是否闰月,返回的时间为本地时区的零点.
+func (kt *LkkTime) Lunar2Solar(year, month, day int, isLeap bool) (time.Time, error) {
+	if year < lunarMinYear || year > lunarMaxYear || month < 1 || month > 12 || day < 1 || day > 30 {
+		return time.Time{}, errors.New("[Lunar2Solar]`date out of range")
+	}
+
+	leap := lunarLeapMonth(year)
+	if isLeap && leap != month {
+		return time.Time{}, errors.New("[Lunar2Solar]`year " + strconv.Itoa(year) + " has no leap month " + strconv.Itoa(month))
+	}
+
+	monthDays := lunarMonthDays(year, month)
+	if isLeap {
+		monthDays = lunarLeapDays(year)
+	}
+	if day > monthDays {
+		return time.Time{}, errors.New("[Lunar2Solar]`day out of range of the month")
+	}
+
+	var offset int
+	for y := lunarMinYear; y < year; y++ {
+		offset += lunarYearDays(y)
+	}
+	for m := 1; m < month; m++ {
+		offset += lunarMonthDays(year, m)
+		if m == leap {
+			offset += lunarLeapDays(year)
+		}
+	}
+	if isLeap {
+		offset += lunarMonthDays(year, month)
+	}
+	offset += day - 1
+
+	res := lunarBaseDate.AddDate(0, 0, offset)
+	if res.After(lunarMaxDate) {
+		return time.Time{}, errors.New("[Lunar2Solar]`date out of range")
+	}
+
+	return time.Date(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0, time.Local), nil
+}
+
+// LunarLeapMonth 获取农历年的闰月月份,无闰月或年份超出1900~2100时为0.
+func (kt *LkkTime) LunarLeapMonth(year int) int {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return 0
+	}
+	return lunarLeapMonth(year)
+}
+
+// LunarMonthDays 获取农历某月的天数,isLeap为是否闰月;月份不存在时为0.
+func (kt *LkkTime) LunarMonthDays(year, month int, isLeap bool) int {
+	if year < lunarMinYear || year > lunarMaxYear || month < 1 || month > 12 {
+		return 0
+	} else if isLeap {
+		if lunarLeapMonth(year) != month {
+			return 0
+		}
+		return lunarLeapDays(year)
+	}
+	return lunarMonthDays(year, month)
+}
+
+// SolarTerms 获取某年的24节气(从小寒到冬至),交节时刻为北京时间.
+func (kt *LkkTime) SolarTerms(year int) []SolarTerm {
+	return yearSolarTerms(year)
+}
+
+// GetSolarTerm 获取日期当天的节气名称,非交节日为空字符串.
+func (kt *LkkTime) GetSolarTerm(date time.Time) string {
+	day := civilDate(date)
+	for _, term := range yearSolarTerms(date.Year()) {
+		if civilDate(term.Time).Equal(day) {
+			return term.Name
+		}
+	}
+	return ""
+}
+
+// GanZhiYear 获取日期的年干支,以农历正月初一为界.
+func (kt *LkkTime) GanZhiYear(date time.Time) (string, error) {
+	ld, err := kt.Solar2Lunar(date)
+	if err != nil {
+		return "", err
+	}
+	return ganZhiName(ld.Year - 4), nil
+}
+
+// GanZhiMonth 获取日期的月干支,以节气中的"节"为界(如立春起寅月).
+func (kt *LkkTime) GanZhiMonth(date time.Time) string {
+	day := civilDate(date)
+	cur := date.Year()
+
+	//小寒前属于上一年的子月
+	year, order := cur-1, 10
+	for i := 0; i < 24; i += 2 {
+		if civilDate(solarTermTime(cur, i)).After(day) {
+			break
+		}
+		if i == 0 {
+			year, order = cur-1, 11
+		} else {
+			year, order = cur, i/2-1
+		}
+	}
+
+	//1900年寅月为戊寅(序号14)
+	return ganZhiName(14 + (year-1900)*12 + order)
+}
+
+// GanZhiDay 获取日期的日干支.
+func (kt *LkkTime) GanZhiDay(date time.Time) string {
+	return ganZhiName(julianDayNumber(date) + 49)
+}
+
+// Zodiac 获取日期的生肖,以农历正月初一为界.
+func (kt *LkkTime) Zodiac(date time.Time) (string, error) {
+	ld, err := kt.Solar2Lunar(date)
+	if err != nil {
+		return "", err
+	}
+	return chineseZodiacs[((ld.Year-4)%12+12)%12], nil
+}
+
+// LunarFestivals 获取日期当天的中国传统节日,如春节、中秋节、清明节、除夕等.
+func (kt *LkkTime) LunarFestivals(date time.Time) (res []string) {
+	ld, err := kt.Solar2Lunar(date)
+	if err != nil {
+		return
+	}
+
+	if !ld.IsLeap {
+		if name, ok := lunarFestivals[strconv.Itoa(ld.Month)+"-"+strconv.Itoa(ld.Day)]; ok {
+			res = append(res, name)
+		}
+		if ld.Month == 12 && ld.Day == lunarMonthDays(ld.Year, 12) {
+			res = append(res, "除夕")
+		}
+	}
+
+	term := kt.GetSolarTerm(date)
+	if termFestivals[term] {
+		res = append(res, term+"节")
+	}
+
+	return
+}
+
+// lunarDateTokens Date支持的农历占位符
+var lunarDateTokens = []string{"{LY}", "{LYN}", "{LM}", "{LD}", "{GY}", "{GM}", "{GD}", "{Z}", "{ST}", "{FE}"}
+
+// lunarDateValue 获取农历占位符对应的值.
+func lunarDateValue(token string, t time.Time) (res string) {
+	switch token {
+	case "{LY}", "{LYN}", "{LM}", "{LD}":
+		ld, err := KTime.Solar2Lunar(t)
+		if err != nil {
+			return
+		}
+		switch token {
+		case "{LY}":
+			res = strconv.Itoa(ld.Year)
+		case "{LYN}":
+			res = ld.YearName()
+		case "{LM}":
+			res = ld.MonthName()
+		default:
+			res = ld.DayName()
+		}
+	case "{GY}":
+		res, _ = KTime.GanZhiYear(t)
+	case "{GM}":
+		res = KTime.GanZhiMonth(t)
+	case "{GD}":
+		res = KTime.GanZhiDay(t)
+	case "{Z}":
+		res, _ = KTime.Zodiac(t)
+	case "{ST}":
+		res = KTime.GetSolarTerm(t)
+	case "{FE}":
+		res = strings.Join(KTime.LunarFestivals(t), ",")
+	}
+
+	return
+}
+
+// lunarDatePlaceholder 将格式中的农历占位符替换为私有区字符,以免被时间格式解析;
+// 返回新的格式,以及格式化之后用于还原农历值的替换器.
+func lunarDatePlaceholder(format string, t time.Time) (string, *strings.Replacer) {
+	var before, after []string
+	for i, token := range lunarDateTokens {
+		if strings.Contains(format, token) {
+			mark := string(rune(0xE000 + i))
+			before = append(before, token, mark)
+			after = append(after, mark, lunarDateValue(token, t))
+		}
+	}
+
+	if len(before) == 0 {
+		return format, nil
+	}
+	return strings.NewReplacer(before...).Replace(format), strings.NewReplacer(after...)
+}
@@ -0,0 +1,218 @@
+package kgo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTime_Solar2Lunar(t *testing.T) {
+	var tests = []struct {
+		date   time.Time
+		year   int
+		month  int
+		day    int
+		isLeap bool
+		str    string
+	}{
+		{time.Date(1900, 1, 31, 0, 0, 0, 0, time.Local), 1900, 1, 1, false, "一九〇〇年正月初一"},
+		{time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local), 2024, 1, 1, false, "二〇二四年正月初一"},
+		{time.Date(2024, 2, 9, 23, 59, 59, 0, time.Local), 2023, 12, 30, false, "二〇二三年腊月三十"},
+		{time.Date(2023, 3, 22, 0, 0, 0, 0, time.Local), 2023, 2, 1, true, "二〇二三年闰二月初一"},
+		{time.Date(2020, 5, 23, 0, 0, 0, 0, time.Local), 2020, 4, 1, true, "二〇二〇年闰四月初一"},
+		{time.Date(2024, 9, 17, 0, 0, 0, 0, time.Local), 2024, 8, 15, false, "二〇二四年八月十五"},
+		{time.Date(2100, 12, 31, 0, 0, 0, 0, time.Local), 2100, 12, 1, false, "二一〇〇年腊月初一"},
+	}
+	for _, test := range tests {
+		res, err := KTime.Solar2Lunar(test.date)
+		assert.Nil(t, err)
+		assert.Equal(t, test.year, res.Year)
+		assert.Equal(t, test.month, res.Month)
+		assert.Equal(t, test.day, res.Day)
+		assert.Equal(t, test.isLeap, res.IsLeap)
+		assert.Equal(t, test.str, res.String())
+
+		//互转
+		solar, err := KTime.Lunar2Solar(res.Year, res.Month, res.Day, res.IsLeap)
+		assert.Nil(t, err)
+		assert.Equal(t, test.date.Format("2006-01-02"), solar.Format("2006-01-02"))
+	}
+
+	//超出范围
+	_, err := KTime.Solar2Lunar(time.Date(1900, 1, 30, 0, 0, 0, 0, time.Local))
+	assert.NotNil(t, err)
+	_, err = KTime.Solar2Lunar(time.Date(2101, 1, 1, 0, 0, 0, 0, time.Local))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkTime_Solar2Lunar(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KTime.Solar2Lunar(myDate1)
+	}
+}
+
+func TestTime_Lunar2Solar(t *testing.T) {
+	var res time.Time
+	var err error
+
+	res, err = KTime.Lunar2Solar(2023, 2, 1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-02-20", res.Format("2006-01-02"))
+
+	res, err = KTime.Lunar2Solar(2023, 2, 1, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "2023-03-22", res.Format("2006-01-02"))
+
+	//非闰月
+	_, err = KTime.Lunar2Solar(2024, 2, 1, true)
+	assert.NotNil(t, err)
+
+	//小月无三十
+	_, err = KTime.Lunar2Solar(2023, 2, 30, true)
+	assert.NotNil(t, err)
+
+	_, err = KTime.Lunar2Solar(1899, 1, 1, false)
+	assert.NotNil(t, err)
+	_, err = KTime.Lunar2Solar(2024, 13, 1, false)
+	assert.NotNil(t, err)
+
+	//超出公历范围
+	_, err = KTime.Lunar2Solar(2100, 12, 2, false)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkTime_Lunar2Solar(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KTime.Lunar2Solar(2023, 2, 1, true)
+	}
+}
+
+func TestTime_LunarLeapMonth(t *testing.T) {
+	assert.Equal(t, 2, KTime.LunarLeapMonth(2023))
+	assert.Equal(t, 0, KTime.LunarLeapMonth(2024))
+	assert.Equal(t, 6, KTime.LunarLeapMonth(2025))
+	assert.Equal(t, 0, KTime.LunarLeapMonth(1800))
+}
+
+func BenchmarkTime_LunarLeapMonth(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.LunarLeapMonth(2023)
+	}
+}
+
+func TestTime_LunarMonthDays(t *testing.T) {
+	assert.Equal(t, 29, KTime.LunarMonthDays(2023, 2, true))
+	assert.Equal(t, 30, KTime.LunarMonthDays(2023, 12, false))
+	assert.Equal(t, 0, KTime.LunarMonthDays(2024, 2, true))
+	assert.Equal(t, 0, KTime.LunarMonthDays(2024, 0, false))
+}
+
+func BenchmarkTime_LunarMonthDays(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.LunarMonthDays(2023, 2, true)
+	}
+}
+
+func TestTime_SolarTerms(t *testing.T) {
+	res := KTime.SolarTerms(2024)
+	assert.Equal(t, 24, len(res))
+	assert.Equal(t, "小寒", res[0].Name)
+	assert.Equal(t, "冬至", res[23].Name)
+	assert.Equal(t, "2024-02-04 16:27", res[2].Time.Format("2006-01-02 15:04"))
+	assert.Equal(t, "2024-03-20 11:06", res[5].Time.Format("2006-01-02 15:04"))
+	assert.Equal(t, "2024-12-21 17:20", res[23].Time.Format("2006-01-02 15:04"))
+
+	res = KTime.SolarTerms(1949)
+	assert.Equal(t, "1949-10-08", res[18].Time.Format("2006-01-02"))
+}
+
+func BenchmarkTime_SolarTerms(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.SolarTerms(2024)
+	}
+}
+
+func TestTime_GetSolarTerm(t *testing.T) {
+	assert.Equal(t, "清明", KTime.GetSolarTerm(time.Date(2024, 4, 4, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "", KTime.GetSolarTerm(time.Date(2024, 4, 5, 0, 0, 0, 0, time.Local)))
+}
+
+func BenchmarkTime_GetSolarTerm(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.GetSolarTerm(myDate1)
+	}
+}
+
+func TestTime_GanZhi(t *testing.T) {
+	var res string
+	var err error
+
+	res, err = KTime.GanZhiYear(time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local))
+	assert.Nil(t, err)
+	assert.Equal(t, "甲辰", res)
+	res, err = KTime.GanZhiYear(time.Date(2024, 2, 9, 0, 0, 0, 0, time.Local))
+	assert.Nil(t, err)
+	assert.Equal(t, "癸卯", res)
+	_, err = KTime.GanZhiYear(time.Date(1800, 1, 1, 0, 0, 0, 0, time.Local))
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "甲子", KTime.GanZhiMonth(time.Date(2024, 1, 5, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "乙丑", KTime.GanZhiMonth(time.Date(2024, 2, 3, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "丙寅", KTime.GanZhiMonth(time.Date(2024, 2, 4, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "丙子", KTime.GanZhiMonth(time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)))
+
+	assert.Equal(t, "甲子", KTime.GanZhiDay(time.Date(1949, 10, 1, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, "甲辰", KTime.GanZhiDay(time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local)))
+}
+
+func BenchmarkTime_GanZhiMonth(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.GanZhiMonth(myDate1)
+	}
+}
+
+func TestTime_Zodiac(t *testing.T) {
+	var res string
+	var err error
+
+	res, err = KTime.Zodiac(time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local))
+	assert.Nil(t, err)
+	assert.Equal(t, "龙", res)
+
+	res, err = KTime.Zodiac(time.Date(2024, 2, 9, 0, 0, 0, 0, time.Local))
+	assert.Nil(t, err)
+	assert.Equal(t, "兔", res)
+
+	_, err = KTime.Zodiac(time.Date(2200, 1, 1, 0, 0, 0, 0, time.Local))
+	assert.NotNil(t, err)
+}
+
+func BenchmarkTime_Zodiac(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KTime.Zodiac(myDate1)
+	}
+}
+
+func TestTime_LunarFestivals(t *testing.T) {
+	assert.Equal(t, []string{"春节"}, KTime.LunarFestivals(time.Date(2024, 2, 10, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, []string{"除夕"}, KTime.LunarFestivals(time.Date(2024, 2, 9, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, []string{"中秋节"}, KTime.LunarFestivals(time.Date(2024, 9, 17, 0, 0, 0, 0, time.Local)))
+	assert.Equal(t, []string{"清明节"}, KTime.LunarFestivals(time.Date(2024, 4, 4, 0, 0, 0, 0, time.Local)))
+	assert.Empty(t, KTime.LunarFestivals(time.Date(2024, 4, 5, 0, 0, 0, 0, time.Local)))
+	assert.Empty(t, KTime.LunarFestivals(time.Date(1800, 1, 1, 0, 0, 0, 0, time.Local)))
+}
+
+func BenchmarkTime_LunarFestivals(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.LunarFestivals(myDate1)
+	}
+}
@@ -0,0 +1,524 @@
+package kgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DecodeHook Map2Struct 的自定义解码钩子,在默认转换之前依次调用;
+// data为源数据,to为目标类型,返回转换后的数据,不处理时原样返回data.
+type DecodeHook func(data interface{}, to reflect.Type) (interface{}, error)
+
+// FieldError 字段解码错误.
+type FieldError struct {
+	Field string `json:"field"` //字段路径,如user.tags[0]
+	Err   error  `json:"err"`   //错误
+}
+
+// DecodeError Map2Struct 的解码错误,包含所有转换失败的字段.
+type DecodeError struct {
+	Fields []*FieldError `json:"fields"` //失败的字段
+}
+
+// structDecoder 字典转结构体的解码器
+type structDecoder struct {
+	tagName string
+	hooks   []DecodeHook
+	errs    []*FieldError
+}
+
+var (
+	// timeType time.Time的类型
+	timeType = reflect.TypeOf(time.Time{})
+	// durationType time.Duration的类型
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// timeLayouts 解码时间字符串时尝试的格式
+	timeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+		"2006/01/02 15:04:05",
+		"2006/01/02",
+	}
+)
+
+// Error 实现error接口.
+func (fe *FieldError) Error() string {
+	return fe.Field + ": " + fe.Err.Error()
+}
+
+// Unwrap 获取原始错误.
+func (fe *FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// Error 实现error接口.
+func (de *DecodeError) Error() string {
+	msgs := make([]string, len(de.Fields))
+	for i, fe := range de.Fields {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("[Map2Struct]`%d field(s) failed: %s", len(de.Fields), strings.Join(msgs, "; "))
+}
+
+// Map2Struct 字典转为结构体,为 Struct2Map 的逆操作.
+// m为键为字符串的字典,如ParseStr、json.Unmarshal的结果;dst为结构体指针;
+// tagName为字段标签名,如"json",标签为"-"的字段忽略,无标签时使用字段名;键名先精确匹配,再忽略大小写匹配.
+// 使用弱类型转换:数值字符串可转为数值,数值可转为字符串,"1"/"on"等可转为布尔值;
+// 支持嵌套结构体、切片、字典、指针、匿名嵌入结构体(无标签时展开),
+// time.Time可由字符串(RFC3339、"2006-01-02 15:04:05"等)或Unix秒数转换,time.Duration可由"1m30s"等字符串或纳秒数转换;
+// hooks为可选的自定义解码钩子.
+// 有字段转换失败时返回 *DecodeError ,其中列出所有失败的字段,其他字段仍会被赋值.
+func (kc *LkkConvert) Map2Struct(m interface{}, dst interface{}, tagName string, hooks ...DecodeHook) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("[Map2Struct]`dst must be a non-nil pointer to struct")
+	}
+
+	mv := reflect.ValueOf(m)
+	for mv.Kind() == reflect.Ptr || mv.Kind() == reflect.Interface {
+		mv = mv.Elem()
+	}
+	if mv.Kind() != reflect.Map {
+		return errors.New("[Map2Struct]`m type must be map; but : " + mv.Kind().String())
+	}
+
+	sd := &structDecoder{tagName: tagName, hooks: hooks}
+	sd.decodeStruct("", mv, dv.Elem())
+	if len(sd.errs) > 0 {
+		return &DecodeError{Fields: sd.errs}
+	}
+
+	return nil
+}
+
+// fail 记录字段错误.
+func (sd *structDecoder) fail(path string, err error) {
+	if path == "" {
+		path = "."
+	}
+	sd.errs = append(sd.errs, &FieldError{Field: path, Err: err})
+}
+
+// fieldName 获取字段对应的键名;skip为是否忽略该字段,tagged为是否有标签.
+func (sd *structDecoder) fieldName(field reflect.StructField) (name string, skip, tagged bool) {
+	name = field.Name
+	if sd.tagName == "" {
+		return
+	}
+
+	tag := field.Tag.Get(sd.tagName)
+	if tag == "-" {
+		return "", true, true
+	}
+	if tag = strings.Split(tag, ",")[0]; tag != "" {
+		return tag, false, true
+	}
+	return
+}
+
+// decodeStruct 将字典mv解码到结构体sv.
+func (sd *structDecoder) decodeStruct(path string, mv reflect.Value, sv reflect.Value) {
+	exact := make(map[string]reflect.Value, mv.Len())
+	folded := make(map[string]reflect.Value, mv.Len())
+	for _, k := range mv.MapKeys() {
+		key := toStr(k.Interface())
+		exact[key] = mv.MapIndex(k)
+		folded[strings.ToLower(key)] = mv.MapIndex(k)
+	}
+
+	t := sv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := sv.Field(i)
+		name, skip, tagged := sd.fieldName(field)
+		if skip {
+			continue
+		}
+
+		//无标签的匿名嵌入结构体,展开解码
+		if field.Anonymous && !tagged {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && ft != timeType {
+				if fv.Kind() == reflect.Ptr {
+					if !fv.CanSet() {
+						continue
+					} else if fv.IsNil() {
+						fv.Set(reflect.New(ft))
+					}
+					fv = fv.Elem()
+				}
+				sd.decodeStruct(path, mv, fv)
+				continue
+			}
+		}
+
+		if !fv.CanSet() {
+			continue
+		}
+
+		raw, ok := exact[name]
+		if !ok {
+			if raw, ok = folded[strings.ToLower(name)]; !ok {
+				continue
+			}
+		}
+
+		if path != "" {
+			name = path + "." + name
+		}
+		sd.decode(name, raw.Interface(), fv)
+	}
+}
+
+// decode 将数据data弱类型转换并写入out.
+func (sd *structDecoder) decode(path string, data interface{}, out reflect.Value) {
+	var err error
+	for _, hook := range sd.hooks {
+		if data, err = hook(data, out.Type()); err != nil {
+			sd.fail(path, err)
+			return
+		}
+	}
+
+	in := reflect.ValueOf(data)
+	for in.Kind() == reflect.Ptr || in.Kind() == reflect.Interface {
+		if in.IsNil() {
+			return
+		}
+		in = in.Elem()
+	}
+	if !in.IsValid() {
+		return
+	}
+
+	if in.Type().AssignableTo(out.Type()) {
+		out.Set(in)
+		return
+	}
+
+	switch out.Type() {
+	case timeType:
+		var tim time.Time
+		if tim, err = weakTime(in); err == nil {
+			out.Set(reflect.ValueOf(tim))
+		}
+		sd.failIf(path, err)
+		return
+	case durationType:
+		var dur time.Duration
+		if dur, err = weakDuration(in); err == nil {
+			out.SetInt(int64(dur))
+		}
+		sd.failIf(path, err)
+		return
+	}
+
+	switch out.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(out.Type().Elem())
+		before := len(sd.errs)
+		sd.decode(path, in.Interface(), elem.Elem())
+		if len(sd.errs) == before {
+			out.Set(elem)
+		}
+	case reflect.Interface:
+		if in.Type().Implements(out.Type()) {
+			out.Set(in)
+		} else {
+			sd.fail(path, fmt.Errorf("%s does not implement %s", in.Type(), out.Type()))
+		}
+	case reflect.String:
+		switch in.Kind() {
+		case reflect.Map, reflect.Struct, reflect.Array, reflect.Func, reflect.Chan:
+			err = fmt.Errorf("cannot convert %s to string", in.Type())
+		case reflect.Slice:
+			if in.Type().Elem().Kind() != reflect.Uint8 {
+				err = fmt.Errorf("cannot convert %s to string", in.Type())
+				break
+			}
+			fallthrough
+		default:
+			out.SetString(toStr(in.Interface()))
+		}
+		sd.failIf(path, err)
+	case reflect.Bool:
+		var b bool
+		if b, err = weakBool(in); err == nil {
+			out.SetBool(b)
+		}
+		sd.failIf(path, err)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = weakInt(in); err == nil {
+			if out.OverflowInt(i) {
+				err = fmt.Errorf("%v to %s: %w", in.Interface(), out.Type(), ErrNumberOverflow)
+			} else {
+				out.SetInt(i)
+			}
+		}
+		sd.failIf(path, err)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if u, err = weakUint(in); err == nil {
+			if out.OverflowUint(u) {
+				err = fmt.Errorf("%v to %s: %w", in.Interface(), out.Type(), ErrNumberOverflow)
+			} else {
+				out.SetUint(u)
+			}
+		}
+		sd.failIf(path, err)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = weakFloat(in); err == nil {
+			if out.OverflowFloat(f) {
+				err = fmt.Errorf("%v to %s: %w", in.Interface(), out.Type(), ErrNumberOverflow)
+			} else {
+				out.SetFloat(f)
+			}
+		}
+		sd.failIf(path, err)
+	case reflect.Struct:
+		if in.Kind() != reflect.Map {
+			sd.fail(path, fmt.Errorf("cannot convert %s to %s", in.Type(), out.Type()))
+			return
+		}
+		sd.decodeStruct(path, in, out)
+	case reflect.Slice:
+		if in.Kind() == reflect.String && out.Type().Elem().Kind() == reflect.Uint8 {
+			out.SetBytes([]byte(in.String()))
+			return
+		} else if in.Kind() != reflect.Slice && in.Kind() != reflect.Array {
+			//单个值视为只有一个元素的切片
+			res := reflect.MakeSlice(out.Type(), 1, 1)
+			sd.decode(path+"[0]", in.Interface(), res.Index(0))
+			out.Set(res)
+			return
+		}
+
+		res := reflect.MakeSlice(out.Type(), in.Len(), in.Len())
+		for i := 0; i < in.Len(); i++ {
+			sd.decode(fmt.Sprintf("%s[%d]", path, i), in.Index(i).Interface(), res.Index(i))
+		}
+		out.Set(res)
+	case reflect.Array:
+		if in.Kind() != reflect.Slice && in.Kind() != reflect.Array {
+			sd.fail(path, fmt.Errorf("cannot convert %s to %s", in.Type(), out.Type()))
+			return
+		} else if in.Len() > out.Len() {
+			sd.fail(path, fmt.Errorf("source length %d exceeds array length %d", in.Len(), out.Len()))
+			return
+		}
+
+		for i := 0; i < in.Len(); i++ {
+			sd.decode(fmt.Sprintf("%s[%d]", path, i), in.Index(i).Interface(), out.Index(i))
+		}
+	case reflect.Map:
+		if in.Kind() != reflect.Map {
+			sd.fail(path, fmt.Errorf("cannot convert %s to %s", in.Type(), out.Type()))
+			return
+		}
+
+		res := reflect.MakeMapWithSize(out.Type(), in.Len())
+		for _, k := range in.MapKeys() {
+			sub := fmt.Sprintf("%s[%v]", path, k.Interface())
+			key := reflect.New(out.Type().Key()).Elem()
+			val := reflect.New(out.Type().Elem()).Elem()
+			before := len(sd.errs)
+			sd.decode(sub, k.Interface(), key)
+			sd.decode(sub, in.MapIndex(k).Interface(), val)
+			if len(sd.errs) == before {
+				res.SetMapIndex(key, val)
+			}
+		}
+		out.Set(res)
+	default:
+		sd.fail(path, fmt.Errorf("unsupported type %s", out.Type()))
+	}
+}
+
+// failIf 错误不为空时记录字段错误.
+func (sd *structDecoder) failIf(path string, err error) {
+	if err != nil {
+		sd.fail(path, err)
+	}
+}
+
+// weakBool 弱类型转换为布尔值;字符串支持1/0、true/false、yes/no、on/off等,空串为false.
+func weakBool(in reflect.Value) (bool, error) {
+	switch in.Kind() {
+	case reflect.Bool:
+		return in.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return toBool(in.Interface()), nil
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		switch strings.ToLower(str) {
+		case "":
+			return false, nil
+		case "yes", "y", "on":
+			return true, nil
+		case "no", "n", "off":
+			return false, nil
+		}
+		res, err := strconv.ParseBool(str)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse %q as bool", str)
+		}
+		return res, nil
+	}
+
+	return false, fmt.Errorf("cannot convert %s to bool", in.Type())
+}
+
+// weakInt 弱类型转换为int64;浮点数取整数部分,数值字符串将被解析,空串为0.
+func weakInt(in reflect.Value) (int64, error) {
+	switch in.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return in.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if in.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%d to int64: %w", in.Uint(), ErrNumberOverflow)
+		}
+		return int64(in.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return float2Int64(in.Float())
+	case reflect.Bool:
+		return int64(bool2Int(in.Bool())), nil
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		if str == "" {
+			return 0, nil
+		} else if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return i, nil
+		} else if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return float2Int64(f)
+		}
+		return 0, fmt.Errorf("cannot parse %q as int", str)
+	}
+
+	return 0, fmt.Errorf("cannot convert %s to int", in.Type())
+}
+
+// weakUint 弱类型转换为uint64;负数返回 ErrNumberOverflow .
+func weakUint(in reflect.Value) (uint64, error) {
+	switch in.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return in.Uint(), nil
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		if u, err := strconv.ParseUint(str, 10, 64); err == nil {
+			return u, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		f := in.Float()
+		if f >= 0 && f < math.MaxUint64 {
+			return uint64(f), nil
+		}
+	}
+
+	i, err := weakInt(in)
+	if err != nil {
+		return 0, err
+	} else if i < 0 {
+		return 0, fmt.Errorf("%d to uint64: %w", i, ErrNumberOverflow)
+	}
+	return uint64(i), nil
+}
+
+// weakFloat 弱类型转换为float64;数值字符串将被解析,空串为0.
+func weakFloat(in reflect.Value) (float64, error) {
+	switch in.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return toFloat(in.Interface()), nil
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		if str == "" {
+			return 0, nil
+		}
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as float", str)
+		}
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("cannot convert %s to float", in.Type())
+}
+
+// float2Int64 浮点数取整数部分转为int64,超出范围时返回 ErrNumberOverflow .
+func float2Int64(f float64) (int64, error) {
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%v to int64: %w", f, ErrNumberOverflow)
+	}
+	return int64(f), nil
+}
+
+// weakTime 弱类型转换为时间;字符串按 timeLayouts 解析(无时区的按本地时区),数值视为Unix秒数.
+func weakTime(in reflect.Value) (time.Time, error) {
+	switch in.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return time.Unix(in.Int(), 0), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return time.Unix(int64(in.Uint()), 0), nil
+	case reflect.Float32, reflect.Float64:
+		sec, frac := math.Modf(in.Float())
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		if str == "" {
+			return time.Time{}, nil
+		} else if ts, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return time.Unix(ts, 0), nil
+		}
+		for _, layout := range timeLayouts {
+			if tim, err := time.ParseInLocation(layout, str, kuptime.Location()); err == nil {
+				return tim, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as time", str)
+	}
+
+	return time.Time{}, fmt.Errorf("cannot convert %s to time.Time", in.Type())
+}
+
+// weakDuration 弱类型转换为时长;字符串按time.ParseDuration解析,数值视为纳秒数.
+func weakDuration(in reflect.Value) (time.Duration, error) {
+	switch in.Kind() {
+	case reflect.String:
+		str := strings.TrimSpace(in.String())
+		if str == "" {
+			return 0, nil
+		} else if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return time.Duration(i), nil
+		}
+		dur, err := time.ParseDuration(str)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as duration", str)
+		}
+		return dur, nil
+	case reflect.Bool:
+		return 0, fmt.Errorf("cannot convert %s to time.Duration", in.Type())
+	}
+
+	i, err := weakInt(in)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(i), nil
+}
@@ -0,0 +1,200 @@
+package kgo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMapBase struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type testMapAddr struct {
+	City string `json:"city"`
+	Zip  uint16 `json:"zip"`
+}
+
+type testMapUser struct {
+	testMapBase
+	Name     string            `json:"name"`
+	Age      uint8             `json:"age"`
+	Score    float32           `json:"score"`
+	Active   bool              `json:"active"`
+	Timeout  time.Duration     `json:"timeout"`
+	Tags     []string          `json:"tags"`
+	Nums     [2]int            `json:"nums"`
+	Addr     testMapAddr       `json:"addr"`
+	Backup   *testMapAddr      `json:"backup"`
+	Extra    map[string]int    `json:"extra"`
+	Any      interface{}       `json:"any"`
+	Raw      []byte            `json:"raw"`
+	Ignored  string            `json:"-"`
+	NoTag    string            ``
+	Labels   map[string]string `json:"labels"`
+	private  int
+	Nickname *string `json:"nickname"`
+}
+
+func TestMapStruct_Map2Struct(t *testing.T) {
+	var err error
+	var user testMapUser
+
+	m := map[string]interface{}{
+		"id":       "12",
+		"created":  "2021-03-04 05:06:07",
+		"name":     123,
+		"age":      "30",
+		"score":    "9.5",
+		"active":   "on",
+		"timeout":  "1m30s",
+		"tags":     []interface{}{"a", 2, true},
+		"nums":     []string{"1", "2"},
+		"addr":     map[string]interface{}{"city": "Beijing", "zip": 100000 % 65536},
+		"backup":   map[string]string{"CITY": "Shanghai"},
+		"extra":    map[string]interface{}{"x": "1", "y": 2.0},
+		"any":      []int{1},
+		"raw":      "bytes",
+		"Ignored":  "x",
+		"notag":    "case insensitive",
+		"labels":   map[interface{}]interface{}{"k": 1},
+		"private":  3,
+		"nickname": "kk",
+	}
+	err = KConv.Map2Struct(m, &user, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, 12, user.ID)
+	assert.Equal(t, "2021-03-04 05:06:07", user.Created.Format("2006-01-02 15:04:05"))
+	assert.Equal(t, "123", user.Name)
+	assert.Equal(t, uint8(30), user.Age)
+	assert.Equal(t, float32(9.5), user.Score)
+	assert.True(t, user.Active)
+	assert.Equal(t, 90*time.Second, user.Timeout)
+	assert.Equal(t, []string{"a", "2", "true"}, user.Tags)
+	assert.Equal(t, [2]int{1, 2}, user.Nums)
+	assert.Equal(t, testMapAddr{City: "Beijing", Zip: uint16(100000 % 65536)}, user.Addr)
+	assert.Equal(t, &testMapAddr{City: "Shanghai"}, user.Backup)
+	assert.Equal(t, map[string]int{"x": 1, "y": 2}, user.Extra)
+	assert.Equal(t, []int{1}, user.Any)
+	assert.Equal(t, []byte("bytes"), user.Raw)
+	assert.Empty(t, user.Ignored)
+	assert.Equal(t, "case insensitive", user.NoTag)
+	assert.Equal(t, map[string]string{"k": "1"}, user.Labels)
+	assert.Equal(t, 0, user.private)
+	assert.Equal(t, "kk", *user.Nickname)
+
+	//单个值转为切片;数值转时间
+	var user2 testMapUser
+	err = KConv.Map2Struct(map[string]interface{}{"tags": "solo", "created": int64(0), "timeout": 1000}, &user2, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"solo"}, user2.Tags)
+	assert.Equal(t, int64(0), user2.Created.Unix())
+	assert.Equal(t, time.Microsecond, user2.Timeout)
+
+	//无标签名时使用字段名;zip超出uint16范围
+	var addr testMapAddr
+	err = KConv.Map2Struct(map[string]string{"City": "Hangzhou", "zip": "310000"}, &addr, "")
+	assert.True(t, errors.Is(err.(*DecodeError).Fields[0], ErrNumberOverflow))
+	assert.Equal(t, "Hangzhou", addr.City)
+}
+
+func BenchmarkMapStruct_Map2Struct(b *testing.B) {
+	m := map[string]interface{}{"id": "1", "name": "kgo", "tags": []string{"a", "b"}, "addr": map[string]interface{}{"city": "x"}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var user testMapUser
+		_ = KConv.Map2Struct(m, &user, "json")
+	}
+}
+
+func TestMapStruct_DecodeError(t *testing.T) {
+	var user testMapUser
+	m := map[string]interface{}{
+		"id":      "abc",
+		"age":     300,
+		"active":  "maybe",
+		"timeout": "soon",
+		"created": "yesterday",
+		"tags":    []interface{}{"ok", map[string]int{}},
+		"addr":    "not a map",
+		"name":    "still set",
+	}
+
+	err := KConv.Map2Struct(m, &user, "json")
+	var de *DecodeError
+	assert.True(t, errors.As(err, &de))
+	assert.Equal(t, 7, len(de.Fields))
+
+	fields := make([]string, len(de.Fields))
+	for i, fe := range de.Fields {
+		fields[i] = fe.Field
+	}
+	for _, f := range []string{"id", "age", "active", "timeout", "created", "tags[1]", "addr"} {
+		assert.Contains(t, fields, f)
+	}
+	assert.Contains(t, err.Error(), "[Map2Struct]`7 field(s) failed")
+	assert.True(t, errors.Is(de.Fields[indexOf(fields, "age")], ErrNumberOverflow))
+	assert.Equal(t, "still set", user.Name)
+
+	//参数错误
+	err = KConv.Map2Struct(m, user, "json")
+	assert.NotNil(t, err)
+	err = KConv.Map2Struct([]int{1}, &user, "json")
+	assert.NotNil(t, err)
+}
+
+func indexOf(arr []string, str string) int {
+	for i, v := range arr {
+		if v == str {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestMapStruct_DecodeHook(t *testing.T) {
+	var user testMapUser
+
+	//逗号分隔的字符串转切片
+	hook := func(data interface{}, to reflect.Type) (interface{}, error) {
+		if str, ok := data.(string); ok && to.Kind() == reflect.Slice && to.Elem().Kind() == reflect.String {
+			return strings.Split(str, ","), nil
+		}
+		return data, nil
+	}
+	err := KConv.Map2Struct(map[string]interface{}{"tags": "a,b,c", "name": "kgo"}, &user, "json", hook)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, user.Tags)
+	assert.Equal(t, "kgo", user.Name)
+
+	//钩子返回错误
+	failHook := func(data interface{}, to reflect.Type) (interface{}, error) {
+		if to.Kind() == reflect.Bool {
+			return nil, errors.New("no bool")
+		}
+		return data, nil
+	}
+	err = KConv.Map2Struct(map[string]interface{}{"active": true}, &user, "json", failHook)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "active: no bool")
+}
+
+func TestMapStruct_ParseStr(t *testing.T) {
+	var user testMapUser
+	m := make(map[string]interface{})
+	err := KStr.ParseStr("id=5&name=kgo&tags[]=x&tags[]=y&addr[city]=Shenzhen&active=1", m)
+	assert.Nil(t, err)
+
+	err = KConv.Map2Struct(m, &user, "json")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, user.ID)
+	assert.Equal(t, "kgo", user.Name)
+	assert.Equal(t, []string{"x", "y"}, user.Tags)
+	assert.Equal(t, "Shenzhen", user.Addr.City)
+	assert.True(t, user.Active)
+}
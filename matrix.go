@@ -0,0 +1,355 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+)
+
+// LUDecomposition 方阵的LU分解结果(部分主元),满足 P*A = L*U .
+type LUDecomposition struct {
+	lu       [][]float64
+	pivot    []int
+	sign     float64
+	singular bool
+}
+
+var (
+	// ErrMatrixShape 矩阵形状不符,如非矩形、非方阵或行列数不匹配
+	ErrMatrixShape = errors.New("[Matrix]`incompatible matrix shape")
+	// ErrSingularMatrix 奇异矩阵,不可逆
+	ErrSingularMatrix = errors.New("[Matrix]`matrix is singular")
+)
+
+// matrixShape 获取矩阵的行数和列数,矩阵为空或各行长度不一致时返回错误.
+func matrixShape(m [][]float64) (rows, cols int, err error) {
+	rows = len(m)
+	if rows == 0 || len(m[0]) == 0 {
+		return 0, 0, ErrEmptyInput
+	}
+
+	cols = len(m[0])
+	for _, row := range m {
+		if len(row) != cols {
+			return 0, 0, ErrMatrixShape
+		}
+	}
+	return
+}
+
+// newMatrix 创建rows行cols列的零矩阵.
+func newMatrix(rows, cols int) [][]float64 {
+	data := make([]float64, rows*cols)
+	res := make([][]float64, rows)
+	for i := range res {
+		res[i] = data[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return res
+}
+
+// checkVectors 检查两个向量长度一致且非空.
+func checkVectors(a, b []float64) error {
+	if len(a) == 0 || len(b) == 0 {
+		return ErrEmptyInput
+	} else if len(a) != len(b) {
+		return ErrLengthMismatch
+	}
+	return nil
+}
+
+// VectorDot 向量点积;长度不一致时返回 ErrLengthMismatch .
+func (kn *LkkNumber) VectorDot(a, b []float64) (float64, error) {
+	if err := checkVectors(a, b); err != nil {
+		return 0, err
+	}
+
+	var res float64
+	for i := range a {
+		res += a[i] * b[i]
+	}
+	return res, nil
+}
+
+// VectorNorm 向量的欧几里得范数(长度).
+func (kn *LkkNumber) VectorNorm(a []float64) float64 {
+	var res float64
+	for _, v := range a {
+		res = math.Hypot(res, v)
+	}
+	return res
+}
+
+// CosineSimilarity 余弦相似度,取值[-1, 1];含零向量时返回 ErrInvalidParam .
+func (kn *LkkNumber) CosineSimilarity(a, b []float64) (float64, error) {
+	dot, err := kn.VectorDot(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	na, nb := kn.VectorNorm(a), kn.VectorNorm(b)
+	if na == 0 || nb == 0 {
+		return 0, ErrInvalidParam
+	}
+
+	res := dot / (na * nb)
+	//浮点误差修正
+	return math.Max(-1, math.Min(1, res)), nil
+}
+
+// EuclideanDistance 两向量的欧几里得距离.
+func (kn *LkkNumber) EuclideanDistance(a, b []float64) (float64, error) {
+	if err := checkVectors(a, b); err != nil {
+		return 0, err
+	}
+
+	var res float64
+	for i := range a {
+		res = math.Hypot(res, a[i]-b[i])
+	}
+	return res, nil
+}
+
+// ManhattanDistance 两向量的曼哈顿距离.
+func (kn *LkkNumber) ManhattanDistance(a, b []float64) (float64, error) {
+	if err := checkVectors(a, b); err != nil {
+		return 0, err
+	}
+
+	var res float64
+	for i := range a {
+		res += math.Abs(a[i] - b[i])
+	}
+	return res, nil
+}
+
+// MatrixMultiply 矩阵乘法a*b;a的列数须等于b的行数,否则返回 ErrMatrixShape .
+func (kn *LkkNumber) MatrixMultiply(a, b [][]float64) ([][]float64, error) {
+	ar, ac, err := matrixShape(a)
+	if err != nil {
+		return nil, err
+	}
+	br, bc, err := matrixShape(b)
+	if err != nil {
+		return nil, err
+	} else if ac != br {
+		return nil, ErrMatrixShape
+	}
+
+	res := newMatrix(ar, bc)
+	for i := 0; i < ar; i++ {
+		for k := 0; k < ac; k++ {
+			aik := a[i][k]
+			if aik == 0 {
+				continue
+			}
+			for j := 0; j < bc; j++ {
+				res[i][j] += aik * b[k][j]
+			}
+		}
+	}
+	return res, nil
+}
+
+// MatrixTranspose 矩阵转置.
+func (kn *LkkNumber) MatrixTranspose(m [][]float64) ([][]float64, error) {
+	rows, cols, err := matrixShape(m)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newMatrix(cols, rows)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			res[j][i] = m[i][j]
+		}
+	}
+	return res, nil
+}
+
+// LUDecompose 对方阵进行带部分主元的LU分解;奇异矩阵也可分解,其行列式为0.
+func (kn *LkkNumber) LUDecompose(m [][]float64) (*LUDecomposition, error) {
+	rows, cols, err := matrixShape(m)
+	if err != nil {
+		return nil, err
+	} else if rows != cols {
+		return nil, ErrMatrixShape
+	}
+
+	n := rows
+	lu := newMatrix(n, n)
+	var maxAbs float64
+	for i := range m {
+		copy(lu[i], m[i])
+		for _, v := range m[i] {
+			maxAbs = math.Max(maxAbs, math.Abs(v))
+		}
+	}
+
+	res := &LUDecomposition{lu: lu, pivot: make([]int, n), sign: 1}
+	for i := range res.pivot {
+		res.pivot[i] = i
+	}
+
+	eps := maxAbs * float64(n) * 1e-15
+	for k := 0; k < n; k++ {
+		//选主元
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i][k]) > math.Abs(lu[p][k]) {
+				p = i
+			}
+		}
+		if p != k {
+			lu[p], lu[k] = lu[k], lu[p]
+			res.pivot[p], res.pivot[k] = res.pivot[k], res.pivot[p]
+			res.sign = -res.sign
+		}
+
+		if math.Abs(lu[k][k]) <= eps {
+			res.singular = true
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			f := lu[i][k]
+			if f == 0 {
+				continue
+			}
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= f * lu[k][j]
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// L 获取单位下三角矩阵L.
+func (d *LUDecomposition) L() [][]float64 {
+	n := len(d.lu)
+	res := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			res[i][j] = d.lu[i][j]
+		}
+		res[i][i] = 1
+	}
+	return res
+}
+
+// U 获取上三角矩阵U.
+func (d *LUDecomposition) U() [][]float64 {
+	n := len(d.lu)
+	res := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		copy(res[i][i:], d.lu[i][i:])
+	}
+	return res
+}
+
+// Pivot 获取行置换,第i行为原矩阵的第Pivot()[i]行.
+func (d *LUDecomposition) Pivot() []int {
+	res := make([]int, len(d.pivot))
+	copy(res, d.pivot)
+	return res
+}
+
+// IsSingular 是否奇异矩阵.
+func (d *LUDecomposition) IsSingular() bool {
+	return d.singular
+}
+
+// Determinant 行列式.
+func (d *LUDecomposition) Determinant() float64 {
+	if d.singular {
+		return 0
+	}
+
+	res := d.sign
+	for i := range d.lu {
+		res *= d.lu[i][i]
+	}
+	return res
+}
+
+// Solve 求解线性方程组 A*x = b ;矩阵奇异时返回 ErrSingularMatrix .
+func (d *LUDecomposition) Solve(b []float64) ([]float64, error) {
+	n := len(d.lu)
+	if len(b) != n {
+		return nil, ErrLengthMismatch
+	} else if d.singular {
+		return nil, ErrSingularMatrix
+	}
+
+	//前代 L*y = P*b
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = b[d.pivot[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= d.lu[i][j] * x[j]
+		}
+	}
+
+	//回代 U*x = y
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= d.lu[i][j] * x[j]
+		}
+		x[i] /= d.lu[i][i]
+	}
+
+	return x, nil
+}
+
+// Inverse 逆矩阵;矩阵奇异时返回 ErrSingularMatrix .
+func (d *LUDecomposition) Inverse() ([][]float64, error) {
+	if d.singular {
+		return nil, ErrSingularMatrix
+	}
+
+	n := len(d.lu)
+	res := newMatrix(n, n)
+	e := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := range e {
+			e[i] = 0
+		}
+		e[j] = 1
+
+		col, err := d.Solve(e)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			res[i][j] = col[i]
+		}
+	}
+	return res, nil
+}
+
+// MatrixDeterminant 方阵的行列式;非方阵时返回 ErrMatrixShape .
+func (kn *LkkNumber) MatrixDeterminant(m [][]float64) (float64, error) {
+	d, err := kn.LUDecompose(m)
+	if err != nil {
+		return 0, err
+	}
+	return d.Determinant(), nil
+}
+
+// MatrixInverse 方阵的逆矩阵;非方阵时返回 ErrMatrixShape ,奇异矩阵返回 ErrSingularMatrix .
+func (kn *LkkNumber) MatrixInverse(m [][]float64) ([][]float64, error) {
+	d, err := kn.LUDecompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Inverse()
+}
+
+// SolveLinear 求解线性方程组 a*x = b ,a为系数方阵,b为常数向量.
+func (kn *LkkNumber) SolveLinear(a [][]float64, b []float64) ([]float64, error) {
+	d, err := kn.LUDecompose(a)
+	if err != nil {
+		return nil, err
+	}
+	return d.Solve(b)
+}
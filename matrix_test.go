@@ -0,0 +1,252 @@
+package kgo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix_Vector(t *testing.T) {
+	var res float64
+	var err error
+
+	a, b := []float64{1, 2, 3}, []float64{4, 5, 6}
+
+	res, err = KNum.VectorDot(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, 32.0, res)
+
+	assert.InDelta(t, math.Sqrt(14), KNum.VectorNorm(a), 1e-12)
+	assert.Equal(t, 0.0, KNum.VectorNorm(nil))
+
+	res, err = KNum.CosineSimilarity(a, b)
+	assert.Nil(t, err)
+	assert.InDelta(t, 32/(math.Sqrt(14)*math.Sqrt(77)), res, 1e-12)
+
+	res, err = KNum.CosineSimilarity(a, []float64{2, 4, 6})
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, res)
+
+	res, err = KNum.CosineSimilarity([]float64{1, 0}, []float64{-1, 0})
+	assert.Nil(t, err)
+	assert.Equal(t, -1.0, res)
+
+	res, err = KNum.EuclideanDistance(a, b)
+	assert.Nil(t, err)
+	assert.InDelta(t, math.Sqrt(27), res, 1e-12)
+
+	res, err = KNum.ManhattanDistance(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, 9.0, res)
+
+	//错误
+	_, err = KNum.VectorDot(a, []float64{1})
+	assert.Equal(t, ErrLengthMismatch, err)
+	_, err = KNum.VectorDot(nil, nil)
+	assert.Equal(t, ErrEmptyInput, err)
+	_, err = KNum.CosineSimilarity(a, []float64{0, 0, 0})
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.CosineSimilarity(a, nil)
+	assert.Equal(t, ErrEmptyInput, err)
+	_, err = KNum.EuclideanDistance(a, []float64{1})
+	assert.Equal(t, ErrLengthMismatch, err)
+	_, err = KNum.ManhattanDistance(a, []float64{1})
+	assert.Equal(t, ErrLengthMismatch, err)
+}
+
+func BenchmarkMatrix_CosineSimilarity(b *testing.B) {
+	x, y := []float64{1, 2, 3, 4, 5}, []float64{5, 4, 3, 2, 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.CosineSimilarity(x, y)
+	}
+}
+
+func TestMatrix_MatrixMultiply(t *testing.T) {
+	var res [][]float64
+	var err error
+
+	a := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	b := [][]float64{{7, 8}, {9, 10}, {11, 12}}
+	res, err = KNum.MatrixMultiply(a, b)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]float64{{58, 64}, {139, 154}}, res)
+
+	_, err = KNum.MatrixMultiply(a, a)
+	assert.Equal(t, ErrMatrixShape, err)
+	_, err = KNum.MatrixMultiply(a, [][]float64{{1}, {2, 3}})
+	assert.Equal(t, ErrMatrixShape, err)
+	_, err = KNum.MatrixMultiply(nil, b)
+	assert.Equal(t, ErrEmptyInput, err)
+	_, err = KNum.MatrixMultiply(a, [][]float64{{}})
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkMatrix_MatrixMultiply(b *testing.B) {
+	m := [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MatrixMultiply(m, m)
+	}
+}
+
+func TestMatrix_MatrixTranspose(t *testing.T) {
+	var res [][]float64
+	var err error
+
+	res, err = KNum.MatrixTranspose([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]float64{{1, 4}, {2, 5}, {3, 6}}, res)
+
+	_, err = KNum.MatrixTranspose([][]float64{{1, 2}, {3}})
+	assert.Equal(t, ErrMatrixShape, err)
+}
+
+func BenchmarkMatrix_MatrixTranspose(b *testing.B) {
+	m := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MatrixTranspose(m)
+	}
+}
+
+func TestMatrix_LUDecompose(t *testing.T) {
+	m := [][]float64{{0, 2, 1}, {1, 1, 0}, {2, 1, 3}}
+	d, err := KNum.LUDecompose(m)
+	assert.Nil(t, err)
+	assert.False(t, d.IsSingular())
+
+	//P*A = L*U
+	lu, _ := KNum.MatrixMultiply(d.L(), d.U())
+	for i, p := range d.Pivot() {
+		for j := range m[p] {
+			assert.InDelta(t, m[p][j], lu[i][j], 1e-12)
+		}
+	}
+
+	//输入不被修改
+	assert.Equal(t, [][]float64{{0, 2, 1}, {1, 1, 0}, {2, 1, 3}}, m)
+
+	_, err = KNum.LUDecompose([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, ErrMatrixShape, err)
+
+	d, err = KNum.LUDecompose([][]float64{{1, 2}, {2, 4}})
+	assert.Nil(t, err)
+	assert.True(t, d.IsSingular())
+	_, err = d.Solve([]float64{1, 2})
+	assert.Equal(t, ErrSingularMatrix, err)
+}
+
+func BenchmarkMatrix_LUDecompose(b *testing.B) {
+	m := [][]float64{{4, 3, 2}, {2, 1, 3}, {3, 2, 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.LUDecompose(m)
+	}
+}
+
+func TestMatrix_MatrixDeterminant(t *testing.T) {
+	var res float64
+	var err error
+
+	res, err = KNum.MatrixDeterminant([][]float64{{5}})
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, res)
+
+	res, err = KNum.MatrixDeterminant([][]float64{{1, 2}, {3, 4}})
+	assert.Nil(t, err)
+	assert.InDelta(t, -2, res, 1e-12)
+
+	res, err = KNum.MatrixDeterminant([][]float64{{0, 2, 1}, {1, 1, 0}, {2, 1, 3}})
+	assert.Nil(t, err)
+	assert.InDelta(t, -7, res, 1e-12)
+
+	res, err = KNum.MatrixDeterminant([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, res)
+
+	_, err = KNum.MatrixDeterminant([][]float64{{1, 2}})
+	assert.Equal(t, ErrMatrixShape, err)
+}
+
+func BenchmarkMatrix_MatrixDeterminant(b *testing.B) {
+	m := [][]float64{{4, 3, 2}, {2, 1, 3}, {3, 2, 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MatrixDeterminant(m)
+	}
+}
+
+func TestMatrix_MatrixInverse(t *testing.T) {
+	var res [][]float64
+	var err error
+
+	m := [][]float64{{4, 7}, {2, 6}}
+	res, err = KNum.MatrixInverse(m)
+	assert.Nil(t, err)
+	expected := [][]float64{{0.6, -0.7}, {-0.2, 0.4}}
+	for i := range expected {
+		for j := range expected[i] {
+			assert.InDelta(t, expected[i][j], res[i][j], 1e-12)
+		}
+	}
+
+	//A*A^-1 = I
+	m = [][]float64{{0, 2, 1}, {1, 1, 0}, {2, 1, 3}}
+	res, err = KNum.MatrixInverse(m)
+	assert.Nil(t, err)
+	id, _ := KNum.MatrixMultiply(m, res)
+	for i := range id {
+		for j := range id[i] {
+			if i == j {
+				assert.InDelta(t, 1, id[i][j], 1e-12)
+			} else {
+				assert.InDelta(t, 0, id[i][j], 1e-12)
+			}
+		}
+	}
+
+	_, err = KNum.MatrixInverse([][]float64{{1, 2}, {2, 4}})
+	assert.Equal(t, ErrSingularMatrix, err)
+	_, err = KNum.MatrixInverse([][]float64{{1, 2}})
+	assert.Equal(t, ErrMatrixShape, err)
+}
+
+func BenchmarkMatrix_MatrixInverse(b *testing.B) {
+	m := [][]float64{{4, 3, 2}, {2, 1, 3}, {3, 2, 1}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MatrixInverse(m)
+	}
+}
+
+func TestMatrix_SolveLinear(t *testing.T) {
+	var res []float64
+	var err error
+
+	//2x+y-z=8, -3x-y+2z=-11, -2x+y+2z=-3
+	a := [][]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}}
+	res, err = KNum.SolveLinear(a, []float64{8, -11, -3})
+	assert.Nil(t, err)
+	expected := []float64{2, 3, -1}
+	for i := range expected {
+		assert.InDelta(t, expected[i], res[i], 1e-12)
+	}
+
+	_, err = KNum.SolveLinear(a, []float64{1, 2})
+	assert.Equal(t, ErrLengthMismatch, err)
+	_, err = KNum.SolveLinear([][]float64{{1, 1}, {1, 1}}, []float64{1, 2})
+	assert.Equal(t, ErrSingularMatrix, err)
+	_, err = KNum.SolveLinear(nil, nil)
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkMatrix_SolveLinear(b *testing.B) {
+	a := [][]float64{{2, 1, -1}, {-3, -1, 2}, {-2, 1, 2}}
+	y := []float64{8, -11, -3}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.SolveLinear(a, y)
+	}
+}
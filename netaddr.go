@@ -0,0 +1,309 @@
+package kgo
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+)
+
+// CIDRInfo CIDR网段的信息
+type CIDRInfo struct {
+	Network   string   `json:"network"`   //网络地址
+	Netmask   string   `json:"netmask"`   //子网掩码
+	Prefix    int      `json:"prefix"`    //前缀长度
+	First     string   `json:"first"`     //第一个可用地址
+	Last      string   `json:"last"`      //最后一个可用地址
+	Broadcast string   `json:"broadcast"` //广播地址,IPv6无广播地址,为空
+	Size      *big.Int `json:"size"`      //地址总数
+}
+
+// ipRange 同一地址族的连续地址区间
+type ipRange struct {
+	start, end *big.Int //起止地址,包含两端
+	bits       int      //地址位数,IPv4为32,IPv6为128
+}
+
+// ipParse 解析IP地址,忽略IPv6的区域(如"fe80::1%eth0");返回IPv4的4字节形式或IPv6的16字节形式,及地址位数.
+func ipParse(str string) (net.IP, int, error) {
+	if i := strings.IndexByte(str, '%'); i > 0 && strings.Contains(str, ":") {
+		str = str[:i]
+	}
+
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, 0, fmt.Errorf("[Ip]`%q is not valid ip: %w", str, ErrInvalidParam)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		return ip4, 32, nil
+	}
+	return ip, 128, nil
+}
+
+// int2Ip 将整数转为bits位的IP地址.
+func int2Ip(n *big.Int, bits int) net.IP {
+	return net.IP(n.FillBytes(make([]byte, bits/8)))
+}
+
+// cidrRange 将IP地址或CIDR解析为地址区间;
+// IPv4映射的地址及"::ffff:0:0/96"内的网段(如"::ffff:10.0.0.0/104")按IPv4处理,同 ipParse .
+func cidrRange(str string) (*ipRange, error) {
+	if !strings.Contains(str, "/") {
+		ip, bits, err := ipParse(str)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).SetBytes(ip)
+		return &ipRange{start: n, end: n, bits: bits}, nil
+	}
+
+	_, ipnet, err := net.ParseCIDR(str)
+	if err != nil {
+		return nil, fmt.Errorf("[Ip]`%q is not valid cidr: %w", str, ErrInvalidParam)
+	}
+	return ipNetRange(ipNetUnmap(ipnet)), nil
+}
+
+// ipNetUnmap 将"::ffff:0:0/96"内的IPv4映射网段转为IPv4网段,如"::ffff:10.0.0.0/104"转为"10.0.0.0/8";其他网段原样返回.
+func ipNetUnmap(ipnet *net.IPNet) *net.IPNet {
+	if ones, bits := ipnet.Mask.Size(); bits == 128 && ones >= 96 && ipnet.IP.To4() != nil {
+		return &net.IPNet{IP: ipnet.IP.To4(), Mask: net.CIDRMask(ones-96, 32)}
+	}
+	return ipnet
+}
+
+// ipNetRange 获取网段的地址区间.
+func ipNetRange(ipnet *net.IPNet) *ipRange {
+	ones, bits := ipnet.Mask.Size()
+	ip := ipnet.IP.To16()
+	if bits == 32 {
+		ip = ipnet.IP.To4()
+	}
+	start := new(big.Int).SetBytes(ip)
+	end := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	end.Add(end, start).Sub(end, big.NewInt(1))
+
+	return &ipRange{start: start, end: end, bits: bits}
+}
+
+// ipRange2CIDR 将地址区间拆分为最少的CIDR.
+func ipRange2CIDR(r *ipRange) []string {
+	var res []string
+	one := big.NewInt(1)
+	start := new(big.Int).Set(r.start)
+	for start.Cmp(r.end) <= 0 {
+		//块大小受起始地址的对齐和剩余地址数限制
+		k := r.bits
+		if start.Sign() != 0 {
+			k = int(start.TrailingZeroBits())
+		}
+		remain := new(big.Int).Sub(r.end, start)
+		if n := remain.Add(remain, one).BitLen() - 1; n < k {
+			k = n
+		}
+
+		res = append(res, fmt.Sprintf("%s/%d", int2Ip(start, r.bits), r.bits-k))
+		start.Add(start, new(big.Int).Lsh(one, uint(k)))
+	}
+	return res
+}
+
+// Ip2BigInt 将IPv4或IPv6地址转换为大整数;IPv4地址的结果同 Ip2Long .
+func (kc *LkkConvert) Ip2BigInt(ipAddress string) (*big.Int, error) {
+	ip, _, err := ipParse(ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(ip), nil
+}
+
+// BigInt2Ip 将大整数转换为IP地址,为 Ip2BigInt 的逆操作;ipv6为false时转为IPv4地址.
+// n为负数或超出地址范围时返回 ErrNumberOverflow .
+func (kc *LkkConvert) BigInt2Ip(n *big.Int, ipv6 bool) (string, error) {
+	bits := 32
+	if ipv6 {
+		bits = 128
+	}
+	if n == nil || n.Sign() < 0 || n.BitLen() > bits {
+		return "", fmt.Errorf("[BigInt2Ip]`%v: %w", n, ErrNumberOverflow)
+	}
+	return int2Ip(n, bits).String(), nil
+}
+
+// Ip2Bytes 将IP地址转换为16字节数组;IPv4地址将转为IPv4映射的IPv6形式(::ffff:a.b.c.d).
+func (kc *LkkConvert) Ip2Bytes(ipAddress string) ([16]byte, error) {
+	var res [16]byte
+	ip, _, err := ipParse(ipAddress)
+	if err != nil {
+		return res, err
+	}
+	copy(res[:], ip.To16())
+	return res, nil
+}
+
+// Bytes2Ip 将16字节数组转换为IP地址,为 Ip2Bytes 的逆操作;IPv4映射的地址将转为IPv4形式.
+func (kc *LkkConvert) Bytes2Ip(b [16]byte) string {
+	return net.IP(b[:]).String()
+}
+
+// ParseCIDR 解析CIDR(如"192.168.1.0/24"、"2001:db8::/32"),获取其网络地址、可用地址范围、广播地址和地址数.
+// IPv4的/31和/32网段所有地址均可用(RFC 3021),其他网段不含网络地址和广播地址;IPv6的所有地址均可用.
+// IPv4映射的网段按IPv4处理,如"::ffff:10.0.0.0/104"同"10.0.0.0/8".
+func (ko *LkkOS) ParseCIDR(cidr string) (*CIDRInfo, error) {
+	_, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("[ParseCIDR]`%q is not valid cidr: %w", cidr, ErrInvalidParam)
+	}
+	ipnet = ipNetUnmap(ipnet)
+
+	r := ipNetRange(ipnet)
+	ones, bits := ipnet.Mask.Size()
+	res := &CIDRInfo{
+		Network: int2Ip(r.start, bits).String(),
+		Netmask: net.IP(ipnet.Mask).String(),
+		Prefix:  ones,
+		First:   int2Ip(r.start, bits).String(),
+		Last:    int2Ip(r.end, bits).String(),
+		Size:    new(big.Int).Add(new(big.Int).Sub(r.end, r.start), big.NewInt(1)),
+	}
+	if bits == 32 {
+		res.Broadcast = res.Last
+		if ones < 31 {
+			res.First = int2Ip(new(big.Int).Add(r.start, big.NewInt(1)), bits).String()
+			res.Last = int2Ip(new(big.Int).Sub(r.end, big.NewInt(1)), bits).String()
+		}
+	}
+
+	return res, nil
+}
+
+// IpRangeHosts 枚举start至end(包含两端)之间的所有地址;start和end须为同一地址族且start不大于end.
+// max为最多允许的地址数,超出时返回 ErrLimitExceeded ,以防止枚举过大的范围.
+func (ko *LkkOS) IpRangeHosts(start, end string, max int) ([]string, error) {
+	r, err := ipPairRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return ipEnumerate(r, max)
+}
+
+// CIDRHosts 枚举CIDR网段的所有可用地址,可用地址的范围见 ParseCIDR ;max同 IpRangeHosts .
+func (ko *LkkOS) CIDRHosts(cidr string, max int) ([]string, error) {
+	info, err := ko.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	r, _ := ipPairRange(info.First, info.Last)
+	return ipEnumerate(r, max)
+}
+
+// ipPairRange 将起止地址转为区间.
+func ipPairRange(start, end string) (*ipRange, error) {
+	first, err := cidrRange(start)
+	if err != nil || strings.Contains(start, "/") {
+		return nil, fmt.Errorf("[Ip]`%q is not valid ip: %w", start, ErrInvalidParam)
+	}
+	last, err := cidrRange(end)
+	if err != nil || strings.Contains(end, "/") {
+		return nil, fmt.Errorf("[Ip]`%q is not valid ip: %w", end, ErrInvalidParam)
+	}
+
+	if first.bits != last.bits {
+		return nil, fmt.Errorf("[Ip]`%s and %s are different address families: %w", start, end, ErrInvalidParam)
+	} else if first.start.Cmp(last.start) > 0 {
+		return nil, fmt.Errorf("[Ip]`%s is greater than %s: %w", start, end, ErrInvalidParam)
+	}
+	return &ipRange{start: first.start, end: last.start, bits: first.bits}, nil
+}
+
+// ipEnumerate 枚举区间的地址,超过max个时返回 ErrLimitExceeded .
+func ipEnumerate(r *ipRange, max int) ([]string, error) {
+	size := new(big.Int).Sub(r.end, r.start)
+	size.Add(size, big.NewInt(1))
+	if !size.IsInt64() || size.Int64() > int64(max) {
+		return nil, fmt.Errorf("[Ip]`range has %s addresses, max %d: %w", size, max, ErrLimitExceeded)
+	}
+
+	res := make([]string, 0, size.Int64())
+	for n := new(big.Int).Set(r.start); n.Cmp(r.end) <= 0; n.Add(n, big.NewInt(1)) {
+		res = append(res, int2Ip(n, r.bits).String())
+	}
+	return res, nil
+}
+
+// CIDRContains 检查CIDR网段outer是否包含inner;inner可以是IP地址或CIDR,地址族不同时返回false.
+// IPv4映射的地址和网段视为IPv4,如"::ffff:10.0.0.1"既包含于"10.0.0.0/8",也包含于"::ffff:0:0/96".
+func (ko *LkkOS) CIDRContains(outer, inner string) (bool, error) {
+	a, err := cidrRange(outer)
+	if err != nil {
+		return false, err
+	}
+	b, err := cidrRange(inner)
+	if err != nil {
+		return false, err
+	}
+
+	return a.bits == b.bits && a.start.Cmp(b.start) <= 0 && a.end.Cmp(b.end) >= 0, nil
+}
+
+// CIDROverlap 检查两个CIDR网段(或IP地址)是否有重叠的地址.
+func (ko *LkkOS) CIDROverlap(a, b string) (bool, error) {
+	x, err := cidrRange(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := cidrRange(b)
+	if err != nil {
+		return false, err
+	}
+
+	return x.bits == y.bits && x.start.Cmp(y.end) <= 0 && y.start.Cmp(x.end) <= 0, nil
+}
+
+// CIDRMerge 将IP地址和CIDR的列表聚合为最少的CIDR,重叠和相邻的网段将被合并.
+// 结果中IPv4在前,IPv6在后,各自按地址排序;单个地址表示为/32或/128.
+func (ko *LkkOS) CIDRMerge(addrs []string) ([]string, error) {
+	ranges := make([]*ipRange, 0, len(addrs))
+	for _, addr := range addrs {
+		r, err := cidrRange(strings.TrimSpace(addr))
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].bits != ranges[j].bits {
+			return ranges[i].bits < ranges[j].bits
+		}
+		return ranges[i].start.Cmp(ranges[j].start) < 0
+	})
+
+	res := make([]string, 0, len(ranges))
+	var cur *ipRange
+	for _, r := range ranges {
+		if cur != nil && cur.bits == r.bits && new(big.Int).Add(cur.end, big.NewInt(1)).Cmp(r.start) >= 0 {
+			if r.end.Cmp(cur.end) > 0 {
+				cur.end = r.end
+			}
+			continue
+		}
+		if cur != nil {
+			res = append(res, ipRange2CIDR(cur)...)
+		}
+		cur = &ipRange{start: r.start, end: r.end, bits: r.bits}
+	}
+	if cur != nil {
+		res = append(res, ipRange2CIDR(cur)...)
+	}
+
+	return res, nil
+}
+
+// IpRange2CIDR 将start至end(包含两端)的地址范围拆分为最少的CIDR列表.
+func (ko *LkkOS) IpRange2CIDR(start, end string) ([]string, error) {
+	r, err := ipPairRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return ipRange2CIDR(r), nil
+}
@@ -0,0 +1,373 @@
+package kgo
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetaddr_Ip2BigInt(t *testing.T) {
+	var res *big.Int
+	var err error
+
+	res, err = KConv.Ip2BigInt(lanIp)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(lanIpInt), res.Int64())
+
+	res, err = KConv.Ip2BigInt("::1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Int64())
+
+	res, err = KConv.Ip2BigInt("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	assert.Nil(t, err)
+	max := new(big.Int).Lsh(big.NewInt(1), 128)
+	assert.Equal(t, 0, res.Cmp(max.Sub(max, big.NewInt(1))))
+
+	res, err = KConv.Ip2BigInt("fe80::1%eth0")
+	assert.Nil(t, err)
+	assert.Equal(t, "fe800000000000000000000000000001", res.Text(16))
+
+	_, err = KConv.Ip2BigInt(strHello)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_Ip2BigInt(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Ip2BigInt(googleIpv6)
+	}
+}
+
+func TestNetaddr_BigInt2Ip(t *testing.T) {
+	var res string
+	var err error
+
+	res, err = KConv.BigInt2Ip(big.NewInt(int64(lanIpInt)), false)
+	assert.Nil(t, err)
+	assert.Equal(t, lanIp, res)
+
+	res, err = KConv.BigInt2Ip(big.NewInt(1), true)
+	assert.Nil(t, err)
+	assert.Equal(t, "::1", res)
+
+	n, _ := KConv.Ip2BigInt(googleIpv6)
+	res, err = KConv.BigInt2Ip(n, true)
+	assert.Nil(t, err)
+	assert.Equal(t, googleIpv6, res)
+
+	_, err = KConv.BigInt2Ip(big.NewInt(1<<32), false)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KConv.BigInt2Ip(big.NewInt(-1), true)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KConv.BigInt2Ip(nil, true)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+}
+
+func BenchmarkNetaddr_BigInt2Ip(b *testing.B) {
+	n, _ := KConv.Ip2BigInt(googleIpv6)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.BigInt2Ip(n, true)
+	}
+}
+
+func TestNetaddr_Ip2Bytes(t *testing.T) {
+	var res [16]byte
+	var err error
+
+	res, err = KConv.Ip2Bytes(lanIp)
+	assert.Nil(t, err)
+	assert.Equal(t, [16]byte{10: 0xff, 11: 0xff, 12: 192, 13: 168, 14: 0, 15: 1}, res)
+	assert.Equal(t, lanIp, KConv.Bytes2Ip(res))
+
+	res, err = KConv.Ip2Bytes("2001:db8::1")
+	assert.Nil(t, err)
+	assert.Equal(t, [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}, res)
+	assert.Equal(t, "2001:db8::1", KConv.Bytes2Ip(res))
+
+	_, err = KConv.Ip2Bytes("1.2.3")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkNetaddr_Ip2Bytes(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Ip2Bytes(googleIpv6)
+	}
+}
+
+func TestNetaddr_Bytes2Ip(t *testing.T) {
+	assert.Equal(t, "::", KConv.Bytes2Ip([16]byte{}))
+	assert.Equal(t, "::ffff", KConv.Bytes2Ip([16]byte{14: 0xff, 15: 0xff}))
+	assert.Equal(t, "0.0.0.1", KConv.Bytes2Ip([16]byte{10: 0xff, 11: 0xff, 15: 1}))
+}
+
+func BenchmarkNetaddr_Bytes2Ip(b *testing.B) {
+	ip := [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KConv.Bytes2Ip(ip)
+	}
+}
+
+func TestNetaddr_ParseCIDR(t *testing.T) {
+	var res *CIDRInfo
+	var err error
+
+	res, err = KOS.ParseCIDR("192.168.1.77/24")
+	assert.Nil(t, err)
+	assert.Equal(t, "192.168.1.0", res.Network)
+	assert.Equal(t, "255.255.255.0", res.Netmask)
+	assert.Equal(t, 24, res.Prefix)
+	assert.Equal(t, "192.168.1.1", res.First)
+	assert.Equal(t, "192.168.1.254", res.Last)
+	assert.Equal(t, "192.168.1.255", res.Broadcast)
+	assert.Equal(t, int64(256), res.Size.Int64())
+
+	res, err = KOS.ParseCIDR("10.0.0.0/31")
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.0", res.First)
+	assert.Equal(t, "10.0.0.1", res.Last)
+	assert.Equal(t, "10.0.0.1", res.Broadcast)
+
+	res, err = KOS.ParseCIDR("8.8.8.8/32")
+	assert.Nil(t, err)
+	assert.Equal(t, "8.8.8.8", res.First)
+	assert.Equal(t, "8.8.8.8", res.Last)
+	assert.Equal(t, int64(1), res.Size.Int64())
+
+	res, err = KOS.ParseCIDR("2001:db8::/32")
+	assert.Nil(t, err)
+	assert.Equal(t, "2001:db8::", res.Network)
+	assert.Equal(t, "ffff:ffff::", res.Netmask)
+	assert.Equal(t, "2001:db8::", res.First)
+	assert.Equal(t, "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", res.Last)
+	assert.Equal(t, "", res.Broadcast)
+	assert.Equal(t, "79228162514264337593543950336", res.Size.String())
+
+	//IPv4映射的网段
+	res, err = KOS.ParseCIDR("::ffff:10.0.0.0/104")
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.0", res.Network)
+	assert.Equal(t, "255.0.0.0", res.Netmask)
+	assert.Equal(t, 8, res.Prefix)
+	assert.Equal(t, "10.0.0.1", res.First)
+	assert.Equal(t, "10.255.255.254", res.Last)
+	assert.Equal(t, "10.255.255.255", res.Broadcast)
+	assert.Equal(t, int64(1<<24), res.Size.Int64())
+
+	res, err = KOS.ParseCIDR("::/0")
+	assert.Nil(t, err)
+	assert.Equal(t, 129, res.Size.BitLen())
+
+	_, err = KOS.ParseCIDR("10.0.0.0/33")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KOS.ParseCIDR(lanIp)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_ParseCIDR(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.ParseCIDR("2001:db8::/32")
+	}
+}
+
+func TestNetaddr_IpRangeHosts(t *testing.T) {
+	var res []string
+	var err error
+
+	res, err = KOS.IpRangeHosts("10.0.0.254", "10.0.1.1", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.254", "10.0.0.255", "10.0.1.0", "10.0.1.1"}, res)
+
+	res, err = KOS.IpRangeHosts("2001:db8::fffe", "2001:db8::1:0", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2001:db8::fffe", "2001:db8::ffff", "2001:db8::1:0"}, res)
+
+	_, err = KOS.IpRangeHosts("::", "ffff::", 1000)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	_, err = KOS.IpRangeHosts("10.0.0.1", "10.0.0.5", 4)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	_, err = KOS.IpRangeHosts("10.0.0.5", "10.0.0.1", 10)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KOS.IpRangeHosts("10.0.0.1", "::1", 10)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KOS.IpRangeHosts("10.0.0.0/24", "10.0.1.1", 10)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KOS.IpRangeHosts("10.0.0.1", strHello, 10)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_IpRangeHosts(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.IpRangeHosts("10.0.0.1", "10.0.0.100", 100)
+	}
+}
+
+func TestNetaddr_CIDRHosts(t *testing.T) {
+	var res []string
+	var err error
+
+	res, err = KOS.CIDRHosts("192.168.1.0/30", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"192.168.1.1", "192.168.1.2"}, res)
+
+	res, err = KOS.CIDRHosts("2001:db8::/126", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"}, res)
+
+	res, err = KOS.CIDRHosts("::ffff:192.168.1.0/126", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"192.168.1.1", "192.168.1.2"}, res)
+
+	_, err = KOS.CIDRHosts("10.0.0.0/8", 1024)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	_, err = KOS.CIDRHosts(strHello, 10)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_CIDRHosts(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.CIDRHosts("192.168.1.0/25", 128)
+	}
+}
+
+func TestNetaddr_CIDRContains(t *testing.T) {
+	tests := []struct {
+		outer, inner string
+		want         bool
+	}{
+		{"10.0.0.0/8", "10.1.2.0/24", true},
+		{"10.0.0.0/8", "10.255.255.255", true},
+		{"10.0.0.0/8", "11.0.0.0", false},
+		{"10.1.2.0/24", "10.0.0.0/8", false},
+		{"10.0.0.0/8", "10.0.0.0/8", true},
+		{"fc00::/7", "fd12:3456::/32", true},
+		{"fc00::/7", "fe80::1", false},
+		{"::/0", "10.0.0.1", false},
+		{"0.0.0.0/0", "::ffff:1.2.3.4", true},
+		{"::ffff:0:0/96", "::ffff:10.0.0.1", true},
+		{"::ffff:10.0.0.0/104", "10.1.2.3", true},
+		{"::ffff:10.0.0.0/104", "11.0.0.0", false},
+		{"::/0", "::ffff:10.0.0.1", false},
+	}
+	for _, test := range tests {
+		res, err := KOS.CIDRContains(test.outer, test.inner)
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, res, test.outer+" "+test.inner)
+	}
+
+	_, err := KOS.CIDRContains(strHello, lanIp)
+	assert.NotNil(t, err)
+	_, err = KOS.CIDRContains("10.0.0.0/8", "10.0.0.0/99")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkNetaddr_CIDRContains(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.CIDRContains("fc00::/7", "fd12:3456::/32")
+	}
+}
+
+func TestNetaddr_CIDROverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"10.0.0.0/8", "10.1.2.0/24", true},
+		{"10.1.2.0/24", "10.0.0.0/8", true},
+		{"10.0.0.0/24", "10.0.1.0/24", false},
+		{"10.0.0.0/23", "10.0.1.0/24", true},
+		{"2001:db8::/32", "2001:db8:1::/48", true},
+		{"2001:db8::/32", "2001:db9::/32", false},
+		{"0.0.0.0/0", "::/0", false},
+		{lanIp, lanIp, true},
+	}
+	for _, test := range tests {
+		res, err := KOS.CIDROverlap(test.a, test.b)
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, res, test.a+" "+test.b)
+	}
+
+	_, err := KOS.CIDROverlap("10.0.0.0/8", strHello)
+	assert.NotNil(t, err)
+	_, err = KOS.CIDROverlap(strHello, "10.0.0.0/8")
+	assert.NotNil(t, err)
+}
+
+func BenchmarkNetaddr_CIDROverlap(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.CIDROverlap("2001:db8::/32", "2001:db8:1::/48")
+	}
+}
+
+func TestNetaddr_CIDRMerge(t *testing.T) {
+	var res []string
+	var err error
+
+	res, err = KOS.CIDRMerge([]string{
+		"192.168.1.0/25", "2001:db8::1", "192.168.1.128/25", "10.0.0.1", "10.0.0.0",
+		"192.168.1.5", "2001:db8::/127", " 10.0.0.2 ", "10.0.0.3", "10.0.0.5",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.0/30", "10.0.0.5/32", "192.168.1.0/24", "2001:db8::/127"}, res)
+
+	res, err = KOS.CIDRMerge([]string{"0.0.0.0/1", "128.0.0.0/1", "::/1", "8000::/1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0.0.0.0/0", "::/0"}, res)
+
+	res, err = KOS.CIDRMerge(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, res)
+
+	_, err = KOS.CIDRMerge([]string{"10.0.0.0/8", strHello})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_CIDRMerge(b *testing.B) {
+	addrs := []string{"192.168.1.0/25", "192.168.1.128/25", "10.0.0.1", "10.0.0.0", "2001:db8::1", "2001:db8::"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.CIDRMerge(addrs)
+	}
+}
+
+func TestNetaddr_IpRange2CIDR(t *testing.T) {
+	var res []string
+	var err error
+
+	res, err = KOS.IpRange2CIDR("10.0.0.1", "10.0.0.10")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/30", "10.0.0.8/31", "10.0.0.10/32"}, res)
+
+	res, err = KOS.IpRange2CIDR("0.0.0.0", "255.255.255.255")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0.0.0.0/0"}, res)
+
+	res, err = KOS.IpRange2CIDR("2001:db8::", "2001:db8::1:ffff")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2001:db8::/111"}, res)
+
+	res, err = KOS.IpRange2CIDR("::ffff", "::1:0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"::ffff/128", "::1:0/128"}, res)
+
+	_, err = KOS.IpRange2CIDR("10.0.0.2", "10.0.0.1")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KOS.IpRange2CIDR(strHello, "10.0.0.1")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkNetaddr_IpRange2CIDR(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KOS.IpRange2CIDR("10.0.0.1", "10.0.200.10")
+	}
+}
@@ -3,10 +3,10 @@ package kgo
 import (
 	"fmt"
 	"math"
-	"math/rand"
+	"math/big"
 	"reflect"
 	"strconv"
-	"time"
+	"strings"
 )
 
 // AbsFloat 浮点型取绝对值.
@@ -43,9 +43,24 @@ func (kn *LkkNumber) NumberFormat(number float64, decimal uint8, point, thousand
 		number = -number
 		neg = true
 	}
-	dec := int(decimal)
 	// Will round off
-	str := fmt.Sprintf("%."+strconv.Itoa(dec)+"F", number)
+	str := fmt.Sprintf("%."+strconv.Itoa(int(decimal))+"F", number)
+
+	return numberFormat(str, neg, decimal, point, thousand)
+}
+
+// DecimalFormat 以千位分隔符方式格式化一个十进制数,不经过浮点数,无精度限制.
+// decimal为要保留的小数位数(四舍五入),point为小数点显示的字符,thousand为千位分隔符显示的字符.
+func (kn *LkkNumber) DecimalFormat(number Decimal, decimal uint8, point, thousand string) string {
+	str := number.Abs().Round(int32(decimal), ROUND_HALF_UP).String()
+	neg := number.Round(int32(decimal), ROUND_HALF_UP).Sign() < 0
+
+	return numberFormat(str, neg, decimal, point, thousand)
+}
+
+// numberFormat 将已保留decimal位小数的非负数字符串str,按千位分隔符格式化.
+func numberFormat(str string, neg bool, decimal uint8, point, thousand string) string {
+	dec := int(decimal)
 	prefix, suffix := "", ""
 	if dec > 0 {
 		prefix = str[:len(str)-(dec+1)]
@@ -121,7 +136,7 @@ func (kn *LkkNumber) RandInt64(min, max int64) int64 {
 		min, max = mMin, mMax
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := kn.rnd()
 	return r.Int63n(max-min) + min
 }
 
@@ -141,7 +156,7 @@ func (kn *LkkNumber) RandInt(min, max int) int {
 		min, max = mMin, mMax
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := kn.rnd()
 	return r.Intn(max-min) + min
 }
 
@@ -164,7 +179,7 @@ func (kn *LkkNumber) RandFloat64(min, max float64) float64 {
 		min, max = mMin, mMax
 	}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	r := kn.rnd()
 	num := r.Float64()
 
 	res := min + num*(max-min)
@@ -310,15 +325,27 @@ func (kn *LkkNumber) Log(x, y float64) float64 {
 }
 
 // ByteFormat 格式化文件比特大小.
-// size为文件大小,decimal为要保留的小数位数,delimiter为数字和单位间的分隔符.
-func (kn *LkkNumber) ByteFormat(size float64, decimal uint8, delimiter string) string {
+// size为文件大小,decimal为要保留的小数位数,delimiter为数字和单位间的分隔符;
+// standard为单位标准,默认BYTE_JEDEC(1024进位,KB),可选BYTE_SI(1000进位,kB)、BYTE_IEC(1024进位,KiB).
+func (kn *LkkNumber) ByteFormat(size float64, decimal uint8, delimiter string, standard ...LkkByteStandard) string {
 	var arr = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", Unknown}
+	var base float64 = 1024
+	if len(standard) > 0 {
+		switch standard[0] {
+		case BYTE_SI:
+			arr = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB", Unknown}
+			base = 1000
+		case BYTE_IEC:
+			arr = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB", Unknown}
+		}
+	}
+
 	var pos int = 0
 	var j float64 = float64(size)
 	for {
-		if size >= 1024 {
-			size = size / 1024
-			j = j / 1024
+		if size >= base {
+			size = size / base
+			j = j / base
 			pos++
 		} else {
 			break
@@ -331,6 +358,41 @@ func (kn *LkkNumber) ByteFormat(size float64, decimal uint8, delimiter string) s
 	return fmt.Sprintf("%."+strconv.Itoa(int(decimal))+"f%s%s", j, delimiter, arr[pos])
 }
 
+// ParseByteSize 解析人类可读的字节大小,如"512MiB"、"1.5G"、"10 kB",不区分大小写.
+// SI单位(k、kB、M、MB...)以1000进位,IEC单位(Ki、KiB、Mi、MiB...)以1024进位;小数字节向下取整,超出uint64范围时返回错误.
+func (kn *LkkNumber) ParseByteSize(str string) (uint64, error) {
+	s := strings.TrimSpace(str)
+	pos := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if pos < 0 {
+		pos = len(s)
+	}
+
+	numStr, unit := s[:pos], strings.ToLower(strings.TrimSpace(s[pos:]))
+	if numStr == "" || strings.Count(numStr, ".") > 1 {
+		return 0, fmt.Errorf("[ParseByteSize]`invalid size: %q", str)
+	}
+
+	bu, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("[ParseByteSize]`unknown unit: %q", str)
+	}
+	multiplier := new(big.Int).Exp(big.NewInt(bu[0]), big.NewInt(bu[1]), nil)
+
+	num, err := NewDecimalFromString(numStr)
+	if err != nil {
+		return 0, fmt.Errorf("[ParseByteSize]`invalid size: %q", str)
+	}
+
+	res := num.Mul(newDecimalBig(multiplier, 0)).Round(0, ROUND_DOWN).val()
+	if !res.IsUint64() {
+		return 0, fmt.Errorf("[ParseByteSize]`size overflows uint64: %q", str)
+	}
+
+	return res.Uint64(), nil
+}
+
 // IsOdd 变量是否奇数.
 func (kn *LkkNumber) IsOdd(val int) bool {
 	return val%2 != 0
@@ -528,6 +590,16 @@ func (kn *LkkNumber) Percent(val, total interface{}) float64 {
 	return (v / t) * 100
 }
 
+// DecimalPercent 返回十进制数的百分比((val/total) *100),保留scale位小数并按mode舍入;total为0时返回0.
+func (kn *LkkNumber) DecimalPercent(val, total Decimal, scale int32, mode LkkRoundMode) Decimal {
+	res, err := val.Mul(NewDecimalFromInt(100)).Div(total, scale, mode)
+	if err != nil {
+		return Decimal{}
+	}
+
+	return res
+}
+
 // IsNan 是否为“非数值”.注意,这里复数也算“非数值”.
 func (kn *LkkNumber) IsNan(val interface{}) bool {
 	if isFloat(val) {
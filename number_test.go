@@ -29,6 +29,30 @@ func BenchmarkNumber_NumberFormat(b *testing.B) {
 	}
 }
 
+func TestNumber_DecimalFormat(t *testing.T) {
+	var res string
+
+	d, _ := NewDecimalFromString("12345678901234567890.125")
+	res = KNum.DecimalFormat(d, 2, ".", ",")
+	assert.Equal(t, "12,345,678,901,234,567,890.13", res)
+
+	d, _ = NewDecimalFromString("-1234.5")
+	res = KNum.DecimalFormat(d, 0, ".", " ")
+	assert.Equal(t, "-1 235", res)
+
+	d, _ = NewDecimalFromString("-0.001")
+	res = KNum.DecimalFormat(d, 2, ".", ",")
+	assert.Equal(t, "0.00", res)
+}
+
+func BenchmarkNumber_DecimalFormat(b *testing.B) {
+	d, _ := NewDecimalFromString("12345678901234567890.125")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.DecimalFormat(d, 2, ".", ",")
+	}
+}
+
 func TestNumber_Range(t *testing.T) {
 	var res []int
 	var start, end int
@@ -510,6 +534,79 @@ func BenchmarkNumber_ByteFormat(b *testing.B) {
 	}
 }
 
+func TestNumber_ByteFormat_Standard(t *testing.T) {
+	var res string
+
+	res = KNum.ByteFormat(1536, 1, "", BYTE_JEDEC)
+	assert.Equal(t, "1.5KB", res)
+
+	res = KNum.ByteFormat(1536, 2, " ", BYTE_SI)
+	assert.Equal(t, "1.54 kB", res)
+
+	res = KNum.ByteFormat(1536, 1, "", BYTE_IEC)
+	assert.Equal(t, "1.5KiB", res)
+
+	res = KNum.ByteFormat(512*1024*1024, 0, "", BYTE_IEC)
+	assert.Equal(t, "512MiB", res)
+
+	res = KNum.ByteFormat(999, 0, "", BYTE_SI)
+	assert.Equal(t, "999B", res)
+}
+
+func TestNumber_ParseByteSize(t *testing.T) {
+	var res uint64
+	var err error
+
+	tests := []struct {
+		str  string
+		want uint64
+	}{
+		{"0", 0},
+		{"100", 100},
+		{"100B", 100},
+		{" 2 bytes ", 2},
+		{"10 kB", 10000},
+		{"10KB", 10000},
+		{"10k", 10000},
+		{"512MiB", 512 << 20},
+		{"512mib", 512 << 20},
+		{"1.5G", 1500000000},
+		{"1.5Gi", 1610612736},
+		{"2TB", 2000000000000},
+		{"1.5B", 1},
+		{".5KiB", 512},
+		{"16EiB", 0},
+		{"15.99EiB", 18435214858663483146},
+		{"18446744073709551615", math.MaxUint64},
+	}
+	for _, test := range tests {
+		res, err = KNum.ParseByteSize(test.str)
+		if test.want == 0 && test.str != "0" {
+			assert.NotNil(t, err, test.str)
+			continue
+		}
+		assert.Nil(t, err, test.str)
+		assert.Equal(t, test.want, res, test.str)
+	}
+
+	for _, str := range []string{"", "abc", "-1KB", "1.2.3MB", "10 XB", "18446744073709551616", "1YB"} {
+		_, err = KNum.ParseByteSize(str)
+		assert.NotNil(t, err, str)
+	}
+
+	//与ByteFormat往返
+	res, err = KNum.ParseByteSize(KNum.ByteFormat(3*1024*1024, 0, " ", BYTE_IEC))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3*1024*1024), res)
+}
+
+func BenchmarkNumber_ParseByteSize(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.ParseByteSize("1.5GiB")
+	}
+}
+
 func TestNumber_IsOdd(t *testing.T) {
 	var tests = []struct {
 		num      int
@@ -1071,6 +1168,19 @@ func BenchmarkNumber_Percent(b *testing.B) {
 	}
 }
 
+func TestNumber_DecimalPercent(t *testing.T) {
+	var res Decimal
+
+	res = KNum.DecimalPercent(NewDecimalFromInt(1), NewDecimalFromInt(3), 2, ROUND_HALF_UP)
+	assert.Equal(t, "33.33", res.String())
+
+	res = KNum.DecimalPercent(NewDecimalFromInt(2), NewDecimalFromInt(3), 2, ROUND_DOWN)
+	assert.Equal(t, "66.66", res.String())
+
+	res = KNum.DecimalPercent(NewDecimalFromInt(2), Decimal{}, 2, ROUND_HALF_UP)
+	assert.True(t, res.IsZero())
+}
+
 func TestNumber_IsNan(t *testing.T) {
 	var actual bool
 
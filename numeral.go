@@ -0,0 +1,380 @@
+package kgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// chineseDigits 中文小写数字
+	chineseDigits = []string{"零", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+	// chineseUpperDigits 中文大写(财务)数字
+	chineseUpperDigits = []string{"零", "壹", "贰", "叁", "肆", "伍", "陆", "柒", "捌", "玖"}
+	// chineseUnits 中文小写位单位(个十百千)
+	chineseUnits = []string{"", "十", "百", "千"}
+	// chineseUpperUnits 中文大写位单位(个拾佰仟)
+	chineseUpperUnits = []string{"", "拾", "佰", "仟"}
+
+	// chineseDigitValues 中文数字字符对应的值
+	chineseDigitValues = map[rune]int64{
+		'零': 0, '〇': 0, '○': 0, '洞': 0,
+		'一': 1, '壹': 1, '幺': 1,
+		'二': 2, '贰': 2, '两': 2,
+		'三': 3, '叁': 3,
+		'四': 4, '肆': 4,
+		'五': 5, '伍': 5,
+		'六': 6, '陆': 6,
+		'七': 7, '柒': 7,
+		'八': 8, '捌': 8,
+		'九': 9, '玖': 9,
+	}
+	// chineseUnitValues 中文位单位字符对应的值
+	chineseUnitValues = map[rune]int64{
+		'十': 10, '拾': 10,
+		'百': 100, '佰': 100,
+		'千': 1000, '仟': 1000,
+	}
+
+	// englishOnes 英文0~19
+	englishOnes = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	// englishTens 英文整十
+	englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	// englishScales 英文千进位单位
+	englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+	// englishOrdinals 英文序数词的不规则变化
+	englishOrdinals = map[string]string{
+		"one": "first", "two": "second", "three": "third", "five": "fifth",
+		"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+	}
+)
+
+// numeral2Decimal 将数值、数值字符串或Decimal转换为Decimal.
+func numeral2Decimal(val interface{}) (Decimal, error) {
+	switch v := val.(type) {
+	case Decimal:
+		return v, nil
+	case *Decimal:
+		if v != nil {
+			return *v, nil
+		}
+	default:
+		if isNumeric(val) {
+			return NewDecimalFromString(toStr(val))
+		}
+	}
+
+	return Decimal{}, fmt.Errorf("[numeral2Decimal]`invalid number: %v", val)
+}
+
+// chineseInteger 将非负整数字符串转换为中文读法;upper为是否大写.
+func chineseInteger(digits string, upper bool) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return chineseDigits[0]
+	}
+
+	res := chineseSection(digits, upper)
+	if !upper && strings.HasPrefix(res, "一十") {
+		res = strings.TrimPrefix(res, "一")
+	}
+
+	return res
+}
+
+// chineseSection 将无前导零的整数字符串转换为中文;超过8位时按"亿"递归拆分,如"一万零一亿".
+func chineseSection(digits string, upper bool) string {
+	nums, units := chineseDigits, chineseUnits
+	if upper {
+		nums, units = chineseUpperDigits, chineseUpperUnits
+	}
+
+	length := len(digits)
+	if length > 8 {
+		res := chineseSection(digits[:length-8], upper) + "亿"
+		low := strings.TrimLeft(digits[length-8:], "0")
+		if low != "" {
+			if len(low) < 8 {
+				res += nums[0]
+			}
+			res += chineseSection(low, upper)
+		}
+		return res
+	}
+
+	var sb strings.Builder
+	zero := false
+	for i := 0; i < length; i++ {
+		pos := length - 1 - i
+		d := digits[i] - '0'
+		if d == 0 {
+			zero = true
+		} else {
+			if zero {
+				sb.WriteString(nums[0])
+			}
+			zero = false
+			sb.WriteString(nums[d])
+			sb.WriteString(units[pos%4])
+		}
+
+		//万位,整节为0时不输出
+		if pos == 4 && strings.Trim(digits[:i+1], "0") != "" {
+			sb.WriteString("万")
+		}
+	}
+
+	return sb.String()
+}
+
+// Number2Chinese 将数值转换为中文读法;upper为是否财务大写.
+// val可以为整数、浮点数、数值字符串或Decimal,如12345.67转换为"一万二千三百四十五点六七".
+func (kc *LkkConvert) Number2Chinese(val interface{}, upper bool) (string, error) {
+	dec, err := numeral2Decimal(val)
+	if err != nil {
+		return "", err
+	}
+
+	str := dec.Abs().String()
+	intPart, fracPart := str, ""
+	if pos := strings.IndexByte(str, '.'); pos >= 0 {
+		intPart, fracPart = str[:pos], strings.TrimRight(str[pos+1:], "0")
+	}
+
+	res := chineseInteger(intPart, upper)
+	nums := chineseDigits
+	if upper {
+		nums = chineseUpperDigits
+	}
+	if fracPart != "" {
+		res += "点"
+		for _, c := range fracPart {
+			res += nums[c-'0']
+		}
+	}
+	if dec.Sign() < 0 {
+		res = "负" + res
+	}
+
+	return res, nil
+}
+
+// Money2Chinese 将金额转换为人民币大写,先四舍五入到分.
+// 如12345.67转换为"壹万贰仟叁佰肆拾伍元陆角柒分",100转换为"壹佰元整".
+func (kc *LkkConvert) Money2Chinese(val interface{}) (string, error) {
+	dec, err := numeral2Decimal(val)
+	if err != nil {
+		return "", err
+	}
+
+	dec = dec.Round(2, ROUND_HALF_UP)
+	str := dec.Abs().String()
+	pos := strings.IndexByte(str, '.')
+	intPart, jiao, fen := str[:pos], str[pos+1]-'0', str[pos+2]-'0'
+
+	var sb strings.Builder
+	if dec.Sign() < 0 {
+		sb.WriteString("负")
+	}
+
+	hasInt := strings.Trim(intPart, "0") != ""
+	if hasInt || (jiao == 0 && fen == 0) {
+		sb.WriteString(chineseInteger(intPart, true))
+		sb.WriteString("元")
+	}
+
+	if jiao == 0 && fen == 0 {
+		sb.WriteString("整")
+		return sb.String(), nil
+	}
+
+	if jiao > 0 {
+		sb.WriteString(chineseUpperDigits[jiao])
+		sb.WriteString("角")
+	} else if hasInt {
+		sb.WriteString(chineseUpperDigits[0])
+	}
+	if fen > 0 {
+		sb.WriteString(chineseUpperDigits[fen])
+		sb.WriteString("分")
+	}
+
+	return sb.String(), nil
+}
+
+// Chinese2Number 将中文数字转换为数值,支持大小写、"两"、"点"小数,
+// 以及金额格式如"壹万元陆角柒分"、"人民币叁佰元整",和逐位读法如"二〇二四".
+func (kc *LkkConvert) Chinese2Number(str string) (float64, error) {
+	errInvalid := fmt.Errorf("[Chinese2Number]`invalid chinese number: %q", str)
+	s := strings.TrimSpace(str)
+	s = strings.TrimPrefix(s, "人民币")
+	s = strings.TrimSuffix(s, "整")
+	s = strings.TrimSuffix(s, "正")
+
+	sign := 1.0
+	if strings.HasPrefix(s, "负") {
+		sign = -1
+		s = strings.TrimPrefix(s, "负")
+	}
+	if s == "" {
+		return 0, errInvalid
+	}
+
+	//金额格式,将角分转换为小数部分
+	var frac string
+	if pos := strings.IndexAny(s, "元圆"); pos >= 0 {
+		rest := s[pos+len("元"):]
+		s = s[:pos]
+		jiao, fen, num := 0, 0, -1
+		for _, c := range rest {
+			if v, ok := chineseDigitValues[c]; ok {
+				num = int(v)
+			} else if c == '角' && num >= 0 {
+				jiao, num = num, -1
+			} else if c == '分' && num >= 0 {
+				fen, num = num, -1
+			} else {
+				return 0, errInvalid
+			}
+		}
+		if num > 0 {
+			return 0, errInvalid
+		}
+		frac = fmt.Sprintf("%d%d", jiao, fen)
+	} else if strings.ContainsAny(s, "角分") {
+		res, err := kc.Chinese2Number("零元" + s)
+		return sign * res, err
+	} else if pos := strings.Index(s, "点"); pos >= 0 {
+		for _, c := range s[pos+len("点"):] {
+			v, ok := chineseDigitValues[c]
+			if !ok {
+				return 0, errInvalid
+			}
+			frac += strconv.FormatInt(v, 10)
+		}
+		s = s[:pos]
+	}
+
+	res, ok := chineseParseInteger(s)
+	if !ok {
+		return 0, errInvalid
+	}
+	if frac != "" {
+		res, _ = strconv.ParseFloat(strconv.FormatFloat(res, 'f', 0, 64)+"."+frac, 64)
+	}
+
+	return sign * res, nil
+}
+
+// chineseParseInteger 解析中文整数.
+func chineseParseInteger(str string) (float64, bool) {
+	if str == "" {
+		return 0, true
+	}
+
+	//逐位读法,不含任何单位
+	if !strings.ContainsAny(str, "十拾百佰千仟万亿") {
+		var res float64
+		for _, c := range str {
+			v, ok := chineseDigitValues[c]
+			if !ok {
+				return 0, false
+			}
+			res = res*10 + float64(v)
+		}
+		return res, true
+	}
+
+	var total, section, num float64
+	for _, c := range str {
+		if v, ok := chineseDigitValues[c]; ok {
+			num = float64(v)
+		} else if v, ok := chineseUnitValues[c]; ok {
+			if num == 0 && v == 10 {
+				//如"十二"、"一百十"
+				num = 1
+			}
+			section += num * float64(v)
+			num = 0
+		} else if c == '万' {
+			section = (section + num) * 1e4
+			num = 0
+		} else if c == '亿' {
+			total = (total + section + num) * 1e8
+			section, num = 0, 0
+		} else {
+			return 0, false
+		}
+	}
+
+	return total + section + num, true
+}
+
+// englishHundreds 将0~999转换为英文.
+func englishHundreds(n int64) string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, englishOnes[n/100], "hundred")
+		n %= 100
+	}
+	if n >= 20 {
+		word := englishTens[n/10]
+		if n%10 > 0 {
+			word += "-" + englishOnes[n%10]
+		}
+		parts = append(parts, word)
+	} else if n > 0 {
+		parts = append(parts, englishOnes[n])
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Number2Words 将整数转换为英文单词;ordinal为是否序数词.
+// 如1234转换为"one thousand two hundred thirty-four",序数词21为"twenty-first".
+func (kc *LkkConvert) Number2Words(num int64, ordinal bool) string {
+	var res string
+	if num == 0 {
+		res = englishOnes[0]
+	} else {
+		//取绝对值,兼容math.MinInt64
+		abs := uint64(num)
+		if num < 0 {
+			abs = uint64(-(num + 1)) + 1
+		}
+
+		var parts []string
+		for i := 0; abs > 0; i++ {
+			if grp := int64(abs % 1000); grp > 0 {
+				word := englishHundreds(grp)
+				if englishScales[i] != "" {
+					word += " " + englishScales[i]
+				}
+				parts = append([]string{word}, parts...)
+			}
+			abs /= 1000
+		}
+		res = strings.Join(parts, " ")
+	}
+
+	if ordinal {
+		//仅变换最后一个单词
+		pos := strings.LastIndexAny(res, " -") + 1
+		last := res[pos:]
+		if word, ok := englishOrdinals[last]; ok {
+			last = word
+		} else if strings.HasSuffix(last, "y") {
+			last = strings.TrimSuffix(last, "y") + "ieth"
+		} else {
+			last += "th"
+		}
+		res = res[:pos] + last
+	}
+
+	if num < 0 {
+		res = "minus " + res
+	}
+
+	return res
+}
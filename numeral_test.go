@@ -0,0 +1,192 @@
+package kgo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumeral_Number2Chinese(t *testing.T) {
+	var res string
+	var err error
+
+	tests := []struct {
+		val   interface{}
+		upper bool
+		want  string
+	}{
+		{0, false, "零"},
+		{10, false, "十"},
+		{15, false, "十五"},
+		{10, true, "壹拾"},
+		{101, false, "一百零一"},
+		{1010, false, "一千零一十"},
+		{100000, false, "十万"},
+		{10001, false, "一万零一"},
+		{10010000, false, "一千零一万"},
+		{100000001, false, "一亿零一"},
+		{int64(1000000000000), false, "一万亿"},
+		{int64(1000100000000), false, "一万零一亿"},
+		{int64(1000000010000), false, "一万亿零一万"},
+		{int64(10000000000000000), false, "一亿亿"},
+		{-12345, false, "负一万二千三百四十五"},
+		{12345.67, false, "一万二千三百四十五点六七"},
+		{12345.67, true, "壹万贰仟叁佰肆拾伍点陆柒"},
+		{"0.05", false, "零点零五"},
+		{"3.1400", false, "三点一四"},
+		{NewDecimal(-205, 1), true, "负贰拾点伍"},
+	}
+	for _, test := range tests {
+		res, err = KConv.Number2Chinese(test.val, test.upper)
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, res, test.val)
+	}
+
+	_, err = KConv.Number2Chinese("abc", false)
+	assert.NotNil(t, err)
+	_, err = KConv.Number2Chinese(math.NaN(), false)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkNumeral_Number2Chinese(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Number2Chinese(12345.67, true)
+	}
+}
+
+func TestNumeral_Money2Chinese(t *testing.T) {
+	var res string
+	var err error
+
+	tests := []struct {
+		val  interface{}
+		want string
+	}{
+		{0, "零元整"},
+		{100, "壹佰元整"},
+		{12345.67, "壹万贰仟叁佰肆拾伍元陆角柒分"},
+		{100.5, "壹佰元伍角"},
+		{100.05, "壹佰元零伍分"},
+		{0.67, "陆角柒分"},
+		{0.05, "伍分"},
+		{1.005, "壹元零壹分"},
+		{0.004, "零元整"},
+		{-1000.1, "负壹仟元壹角"},
+		{"100020003.40", "壹亿零贰万零叁元肆角"},
+	}
+	for _, test := range tests {
+		res, err = KConv.Money2Chinese(test.val)
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, res, test.val)
+	}
+
+	_, err = KConv.Money2Chinese(nil)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkNumeral_Money2Chinese(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Money2Chinese(12345.67)
+	}
+}
+
+func TestNumeral_Chinese2Number(t *testing.T) {
+	var res float64
+	var err error
+
+	tests := []struct {
+		str  string
+		want float64
+	}{
+		{"零", 0},
+		{"十", 10},
+		{"十五", 15},
+		{"一百十", 110},
+		{"两百", 200},
+		{"一千零一十", 1010},
+		{"一万零一", 10001},
+		{"十万", 100000},
+		{"一亿零五万", 100050000},
+		{"一万亿", 1e12},
+		{"一万零一亿", 1000100000000},
+		{"一亿亿", 1e16},
+		{"负一万二千三百四十五", -12345},
+		{"一万二千三百四十五点六七", 12345.67},
+		{"壹万贰仟叁佰肆拾伍元陆角柒分", 12345.67},
+		{"人民币壹佰元整", 100},
+		{"壹佰元零伍分", 100.05},
+		{"陆角柒分", 0.67},
+		{"负壹仟元壹角", -1000.1},
+		{"二〇二四", 2024},
+	}
+	for _, test := range tests {
+		res, err = KConv.Chinese2Number(test.str)
+		assert.Nil(t, err, test.str)
+		assert.Equal(t, test.want, res, test.str)
+	}
+
+	//往返转换
+	for _, v := range []float64{1, 19, 305, 40050, 1234567.89, 900000000.5} {
+		str, _ := KConv.Number2Chinese(v, true)
+		res, err = KConv.Chinese2Number(str)
+		assert.Nil(t, err)
+		assert.Equal(t, v, res)
+
+		str, _ = KConv.Money2Chinese(v)
+		res, err = KConv.Chinese2Number(str)
+		assert.Nil(t, err)
+		assert.Equal(t, v, res)
+	}
+
+	for _, str := range []string{"", "负", "abc", "一百块", "壹佰元伍", "一点x"} {
+		_, err = KConv.Chinese2Number(str)
+		assert.NotNil(t, err, str)
+	}
+}
+
+func BenchmarkNumeral_Chinese2Number(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Chinese2Number("壹万贰仟叁佰肆拾伍元陆角柒分")
+	}
+}
+
+func TestNumeral_Number2Words(t *testing.T) {
+	tests := []struct {
+		num     int64
+		ordinal bool
+		want    string
+	}{
+		{0, false, "zero"},
+		{0, true, "zeroth"},
+		{13, false, "thirteen"},
+		{21, false, "twenty-one"},
+		{100, false, "one hundred"},
+		{1234, false, "one thousand two hundred thirty-four"},
+		{1000001, false, "one million one"},
+		{-45, false, "minus forty-five"},
+		{1, true, "first"},
+		{2, true, "second"},
+		{3, true, "third"},
+		{12, true, "twelfth"},
+		{20, true, "twentieth"},
+		{21, true, "twenty-first"},
+		{112, true, "one hundred twelfth"},
+		{1000, true, "one thousandth"},
+		{math.MaxInt64, false, "nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred seven"},
+		{math.MinInt64, false, "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, KConv.Number2Words(test.num, test.ordinal))
+	}
+}
+
+func BenchmarkNumeral_Number2Words(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KConv.Number2Words(1234567, true)
+	}
+}
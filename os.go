@@ -174,10 +174,11 @@ func (ko *LkkOS) PrivateCIDR() []*net.IPNet {
 	return res
 }
 
-// IsPrivateIp 是否私有IP地址(ipv4/ipv6).
+// IsPrivateIp 是否私有IP地址(ipv4/ipv6),包括IPv6的唯一本地地址和链路本地地址;
+// 支持带区域的IPv6地址(如"fe80::1%eth0")和IPv4映射的IPv6地址(如"::ffff:10.0.0.1").
 func (ko *LkkOS) IsPrivateIp(str string) (bool, error) {
-	ip := net.ParseIP(str)
-	if ip == nil {
+	ip, _, err := ipParse(str)
+	if err != nil {
 		return false, errors.New("[IsPrivateIp]`str is not valid ip")
 	}
 
@@ -96,9 +96,21 @@ func TestOS_IsPrivateIp(t *testing.T) {
 	assert.Nil(t, err)
 	assert.False(t, res)
 
+	//IPv6唯一本地地址、链路本地地址及IPv4映射地址
+	for _, ip := range []string{"fd12:3456:789a::1", "fe80::1", "fe80::1%eth0", "::1", "::ffff:10.0.0.1"} {
+		res, err = KOS.IsPrivateIp(ip)
+		assert.Nil(t, err)
+		assert.True(t, res, ip)
+	}
+	res, err = KOS.IsPrivateIp(googleIpv6)
+	assert.Nil(t, err)
+	assert.False(t, res)
+
 	//非IP
 	res, err = KOS.IsPrivateIp(strHello)
 	assert.NotNil(t, err)
+	res, err = KOS.IsPrivateIp("10.0.0.1%eth0")
+	assert.NotNil(t, err)
 }
 
 func BenchmarkOS_IsPrivateIp(b *testing.B) {
@@ -0,0 +1,466 @@
+package kgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// packItem 格式字符串中的一项
+type packItem struct {
+	code  byte //格式字符
+	count int  //重复次数,对于s为字节长度
+}
+
+// packSizes 各格式字符对应的字节数
+var packSizes = map[byte]int{
+	'x': 1, '?': 1, 'b': 1, 'B': 1, 'h': 2, 'H': 2, 'i': 4, 'I': 4,
+	'l': 4, 'L': 4, 'q': 8, 'Q': 8, 'f': 4, 'd': 8, 's': 1,
+}
+
+// PackMaxSize Pack 格式字符串对应的最大字节数,防止过大的重复次数导致溢出或分配过多内存
+const PackMaxSize = 1 << 26
+
+// packParse 解析格式字符串,返回字节序、各项及总字节数;总字节数超过 PackMaxSize 时返回 ErrInvalidParam .
+func packParse(format string) (binary.ByteOrder, []packItem, int, error) {
+	var order binary.ByteOrder = getEndian()
+	if format != "" {
+		switch format[0] {
+		case '<':
+			order = binary.LittleEndian
+		case '>', '!':
+			order = binary.BigEndian
+		}
+		if strings.IndexByte("@=<>!", format[0]) >= 0 {
+			format = format[1:]
+		}
+	}
+
+	var items []packItem
+	var size int
+	for i := 0; i < len(format); i++ {
+		if format[i] == ' ' || format[i] == '\t' || format[i] == '\n' {
+			continue
+		}
+
+		count, start := 1, i
+		for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+			i++
+		}
+		if i > start {
+			n, err := strconv.Atoi(format[start:i])
+			if err != nil || i == len(format) {
+				return nil, nil, 0, fmt.Errorf("[Pack]`invalid format %q: %w", format, ErrInvalidParam)
+			}
+			count = n
+		}
+
+		unit, ok := packSizes[format[i]]
+		if !ok {
+			return nil, nil, 0, fmt.Errorf("[Pack]`invalid format character %q: %w", format[i], ErrInvalidParam)
+		} else if count > (PackMaxSize-size)/unit {
+			//先比较再相乘,避免溢出
+			return nil, nil, 0, fmt.Errorf("[Pack]`format %q exceeds %d bytes: %w", format, PackMaxSize, ErrInvalidParam)
+		}
+		size += unit * count
+		items = append(items, packItem{code: format[i], count: count})
+	}
+
+	return order, items, size, nil
+}
+
+// PackSize 计算格式字符串format对应的字节数,格式见 Pack ;超过 PackMaxSize 时返回 ErrInvalidParam .
+func (kc *LkkConvert) PackSize(format string) (int, error) {
+	_, _, size, err := packParse(format)
+	return size, err
+}
+
+// Pack 按格式字符串format将values打包为二进制数据,类似Python的struct.pack和PHP的pack.
+// format的首字符可指定字节序:"<"小端,">"或"!"大端(网络字节序),"@"或"="及省略时为本机字节序;不进行对齐.
+// 格式字符:x填充字节(不消耗值),?布尔,b/B有符号/无符号8位,h/H 16位,i/I和l/L 32位,q/Q 64位整数,f 32位浮点,d 64位浮点,s定长字符串;
+// 格式字符前的数字为重复次数,如"4H";对于s为字节长度,如"10s",字符串过长时截断,不足时以\x00填充.
+// 整数的值须为整数类型,超出范围时返回 ErrNumberOverflow ;值的数量与格式不一致时返回 ErrLengthMismatch ;
+// 格式对应的字节数超过 PackMaxSize 时返回 ErrInvalidParam .
+func (kc *LkkConvert) Pack(format string, values ...interface{}) ([]byte, error) {
+	order, items, _, err := packParse(format)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []byte
+	var idx int
+	for _, item := range items {
+		switch item.code {
+		case 'x':
+			res = append(res, make([]byte, item.count)...)
+			continue
+		case 's':
+			if idx >= len(values) {
+				return nil, fmt.Errorf("[Pack]`%d values for format %q: %w", len(values), format, ErrLengthMismatch)
+			}
+			str, ok := castString(castIndirect(values[idx]))
+			if !ok {
+				return nil, fmt.Errorf("[Pack]`value %d %T for 's': %w", idx, values[idx], ErrUnsupportedType)
+			}
+			buf := make([]byte, item.count)
+			copy(buf, str)
+			res = append(res, buf...)
+			idx++
+			continue
+		}
+
+		for n := 0; n < item.count; n++ {
+			if idx >= len(values) {
+				return nil, fmt.Errorf("[Pack]`%d values for format %q: %w", len(values), format, ErrLengthMismatch)
+			}
+			if res, err = packValue(res, order, item.code, castIndirect(values[idx])); err != nil {
+				return nil, fmt.Errorf("[Pack]`value %d %T for '%c': %w", idx, values[idx], item.code, err)
+			}
+			idx++
+		}
+	}
+
+	if idx != len(values) {
+		return nil, fmt.Errorf("[Pack]`%d values for format %q: %w", len(values), format, ErrLengthMismatch)
+	}
+	return res, nil
+}
+
+// packValue 按格式字符code将v编码后追加到buf.
+func packValue(buf []byte, order binary.ByteOrder, code byte, v reflect.Value) ([]byte, error) {
+	switch code {
+	case '?':
+		if v.Kind() != reflect.Bool {
+			return nil, ErrUnsupportedType
+		}
+		return append(buf, byte(bool2Int(v.Bool()))), nil
+	case 'f', 'd':
+		var f float64
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f = v.Float()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(v.Uint())
+		default:
+			return nil, ErrUnsupportedType
+		}
+		if code == 'f' {
+			if math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+				return nil, ErrNumberOverflow
+			}
+			return packUint(buf, order, 4, uint64(math.Float32bits(float32(f)))), nil
+		}
+		return packUint(buf, order, 8, math.Float64bits(f)), nil
+	}
+
+	size := packSizes[code]
+	bits := uint(size * 8)
+	signed := code >= 'a'
+	var u uint64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if signed && bits < 64 && (i < -1<<(bits-1) || i > 1<<(bits-1)-1) {
+			return nil, ErrNumberOverflow
+		} else if !signed && (i < 0 || (bits < 64 && i > 1<<bits-1)) {
+			return nil, ErrNumberOverflow
+		}
+		u = uint64(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u = v.Uint()
+		if signed && u > 1<<(bits-1)-1 {
+			return nil, ErrNumberOverflow
+		} else if !signed && bits < 64 && u > 1<<bits-1 {
+			return nil, ErrNumberOverflow
+		}
+	default:
+		return nil, ErrUnsupportedType
+	}
+	return packUint(buf, order, size, u), nil
+}
+
+// packUint 将u的低size字节按字节序追加到buf.
+func packUint(buf []byte, order binary.ByteOrder, size int, u uint64) []byte {
+	tmp := make([]byte, 8)
+	switch size {
+	case 1:
+		tmp[0] = byte(u)
+	case 2:
+		order.PutUint16(tmp, uint16(u))
+	case 4:
+		order.PutUint32(tmp, uint32(u))
+	default:
+		order.PutUint64(tmp, u)
+	}
+	return append(buf, tmp[:size]...)
+}
+
+// unpackUint 按字节序读取data前size字节的无符号整数.
+func unpackUint(data []byte, order binary.ByteOrder, size int) uint64 {
+	switch size {
+	case 1:
+		return uint64(data[0])
+	case 2:
+		return uint64(order.Uint16(data))
+	case 4:
+		return uint64(order.Uint32(data))
+	default:
+		return order.Uint64(data)
+	}
+}
+
+// Unpack 按格式字符串format将二进制数据data解包,为 Pack 的逆操作.
+// 结果中有符号整数为int64,无符号整数为uint64,浮点数为float64,?为bool,s为[]byte,x不产生值;
+// data的长度须与格式一致,否则返回 ErrLengthMismatch .
+func (kc *LkkConvert) Unpack(format string, data []byte) ([]interface{}, error) {
+	order, items, size, err := packParse(format)
+	if err != nil {
+		return nil, err
+	} else if size != len(data) {
+		return nil, fmt.Errorf("[Unpack]`format %q requires %d bytes, got %d: %w", format, size, len(data), ErrLengthMismatch)
+	}
+
+	var res []interface{}
+	for _, item := range items {
+		switch item.code {
+		case 'x':
+			data = data[item.count:]
+			continue
+		case 's':
+			res = append(res, append([]byte{}, data[:item.count]...))
+			data = data[item.count:]
+			continue
+		}
+
+		size := packSizes[item.code]
+		for n := 0; n < item.count; n++ {
+			u := unpackUint(data, order, size)
+			data = data[size:]
+			switch item.code {
+			case '?':
+				res = append(res, u != 0)
+			case 'f':
+				res = append(res, float64(math.Float32frombits(uint32(u))))
+			case 'd':
+				res = append(res, math.Float64frombits(u))
+			case 'b', 'h', 'i', 'l', 'q':
+				//符号扩展
+				shift := uint(64 - size*8)
+				res = append(res, int64(u<<shift)>>shift)
+			default:
+				res = append(res, u)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// binFieldSize 解析字段的bin标签,返回定长size(未指定时为-1)及是否忽略该字段.
+func binFieldSize(field reflect.StructField) (int, bool, error) {
+	tag := field.Tag.Get("bin")
+	if tag == "-" {
+		return 0, true, nil
+	}
+
+	size := -1
+	for _, opt := range strings.Split(tag, ",") {
+		if strings.HasPrefix(opt, "size=") {
+			n, err := strconv.Atoi(opt[5:])
+			if err != nil || n < 0 {
+				return 0, false, fmt.Errorf("[Bin]`field %s invalid tag %q: %w", field.Name, tag, ErrInvalidParam)
+			}
+			size = n
+		}
+	}
+	return size, false, nil
+}
+
+// PackStruct 将结构体val按字段顺序编码为二进制数据,order为字节序,为nil时使用大端(网络字节序).
+// 支持的字段类型:bool、有/无符号8~64位整数、float32/float64、数组、嵌套结构体,
+// 以及须用标签`bin:"size=N"`指定字节长度(切片为元素个数)的string、[]byte和其他切片,过长时截断,不足时以零值填充;
+// 标签`bin:"-"`和未导出的字段将被忽略,名为"_"的字段按其大小填充\x00;int、uint、字典等返回 ErrUnsupportedType .
+func (kc *LkkConvert) PackStruct(val interface{}, order binary.ByteOrder) ([]byte, error) {
+	v := castIndirect(val)
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("[PackStruct]`%T: %w", val, ErrUnsupportedType)
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+
+	return binPackValue(nil, order, v, -1, v.Type().Name())
+}
+
+// binPackValue 将v编码后追加到buf;size为标签指定的长度,path为字段路径,用于错误信息.
+func binPackValue(buf []byte, order binary.ByteOrder, v reflect.Value, size int, path string) ([]byte, error) {
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		return append(buf, byte(bool2Int(v.Bool()))), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return packUint(buf, order, int(v.Type().Size()), uint64(v.Int())), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return packUint(buf, order, int(v.Type().Size()), v.Uint()), nil
+	case reflect.Float32:
+		return packUint(buf, order, 4, uint64(math.Float32bits(float32(v.Float())))), nil
+	case reflect.Float64:
+		return packUint(buf, order, 8, math.Float64bits(v.Float())), nil
+	case reflect.String:
+		if size < 0 {
+			return nil, fmt.Errorf("[Bin]`field %s requires size tag: %w", path, ErrInvalidParam)
+		}
+		tmp := make([]byte, size)
+		copy(tmp, v.String())
+		return append(buf, tmp...), nil
+	case reflect.Slice:
+		if size < 0 {
+			return nil, fmt.Errorf("[Bin]`field %s requires size tag: %w", path, ErrInvalidParam)
+		}
+		zero := reflect.Zero(v.Type().Elem())
+		for i := 0; i < size; i++ {
+			item := zero
+			if i < v.Len() {
+				item = v.Index(i)
+			}
+			if buf, err = binPackValue(buf, order, item, -1, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if buf, err = binPackValue(buf, order, v.Index(i), -1, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fsize, skip, err := binFieldSize(field)
+			if err != nil {
+				return nil, err
+			} else if skip || (field.PkgPath != "" && field.Name != "_") {
+				continue
+			}
+
+			item := v.Field(i)
+			if field.Name == "_" {
+				//填充字段只计算大小
+				item = reflect.Zero(field.Type)
+			}
+			if buf, err = binPackValue(buf, order, item, fsize, path+"."+field.Name); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+
+	return nil, fmt.Errorf("[Bin]`field %s %s: %w", path, v.Type(), ErrUnsupportedType)
+}
+
+// UnpackStruct 将二进制数据data按字段顺序解码到结构体指针res,为 PackStruct 的逆操作,返回读取的字节数.
+// data的剩余数据将被忽略,便于解析后跟变长内容的报文头;长度不足时返回 ErrLengthMismatch .
+// string字段将去除末尾的\x00.
+func (kc *LkkConvert) UnpackStruct(data []byte, res interface{}, order binary.ByteOrder) (int, error) {
+	v := reflect.ValueOf(res)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return 0, fmt.Errorf("[UnpackStruct]`res must be a non-nil pointer to struct: %w", ErrUnsupportedType)
+	}
+	if order == nil {
+		order = binary.BigEndian
+	}
+
+	v = v.Elem()
+	rest, err := binUnpackValue(data, order, v, -1, v.Type().Name())
+	if err != nil {
+		return 0, err
+	}
+	return len(data) - len(rest), nil
+}
+
+// binUnpackValue 从data解码到v,返回剩余的数据;size为标签指定的长度,path为字段路径,用于错误信息.
+func binUnpackValue(data []byte, order binary.ByteOrder, v reflect.Value, size int, path string) ([]byte, error) {
+	var err error
+	need := 0
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		need = int(v.Type().Size())
+	case reflect.String:
+		need = size
+	}
+	if need > len(data) {
+		return nil, fmt.Errorf("[Bin]`field %s: %w", path, ErrLengthMismatch)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(data[0] != 0)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		shift := uint(64 - need*8)
+		v.SetInt(int64(unpackUint(data, order, need)<<shift) >> shift)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(unpackUint(data, order, need))
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(uint32(unpackUint(data, order, 4)))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(unpackUint(data, order, 8)))
+	case reflect.String:
+		if size < 0 {
+			return nil, fmt.Errorf("[Bin]`field %s requires size tag: %w", path, ErrInvalidParam)
+		}
+		v.SetString(strings.TrimRight(string(data[:size]), "\x00"))
+	case reflect.Slice:
+		if size < 0 {
+			return nil, fmt.Errorf("[Bin]`field %s requires size tag: %w", path, ErrInvalidParam)
+		}
+		slice := reflect.MakeSlice(v.Type(), size, size)
+		for i := 0; i < size; i++ {
+			if data, err = binUnpackValue(data, order, slice.Index(i), -1, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		v.Set(slice)
+		return data, nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if data, err = binUnpackValue(data, order, v.Index(i), -1, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fsize, skip, err := binFieldSize(field)
+			if err != nil {
+				return nil, err
+			} else if skip || (field.PkgPath != "" && field.Name != "_") {
+				continue
+			}
+
+			item := v.Field(i)
+			if field.Name == "_" {
+				//填充字段解码到临时变量
+				item = reflect.New(field.Type).Elem()
+			}
+			if data, err = binUnpackValue(data, order, item, fsize, path+"."+field.Name); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("[Bin]`field %s %s: %w", path, v.Type(), ErrUnsupportedType)
+	}
+
+	return data[need:], nil
+}
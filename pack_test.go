@@ -0,0 +1,299 @@
+package kgo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPackHeader struct {
+	Magic   [2]byte
+	Version uint8
+	Flags   int8
+	_       [2]byte
+	Length  uint32
+	Seq     int64
+	Temp    float32
+	Ratio   float64
+	Online  bool
+	Name    string  `bin:"size=8"`
+	Data    []byte  `bin:"size=3"`
+	Points  []int16 `bin:"size=2"`
+	Ignored string  `bin:"-"`
+	Pos     testPackPos
+	secret  int
+}
+
+type testPackPos struct {
+	X, Y int16
+}
+
+func TestPack_PackSize(t *testing.T) {
+	var res int
+	var err error
+
+	res, err = KConv.PackSize("<hhl")
+	assert.Nil(t, err)
+	assert.Equal(t, 8, res)
+
+	res, err = KConv.PackSize("!4s 2x ? 3Q d")
+	assert.Nil(t, err)
+	assert.Equal(t, 39, res)
+
+	res, err = KConv.PackSize("")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, res)
+
+	_, err = KConv.PackSize(">hz")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.PackSize(">h3")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	//重复次数过大
+	res, err = KConv.PackSize(fmt.Sprintf("%dx", PackMaxSize))
+	assert.Nil(t, err)
+	assert.Equal(t, PackMaxSize, res)
+	for _, format := range []string{"4611686018427387904q", "9223372036854775807x1B", "99999999999999999999x", fmt.Sprintf("%dxB", PackMaxSize), "33554432h2h"} {
+		_, err = KConv.PackSize(format)
+		assert.True(t, errors.Is(err, ErrInvalidParam), format)
+	}
+}
+
+func BenchmarkPack_PackSize(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.PackSize("!4s 2x ? 3Q d")
+	}
+}
+
+func TestPack_Pack(t *testing.T) {
+	var res []byte
+	var err error
+
+	res, err = KConv.Pack(">hHi", -2, uint16(0xABCD), int32(1))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xFF, 0xFE, 0xAB, 0xCD, 0, 0, 0, 1}, res)
+
+	res, err = KConv.Pack("<hHi", -2, uint16(0xABCD), int32(1))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xFE, 0xFF, 0xCD, 0xAB, 1, 0, 0, 0}, res)
+
+	res, err = KConv.Pack("!bB2x?4s3s", -1, 255, true, "abcdef", []byte("z"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xFF, 0xFF, 0, 0, 1, 'a', 'b', 'c', 'd', 'z', 0, 0}, res)
+
+	res, err = KConv.Pack(">qQ", int64(math.MinInt64), uint64(math.MaxUint64))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x80, 0, 0, 0, 0, 0, 0, 0, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, res)
+
+	res, err = KConv.Pack(">fd", 1.5, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x3F, 0xC0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}, res)
+
+	//本机字节序
+	num := 0x0102
+	res, err = KConv.Pack("=H", &num)
+	assert.Nil(t, err)
+	expect := make([]byte, 2)
+	KOS.GetEndian().PutUint16(expect, 0x0102)
+	assert.Equal(t, expect, res)
+
+	overflows := []struct {
+		format string
+		value  interface{}
+	}{
+		{"b", 128}, {"b", -129}, {"B", 256}, {"B", -1}, {"h", uint(1 << 15)},
+		{"H", 1 << 16}, {"i", int64(math.MaxInt32) + 1}, {"I", -1}, {"q", uint64(math.MaxInt64) + 1},
+		{"Q", -1}, {"f", math.MaxFloat64},
+	}
+	for _, test := range overflows {
+		_, err = KConv.Pack(test.format, test.value)
+		assert.True(t, errors.Is(err, ErrNumberOverflow), test.format)
+	}
+
+	_, err = KConv.Pack("h", 1.5)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.Pack("?", 1)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.Pack("2s", 1)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.Pack("d", "1")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.Pack("hh", 1)
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.Pack("s", nil)
+	assert.NotNil(t, err)
+	_, err = KConv.Pack("2s")
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.Pack("h", 1, 2)
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.Pack("y", 1)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.Pack("9223372036854775807x")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.Pack("4611686018427387904s", "a")
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkPack_Pack(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Pack("!hHi4sd", -2, 0xABCD, 1, "abcd", 1.5)
+	}
+}
+
+func TestPack_Unpack(t *testing.T) {
+	var res []interface{}
+	var err error
+
+	res, err = KConv.Unpack(">hHi", []byte{0xFF, 0xFE, 0xAB, 0xCD, 0, 0, 0, 1})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(-2), uint64(0xABCD), int64(1)}, res)
+
+	data, _ := KConv.Pack("<bB2x?4s2q2fd", -128, 200, false, "ab", int64(math.MinInt64), 7, 0.5, -1, math.Pi)
+	res, err = KConv.Unpack("<bB2x?4s2q2fd", data)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(-128), uint64(200), false, []byte{'a', 'b', 0, 0}, int64(math.MinInt64), int64(7), 0.5, -1.0, math.Pi}, res)
+
+	res, err = KConv.Unpack("", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, res)
+
+	_, err = KConv.Unpack(">I", []byte{1, 2, 3})
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.Unpack(">I", []byte{1, 2, 3, 4, 5})
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.Unpack(">Z", []byte{1})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.Unpack("4611686018427387904q", nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.Unpack("9223372036854775807x1B", []byte{1})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkPack_Unpack(b *testing.B) {
+	data, _ := KConv.Pack("!hHi4sd", -2, 0xABCD, 1, "abcd", 1.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.Unpack("!hHi4sd", data)
+	}
+}
+
+func TestPack_PackStruct(t *testing.T) {
+	var res []byte
+	var err error
+
+	header := testPackHeader{
+		Magic:   [2]byte{'K', 'G'},
+		Version: 2,
+		Flags:   -1,
+		Length:  0x01020304,
+		Seq:     -2,
+		Temp:    1.5,
+		Ratio:   0.25,
+		Online:  true,
+		Name:    "device",
+		Data:    []byte{1, 2, 3, 4},
+		Points:  []int16{-1},
+		Ignored: "ignored",
+		Pos:     testPackPos{X: 1, Y: -1},
+		secret:  9,
+	}
+	res, err = KConv.PackStruct(&header, nil)
+	assert.Nil(t, err)
+
+	expect, _ := KConv.Pack("!2sBb2xIqfd?8s3s2h2h", "KG", 2, -1, 0x01020304, -2, 1.5, 0.25, true, "device", []byte{1, 2, 3}, -1, 0, 1, -1)
+	assert.Equal(t, expect, res)
+
+	res, err = KConv.PackStruct(header, binary.LittleEndian)
+	assert.Nil(t, err)
+	expect, _ = KConv.Pack("<2sBb2xIqfd?8s3s2h2h", "KG", 2, -1, 0x01020304, -2, 1.5, 0.25, true, "device", []byte{1, 2, 3}, -1, 0, 1, -1)
+	assert.Equal(t, expect, res)
+
+	_, err = KConv.PackStruct(1, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.PackStruct(struct{ N int }{}, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.PackStruct(struct{ S string }{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.PackStruct(struct{ B []byte }{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.PackStruct(struct {
+		S string `bin:"size=x"`
+	}{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.PackStruct(struct{ M map[string]int8 }{}, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkPack_PackStruct(b *testing.B) {
+	header := testPackHeader{Name: "device", Data: []byte{1, 2, 3}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.PackStruct(&header, binary.BigEndian)
+	}
+}
+
+func TestPack_UnpackStruct(t *testing.T) {
+	var n int
+	var err error
+
+	header := testPackHeader{
+		Magic:   [2]byte{'K', 'G'},
+		Version: 2,
+		Flags:   -1,
+		Length:  0x01020304,
+		Seq:     -2,
+		Temp:    1.5,
+		Ratio:   0.25,
+		Online:  true,
+		Name:    "device",
+		Data:    []byte{1, 2, 3},
+		Points:  []int16{-1, 0},
+		Pos:     testPackPos{X: 1, Y: -1},
+	}
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		data, _ := KConv.PackStruct(header, order)
+		size := len(data)
+		data = append(data, "payload"...)
+
+		var res testPackHeader
+		res.Ignored = "keep"
+		n, err = KConv.UnpackStruct(data, &res, order)
+		assert.Nil(t, err)
+		assert.Equal(t, size, n)
+		assert.Equal(t, "payload", string(data[n:]))
+
+		expect := header
+		expect.Ignored = "keep"
+		assert.Equal(t, expect, res)
+	}
+
+	var res testPackHeader
+	data, _ := KConv.PackStruct(header, nil)
+	_, err = KConv.UnpackStruct(data[:len(data)-1], &res, nil)
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.UnpackStruct(data[:20], &res, nil)
+	assert.True(t, errors.Is(err, ErrLengthMismatch))
+	_, err = KConv.UnpackStruct(data, res, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+	_, err = KConv.UnpackStruct(data, &struct{ S string }{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.UnpackStruct(data, &struct{ B []byte }{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KConv.UnpackStruct(data, &struct{ U uint }{}, nil)
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkPack_UnpackStruct(b *testing.B) {
+	var res testPackHeader
+	data, _ := KConv.PackStruct(testPackHeader{Name: "device"}, binary.BigEndian)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.UnpackStruct(data, &res, binary.BigEndian)
+	}
+}
@@ -0,0 +1,196 @@
+package kgo
+
+import (
+	"sort"
+	"time"
+)
+
+// Period 时间段,为左闭右开区间[Start, End).
+type Period struct {
+	Start time.Time     `json:"start"` //开始时间(包含)
+	End   time.Time     `json:"end"`   //结束时间(不包含)
+	Unit  LkkPeriodUnit `json:"unit"`  //迭代的步长单位
+	Step  time.Duration `json:"step"`  //迭代的固定步长,仅当Unit为PERIOD_DURATION时有效
+}
+
+// NewPeriod 创建按日历单位迭代的时间段,如按天、周、月、季度、年;start>end时将交换.
+func (kt *LkkTime) NewPeriod(start, end time.Time, unit LkkPeriodUnit) Period {
+	if start.After(end) {
+		start, end = end, start
+	}
+	return Period{Start: start, End: end, Unit: unit}
+}
+
+// NewPeriodEvery 创建按固定时长step迭代的时间段;start>end时将交换.
+func (kt *LkkTime) NewPeriodEvery(start, end time.Time, step time.Duration) Period {
+	if start.After(end) {
+		start, end = end, start
+	}
+	return Period{Start: start, End: end, Unit: PERIOD_DURATION, Step: step}
+}
+
+// MergePeriods 合并时间段列表,将重叠或首尾相接的时间段合并,返回按开始时间排序的结果.
+func (kt *LkkTime) MergePeriods(periods []Period) []Period {
+	if len(periods) == 0 {
+		return nil
+	}
+
+	arr := make([]Period, len(periods))
+	copy(arr, periods)
+	sort.SliceStable(arr, func(i, j int) bool {
+		return arr[i].Start.Before(arr[j].Start)
+	})
+
+	res := []Period{arr[0]}
+	for _, p := range arr[1:] {
+		last := &res[len(res)-1]
+		if !p.Start.After(last.End) {
+			if p.End.After(last.End) {
+				last.End = p.End
+			}
+		} else {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+// periodAdd 将时间t按单位增加n个步长.
+func periodAdd(t time.Time, unit LkkPeriodUnit, step time.Duration, n int) time.Time {
+	switch unit {
+	case PERIOD_DAY:
+		return t.AddDate(0, 0, n)
+	case PERIOD_WEEK:
+		return t.AddDate(0, 0, 7*n)
+	case PERIOD_MONTH:
+		return periodAddMonths(t, n)
+	case PERIOD_QUARTER:
+		return periodAddMonths(t, 3*n)
+	case PERIOD_YEAR:
+		return periodAddMonths(t, 12*n)
+	default:
+		return t.Add(step * time.Duration(n))
+	}
+}
+
+// periodAddMonths 将时间t增加n个月;目标月份没有该日时取该月最后一天,如1月31日加1个月为2月28日(或29日).
+func periodAddMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	hour, minute, sec := t.Clock()
+	//目标月份的最后一天,即下个月的第0天
+	if last := time.Date(y, m+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day(); d > last {
+		d = last
+	}
+	return time.Date(y, m+time.Month(n), d, hour, minute, sec, t.Nanosecond(), t.Location())
+}
+
+// periodTruncate 将时间t截断到所在单位的开始时间.
+func periodTruncate(t time.Time, unit LkkPeriodUnit, step time.Duration) time.Time {
+	switch unit {
+	case PERIOD_DAY:
+		return KTime.StartOfDay(t)
+	case PERIOD_WEEK:
+		return KTime.StartOfWeek(t)
+	case PERIOD_MONTH:
+		return KTime.StartOfMonth(t)
+	case PERIOD_QUARTER:
+		return KTime.StartOfQuarter(t)
+	case PERIOD_YEAR:
+		return KTime.StartOfYear(t)
+	default:
+		return t.Truncate(step)
+	}
+}
+
+// valid 步长是否有效.
+func (p Period) valid() bool {
+	if p.Unit == PERIOD_DURATION {
+		return p.Step > 0
+	}
+	return p.Unit <= PERIOD_YEAR
+}
+
+// Duration 获取时间段的时长.
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// IsEmpty 时间段是否为空(开始时间不早于结束时间).
+func (p Period) IsEmpty() bool {
+	return !p.Start.Before(p.End)
+}
+
+// Each 从开始时间起按步长迭代时间点,直至结束时间(不包含);fn返回false时停止迭代.
+func (p Period) Each(fn func(t time.Time) bool) {
+	if !p.valid() {
+		return
+	}
+
+	for i := 0; ; i++ {
+		t := periodAdd(p.Start, p.Unit, p.Step, i)
+		if !t.Before(p.End) || !fn(t) {
+			break
+		}
+	}
+}
+
+// Slice 获取按步长迭代的所有时间点.
+func (p Period) Slice() (res []time.Time) {
+	p.Each(func(t time.Time) bool {
+		res = append(res, t)
+		return true
+	})
+	return
+}
+
+// Contains 时间点t是否在时间段内.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// Overlaps 是否与另一时间段有重叠(首尾相接不算重叠).
+func (p Period) Overlaps(o Period) bool {
+	return p.Start.Before(o.End) && o.Start.Before(p.End)
+}
+
+// Intersect 获取与另一时间段的交集;无交集时ok为false.
+func (p Period) Intersect(o Period) (res Period, ok bool) {
+	if !p.Overlaps(o) {
+		return
+	}
+
+	res = p
+	if o.Start.After(res.Start) {
+		res.Start = o.Start
+	}
+	if o.End.Before(res.End) {
+		res.End = o.End
+	}
+	ok = true
+	return
+}
+
+// Buckets 将时间段按步长拆分为与日历对齐的子时间段.
+// 如按月拆分2022-01-15~2022-03-10,得到[01-15,02-01)、[02-01,03-01)、[03-01,03-10);
+// 按固定时长拆分时,以该时长对零时刻取整对齐.
+func (p Period) Buckets() (res []Period) {
+	if !p.valid() || p.IsEmpty() {
+		return
+	}
+
+	base := periodTruncate(p.Start, p.Unit, p.Step)
+	start := p.Start
+	for i := 1; start.Before(p.End); i++ {
+		end := periodAdd(base, p.Unit, p.Step, i)
+		if end.After(p.End) {
+			end = p.End
+		}
+		if end.After(start) {
+			res = append(res, Period{Start: start, End: end, Unit: p.Unit, Step: p.Step})
+			start = end
+		}
+	}
+
+	return
+}
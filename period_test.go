@@ -0,0 +1,184 @@
+package kgo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPeriod_Each(t *testing.T) {
+	start := time.Date(2022, 1, 30, 0, 0, 0, 0, time.Local)
+	end := time.Date(2022, 2, 3, 0, 0, 0, 0, time.Local)
+
+	var res []time.Time
+	p := KTime.NewPeriod(end, start, PERIOD_DAY)
+	assert.Equal(t, start, p.Start)
+	res = p.Slice()
+	assert.Equal(t, 4, len(res))
+	assert.Equal(t, "2022-02-02", res[3].Format("2006-01-02"))
+
+	//提前终止
+	var num int
+	p.Each(func(t time.Time) bool {
+		num++
+		return num < 2
+	})
+	assert.Equal(t, 2, num)
+
+	//按月,不会因月末天数而漂移
+	p = KTime.NewPeriod(time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local), time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local), PERIOD_QUARTER)
+	res = p.Slice()
+	assert.Equal(t, 4, len(res))
+	assert.Equal(t, time.October, res[3].Month())
+
+	//从月末开始按月,不跳过2月
+	p = KTime.NewPeriod(time.Date(2024, 1, 31, 8, 0, 0, 0, time.Local), time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local), PERIOD_MONTH)
+	res = p.Slice()
+	days := make([]string, len(res))
+	for i, r := range res {
+		days[i] = r.Format("2006-01-02 15")
+	}
+	assert.Equal(t, []string{"2024-01-31 08", "2024-02-29 08", "2024-03-31 08", "2024-04-30 08", "2024-05-31 08"}, days)
+
+	//从闰日开始按季度和年
+	leap := time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local)
+	res = KTime.NewPeriod(leap, leap.AddDate(1, 0, 1), PERIOD_QUARTER).Slice()
+	assert.Equal(t, 5, len(res))
+	assert.Equal(t, time.Date(2024, 5, 29, 0, 0, 0, 0, time.Local), res[1])
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local), res[4])
+	res = KTime.NewPeriod(leap, time.Date(2028, 3, 1, 0, 0, 0, 0, time.Local), PERIOD_YEAR).Slice()
+	assert.Equal(t, 5, len(res))
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local), res[1])
+	assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.Local), res[4])
+
+	p = KTime.NewPeriodEvery(start, start.Add(time.Hour), 15*time.Minute)
+	assert.Equal(t, 4, len(p.Slice()))
+
+	//无效步长
+	p = KTime.NewPeriodEvery(start, end, 0)
+	assert.Empty(t, p.Slice())
+	p = KTime.NewPeriod(start, end, LkkPeriodUnit(99))
+	assert.Empty(t, p.Slice())
+}
+
+func BenchmarkPeriod_Each(b *testing.B) {
+	p := KTime.NewPeriod(myDate3, myDate1, PERIOD_DAY)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Each(func(t time.Time) bool {
+			return true
+		})
+	}
+}
+
+func TestPeriod_Contains(t *testing.T) {
+	p := KTime.NewPeriod(myDate2, myDate1, PERIOD_DAY)
+	assert.True(t, p.Contains(myDate2))
+	assert.False(t, p.Contains(myDate1))
+	assert.False(t, p.Contains(myDate3))
+	assert.Equal(t, myDate1.Sub(myDate2), p.Duration())
+	assert.False(t, p.IsEmpty())
+}
+
+func BenchmarkPeriod_Contains(b *testing.B) {
+	p := KTime.NewPeriod(myDate2, myDate1, PERIOD_DAY)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Contains(myDate3)
+	}
+}
+
+func TestPeriod_OverlapsIntersect(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 3, d, 0, 0, 0, 0, time.Local)
+	}
+	p1 := KTime.NewPeriod(day(1), day(10), PERIOD_DAY)
+	p2 := KTime.NewPeriod(day(5), day(15), PERIOD_DAY)
+	p3 := KTime.NewPeriod(day(10), day(20), PERIOD_DAY)
+
+	assert.True(t, p1.Overlaps(p2))
+	assert.False(t, p1.Overlaps(p3))
+
+	res, ok := p1.Intersect(p2)
+	assert.True(t, ok)
+	assert.Equal(t, day(5), res.Start)
+	assert.Equal(t, day(10), res.End)
+
+	_, ok = p1.Intersect(p3)
+	assert.False(t, ok)
+}
+
+func BenchmarkPeriod_Intersect(b *testing.B) {
+	p1 := KTime.NewPeriod(myDate2, myDate1, PERIOD_DAY)
+	p2 := KTime.NewPeriod(myDate2, myDate3, PERIOD_DAY)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = p1.Intersect(p2)
+	}
+}
+
+func TestPeriod_Buckets(t *testing.T) {
+	start := time.Date(2022, 1, 15, 8, 0, 0, 0, time.Local)
+	end := time.Date(2022, 3, 10, 0, 0, 0, 0, time.Local)
+
+	res := KTime.NewPeriod(start, end, PERIOD_MONTH).Buckets()
+	assert.Equal(t, 3, len(res))
+	assert.Equal(t, start, res[0].Start)
+	assert.Equal(t, time.Date(2022, 2, 1, 0, 0, 0, 0, time.Local), res[0].End)
+	assert.Equal(t, time.Date(2022, 3, 1, 0, 0, 0, 0, time.Local), res[2].Start)
+	assert.Equal(t, end, res[2].End)
+
+	//按周,周一对齐
+	res = KTime.NewPeriod(start, end, PERIOD_WEEK).Buckets()
+	assert.Equal(t, time.Monday, res[1].Start.Weekday())
+
+	res = KTime.NewPeriod(start, end, PERIOD_YEAR).Buckets()
+	assert.Equal(t, 1, len(res))
+
+	res = KTime.NewPeriodEvery(start.Add(30*time.Minute), start.Add(3*time.Hour), time.Hour).Buckets()
+	assert.Equal(t, 3, len(res))
+	assert.Equal(t, 0, res[1].Start.Minute())
+
+	assert.Empty(t, KTime.NewPeriod(start, start, PERIOD_DAY).Buckets())
+}
+
+func BenchmarkPeriod_Buckets(b *testing.B) {
+	p := KTime.NewPeriod(myDate3, myDate1, PERIOD_WEEK)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Buckets()
+	}
+}
+
+func TestTime_MergePeriods(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 3, d, 0, 0, 0, 0, time.Local)
+	}
+	periods := []Period{
+		KTime.NewPeriod(day(20), day(25), PERIOD_DAY),
+		KTime.NewPeriod(day(1), day(5), PERIOD_DAY),
+		KTime.NewPeriod(day(5), day(8), PERIOD_DAY),
+		KTime.NewPeriod(day(3), day(4), PERIOD_DAY),
+	}
+
+	res := KTime.MergePeriods(periods)
+	assert.Equal(t, 2, len(res))
+	assert.Equal(t, day(1), res[0].Start)
+	assert.Equal(t, day(8), res[0].End)
+	assert.Equal(t, day(20), res[1].Start)
+	//不修改原切片
+	assert.Equal(t, day(20), periods[0].Start)
+
+	assert.Nil(t, KTime.MergePeriods(nil))
+}
+
+func BenchmarkTime_MergePeriods(b *testing.B) {
+	periods := []Period{
+		KTime.NewPeriod(myDate2, myDate1, PERIOD_DAY),
+		KTime.NewPeriod(myDate1, myDate3, PERIOD_DAY),
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.MergePeriods(periods)
+	}
+}
@@ -0,0 +1,699 @@
+package kgo
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PhpArray PHP的数组,即键为int64或string的有序字典.
+type PhpArray struct {
+	keys   []interface{}
+	values map[interface{}]interface{}
+}
+
+// PhpObject PHP的对象.
+// 属性名与PHP一致,私有属性为"\x00类名\x00属性名",受保护属性为"\x00*\x00属性名".
+type PhpObject struct {
+	ClassName string    `json:"className"` //类名
+	Props     *PhpArray `json:"props"`     //属性
+}
+
+// PhpUnserializeOptions PhpUnserialize 的安全限制.
+type PhpUnserializeOptions struct {
+	MaxDepth  int `json:"maxDepth"`  //数组和对象的最大嵌套深度,默认64
+	MaxLength int `json:"maxLength"` //数据的最大字节数,为0时不限制
+	MaxNodes  int `json:"maxNodes"`  //PhpUnserializeTo 写入结构体等类型时,展开r:/R:引用后的最大值数量,默认1000000
+}
+
+// phpItem 序列化时数组或对象的元素
+type phpItem struct {
+	key interface{}
+	val reflect.Value
+}
+
+// phpDecoder PHP反序列化解码器
+type phpDecoder struct {
+	data  []byte
+	pos   int
+	depth int
+	opt   PhpUnserializeOptions
+	vars  []interface{} //已解码的值,供r:/R:引用
+}
+
+// NewPhpArray 创建PHP数组.
+func NewPhpArray() *PhpArray {
+	return &PhpArray{values: make(map[interface{}]interface{})}
+}
+
+// phpArrayKey 按PHP的规则转换数组键:整数和十进制整数字符串转为int64,布尔转为0/1,nil转为空串,其他转为字符串.
+func phpArrayKey(key interface{}) interface{} {
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() <= math.MaxInt64 {
+			return int64(v.Uint())
+		}
+	case reflect.Bool:
+		return int64(bool2Int(v.Bool()))
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f)
+		}
+	case reflect.String:
+		str := v.String()
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil && strconv.FormatInt(i, 10) == str {
+			return i
+		}
+		return str
+	}
+	return toStr(key)
+}
+
+// Set 设置键值,已存在的键保持原有位置.
+func (pa *PhpArray) Set(key, value interface{}) {
+	key = phpArrayKey(key)
+	if _, ok := pa.values[key]; !ok {
+		pa.keys = append(pa.keys, key)
+	}
+	pa.values[key] = value
+}
+
+// Append 以当前最大整数键加1为键追加值,同PHP的$arr[] = $value.
+func (pa *PhpArray) Append(value interface{}) {
+	var next int64
+	for _, k := range pa.keys {
+		if i, ok := k.(int64); ok && i >= next {
+			next = i + 1
+		}
+	}
+	pa.Set(next, value)
+}
+
+// Get 获取键对应的值.
+func (pa *PhpArray) Get(key interface{}) (interface{}, bool) {
+	res, ok := pa.values[phpArrayKey(key)]
+	return res, ok
+}
+
+// Delete 删除键.
+func (pa *PhpArray) Delete(key interface{}) {
+	key = phpArrayKey(key)
+	if _, ok := pa.values[key]; !ok {
+		return
+	}
+
+	delete(pa.values, key)
+	for i, k := range pa.keys {
+		if k == key {
+			pa.keys = append(pa.keys[:i], pa.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Keys 获取所有键(int64或string),按插入顺序.
+func (pa *PhpArray) Keys() []interface{} {
+	res := make([]interface{}, len(pa.keys))
+	copy(res, pa.keys)
+	return res
+}
+
+// Len 获取元素数量.
+func (pa *PhpArray) Len() int {
+	return len(pa.keys)
+}
+
+// IsList 是否列表,即键依次为0到Len()-1.
+func (pa *PhpArray) IsList() bool {
+	for i, k := range pa.keys {
+		if k != int64(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Values 获取所有值,按插入顺序.
+func (pa *PhpArray) Values() []interface{} {
+	res := make([]interface{}, len(pa.keys))
+	for i, k := range pa.keys {
+		res[i] = pa.values[k]
+	}
+	return res
+}
+
+// ToMap 转为键为字符串的字典(整数键转为字符串),不递归转换值.
+func (pa *PhpArray) ToMap() map[string]interface{} {
+	res := make(map[string]interface{}, len(pa.keys))
+	for _, k := range pa.keys {
+		res[toStr(k)] = pa.values[k]
+	}
+	return res
+}
+
+// PhpSerialize 生成与PHP serialize()兼容的序列化数据.
+// 支持nil、布尔、整数、浮点数、字符串、[]byte(作为字符串)、切片和数组(作为列表数组)、
+// 字典(键为整数或字符串,整数键在前并按大小排序,字符串键按名称排序)、*PhpArray 、*PhpObject ,
+// 以及结构体(按"php"标签转为关联数组,保持字段顺序;实现了encoding.TextMarshaler的转为字符串);nil的切片和字典为N.
+func (ks *LkkString) PhpSerialize(val interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := phpEncode(&buf, reflect.ValueOf(val), 0); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// phpEncode 序列化v.
+func phpEncode(buf *bytes.Buffer, v reflect.Value, depth int) error {
+	if depth > 512 {
+		return fmt.Errorf("[PhpSerialize]`nesting too deep: %w", ErrLimitExceeded)
+	}
+
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			buf.WriteString("N;")
+			return nil
+		}
+
+		if !v.CanInterface() {
+			v = v.Elem()
+			continue
+		}
+		switch o := v.Interface().(type) {
+		case *PhpArray:
+			buf.WriteString("a:")
+			return phpEncodeItems(buf, phpArrayItems(o), depth)
+		case *PhpObject:
+			fmt.Fprintf(buf, "O:%d:\"%s\":", len(o.ClassName), o.ClassName)
+			return phpEncodeItems(buf, phpArrayItems(o.Props), depth)
+		}
+		v = v.Elem()
+	}
+
+	if v.IsValid() && v.Kind() == reflect.Struct && v.CanInterface() {
+		if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+			text, err := tm.MarshalText()
+			if err != nil {
+				return err
+			}
+			phpEncodeString(buf, string(text))
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		buf.WriteString("N;")
+	case reflect.Bool:
+		fmt.Fprintf(buf, "b:%d;", bool2Int(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(buf, "i:%d;", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Errorf("[PhpSerialize]`%d: %w", v.Uint(), ErrNumberOverflow)
+		}
+		fmt.Fprintf(buf, "i:%d;", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString("d:" + phpFloat(v.Float()) + ";")
+	case reflect.String:
+		phpEncodeString(buf, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			buf.WriteString("N;")
+			return nil
+		} else if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			phpEncodeString(buf, string(v.Bytes()))
+			return nil
+		}
+		items := make([]phpItem, v.Len())
+		for i := range items {
+			items[i] = phpItem{int64(i), v.Index(i)}
+		}
+		buf.WriteString("a:")
+		return phpEncodeItems(buf, items, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			buf.WriteString("N;")
+			return nil
+		}
+		items := make([]phpItem, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			items = append(items, phpItem{phpArrayKey(k.Interface()), v.MapIndex(k)})
+		}
+		sort.Slice(items, func(i, j int) bool {
+			a, aInt := items[i].key.(int64)
+			b, bInt := items[j].key.(int64)
+			if aInt != bInt {
+				return aInt
+			} else if aInt {
+				return a < b
+			}
+			return items[i].key.(string) < items[j].key.(string)
+		})
+		buf.WriteString("a:")
+		return phpEncodeItems(buf, items, depth)
+	case reflect.Struct:
+		buf.WriteString("a:")
+		return phpEncodeItems(buf, phpStructItems(v, nil), depth)
+	default:
+		return fmt.Errorf("[PhpSerialize]`%s: %w", v.Type(), ErrUnsupportedType)
+	}
+	return nil
+}
+
+// phpArrayItems 获取PHP数组的元素.
+func phpArrayItems(arr *PhpArray) []phpItem {
+	if arr == nil {
+		return nil
+	}
+
+	items := make([]phpItem, len(arr.keys))
+	for i, k := range arr.keys {
+		items[i] = phpItem{k, reflect.ValueOf(arr.values[k])}
+	}
+	return items
+}
+
+// phpStructItems 将结构体的可导出字段按"php"标签加入items,无标签的匿名嵌入结构体展开.
+func phpStructItems(v reflect.Value, items []phpItem) []phpItem {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("php")
+		if tag == "-" {
+			continue
+		}
+
+		name := splitTagName(tag)
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			items = phpStructItems(v.Field(i), items)
+		} else if field.PkgPath == "" {
+			if name == "" {
+				name = field.Name
+			}
+			items = append(items, phpItem{phpArrayKey(name), v.Field(i)})
+		}
+	}
+	return items
+}
+
+// phpEncodeItems 序列化数组或对象的元素数量及"{...}".
+func phpEncodeItems(buf *bytes.Buffer, items []phpItem, depth int) error {
+	fmt.Fprintf(buf, "%d:{", len(items))
+	for _, item := range items {
+		if i, ok := item.key.(int64); ok {
+			fmt.Fprintf(buf, "i:%d;", i)
+		} else {
+			phpEncodeString(buf, item.key.(string))
+		}
+
+		if err := phpEncode(buf, item.val, depth+1); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// phpEncodeString 序列化字符串,长度为字节数.
+func phpEncodeString(buf *bytes.Buffer, str string) {
+	fmt.Fprintf(buf, "s:%d:\"%s\";", len(str), str)
+}
+
+// phpFloat 按PHP的格式输出浮点数.
+func phpFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NAN"
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	}
+	return strings.ToUpper(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// PhpUnserialize 解析PHP serialize()生成的数据.
+// 结果为nil、bool、int64、float64、string、*PhpArray 或 *PhpObject ,数组保持原有顺序,支持r:和R:引用;
+// 不支持C:(自定义序列化)和E:(枚举).opt为可选的安全限制,超出时返回 ErrLimitExceeded .
+func (ks *LkkString) PhpUnserialize(data []byte, opt ...PhpUnserializeOptions) (interface{}, error) {
+	d := &phpDecoder{data: data, opt: PhpUnserializeOptions{MaxDepth: 64}}
+	if len(opt) > 0 {
+		if opt[0].MaxDepth > 0 {
+			d.opt.MaxDepth = opt[0].MaxDepth
+		}
+		d.opt.MaxLength = opt[0].MaxLength
+	}
+	if d.opt.MaxLength > 0 && len(data) > d.opt.MaxLength {
+		return nil, fmt.Errorf("[PhpUnserialize]`data length %d: %w", len(data), ErrLimitExceeded)
+	}
+
+	res, err := d.decode(false)
+	if err != nil {
+		return nil, err
+	} else if d.pos != len(d.data) {
+		return nil, d.errorf("unexpected trailing data")
+	}
+	return res, nil
+}
+
+// PhpUnserializeTo 解析PHP serialize()生成的数据并写入res指针.
+// res为*interface{}时,列表数组转为[]interface{},其他数组和对象转为map[string]interface{}(属性名去掉可见性前缀);
+// res为**PhpArray时保持原始结构;其他类型(如结构体按"php"标签)使用与 Map2Struct 相同的弱类型转换,
+// 此时展开引用后的值数量超过 PhpUnserializeOptions 的MaxNodes时返回 ErrLimitExceeded .
+func (ks *LkkString) PhpUnserializeTo(data []byte, res interface{}, opt ...PhpUnserializeOptions) error {
+	val, err := ks.PhpUnserialize(data, opt...)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(res)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("[PhpUnserializeTo]`res must be a non-nil pointer")
+	}
+	if arr, ok := val.(*PhpArray); ok {
+		if r, ok := res.(**PhpArray); ok {
+			*r = arr
+			return nil
+		}
+	}
+
+	plain := phpPlain(val, make(map[interface{}]bool), make(map[interface{}]interface{}))
+	if r, ok := res.(*interface{}); ok {
+		*r = plain
+		return nil
+	}
+
+	//多次引用的值在写入结构体等类型时会被逐一展开,须限制展开后的数量
+	maxNodes := 1000000
+	if len(opt) > 0 && opt[0].MaxNodes > 0 {
+		maxNodes = opt[0].MaxNodes
+	}
+	if n := phpNodes(val, maxNodes, make(map[interface{}]bool), make(map[interface{}]int)); n > maxNodes {
+		return fmt.Errorf("[PhpUnserializeTo]`more than %d values after expanding references: %w", maxNodes, ErrLimitExceeded)
+	}
+
+	sd := &structDecoder{tagName: "php"}
+	sd.decode("", plain, rv.Elem())
+	if len(sd.errs) > 0 {
+		return &DecodeError{Fields: sd.errs}
+	}
+	return nil
+}
+
+// phpPlain 将 *PhpArray 和 *PhpObject 递归转为切片或字典;循环引用转为nil.
+// done缓存已转换的数组和对象,多次引用的值共用同一结果,避免嵌套引用导致结果呈指数增长.
+func phpPlain(val interface{}, visiting map[interface{}]bool, done map[interface{}]interface{}) interface{} {
+	var arr *PhpArray
+	isObj := false
+	switch v := val.(type) {
+	case *PhpArray:
+		arr = v
+	case *PhpObject:
+		arr, isObj = v.Props, true
+		if arr == nil {
+			return map[string]interface{}{}
+		}
+	default:
+		return val
+	}
+
+	if res, ok := done[val]; ok {
+		return res
+	} else if visiting[arr] {
+		return nil
+	}
+	visiting[arr] = true
+	defer delete(visiting, arr)
+
+	if !isObj && arr.IsList() {
+		res := make([]interface{}, arr.Len())
+		for i, k := range arr.keys {
+			res[i] = phpPlain(arr.values[k], visiting, done)
+		}
+		done[val] = res
+		return res
+	}
+
+	res := make(map[string]interface{}, arr.Len())
+	for _, k := range arr.keys {
+		name := toStr(k)
+		if isObj {
+			name = name[strings.LastIndexByte(name, 0)+1:]
+		}
+		res[name] = phpPlain(arr.values[k], visiting, done)
+	}
+	done[val] = res
+	return res
+}
+
+// phpNodes 统计val展开引用后的值数量,超过limit时返回limit+1;循环引用计为1个.
+func phpNodes(val interface{}, limit int, visiting map[interface{}]bool, done map[interface{}]int) int {
+	var arr *PhpArray
+	switch v := val.(type) {
+	case *PhpArray:
+		arr = v
+	case *PhpObject:
+		arr = v.Props
+	}
+	if arr == nil {
+		return 1
+	} else if n, ok := done[val]; ok {
+		return n
+	} else if visiting[arr] {
+		return 1
+	}
+	visiting[arr] = true
+	defer delete(visiting, arr)
+
+	res := 1
+	for _, k := range arr.keys {
+		if res += phpNodes(arr.values[k], limit, visiting, done); res > limit {
+			res = limit + 1
+			break
+		}
+	}
+	done[val] = res
+	return res
+}
+
+// errorf 生成带偏移量的解析错误.
+func (d *phpDecoder) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("[PhpUnserialize]`offset %d: %s", d.pos, fmt.Sprintf(format, args...))
+}
+
+// expect 读取指定的字符.
+func (d *phpDecoder) expect(c byte) error {
+	if d.pos >= len(d.data) || d.data[d.pos] != c {
+		return d.errorf("expected '%c'", c)
+	}
+	d.pos++
+	return nil
+}
+
+// readUntil 读取到字符c为止的内容,并跳过c.
+func (d *phpDecoder) readUntil(c byte) (string, error) {
+	idx := bytes.IndexByte(d.data[d.pos:], c)
+	if idx < 0 {
+		return "", d.errorf("expected '%c'", c)
+	}
+	res := string(d.data[d.pos : d.pos+idx])
+	d.pos += idx + 1
+	return res, nil
+}
+
+// readLen 读取非负长度及其后的字符c,长度不能超过剩余数据的字节数除以minSize.
+func (d *phpDecoder) readLen(c byte, minSize int) (int, error) {
+	str, err := d.readUntil(c)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(str)
+	if err != nil || n < 0 {
+		return 0, d.errorf("invalid length %q", str)
+	} else if n > (len(d.data)-d.pos)/minSize {
+		return 0, d.errorf("length %d exceeds data", n)
+	}
+	return n, nil
+}
+
+// readString 读取"xxx"形式、长度为n字节的字符串.
+func (d *phpDecoder) readString(n int) (string, error) {
+	if err := d.expect('"'); err != nil {
+		return "", err
+	} else if d.pos+n > len(d.data) {
+		return "", d.errorf("string length %d exceeds data", n)
+	}
+	res := string(d.data[d.pos : d.pos+n])
+	d.pos += n
+	return res, d.expect('"')
+}
+
+// decode 解析一个值;isKey为是否数组键,数组键只能是整数或字符串且不计入引用编号.
+func (d *phpDecoder) decode(isKey bool) (interface{}, error) {
+	if d.pos+1 >= len(d.data) {
+		return nil, d.errorf("unexpected end of data")
+	}
+
+	typ := d.data[d.pos]
+	if isKey && typ != 'i' && typ != 's' {
+		return nil, d.errorf("invalid array key type '%c'", typ)
+	}
+
+	slot := -1
+	if !isKey && typ != 'R' {
+		slot = len(d.vars)
+		d.vars = append(d.vars, nil)
+	}
+
+	if typ == 'N' {
+		d.pos++
+		return nil, d.expect(';')
+	}
+
+	d.pos++
+	if err := d.expect(':'); err != nil {
+		return nil, err
+	}
+
+	var res interface{}
+	switch typ {
+	case 'b':
+		str, err := d.readUntil(';')
+		if err != nil {
+			return nil, err
+		} else if str != "0" && str != "1" {
+			return nil, d.errorf("invalid bool %q", str)
+		}
+		res = str == "1"
+	case 'i':
+		str, err := d.readUntil(';')
+		if err != nil {
+			return nil, err
+		}
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, d.errorf("invalid int %q", str)
+		}
+		res = i
+	case 'd':
+		str, err := d.readUntil(';')
+		if err != nil {
+			return nil, err
+		}
+		switch str {
+		case "INF":
+			res = math.Inf(1)
+		case "-INF":
+			res = math.Inf(-1)
+		case "NAN":
+			res = math.NaN()
+		default:
+			f, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, d.errorf("invalid float %q", str)
+			}
+			res = f
+		}
+	case 's':
+		n, err := d.readLen(':', 1)
+		if err != nil {
+			return nil, err
+		}
+		str, err := d.readString(n)
+		if err != nil {
+			return nil, err
+		}
+		res = str
+		if err = d.expect(';'); err != nil {
+			return nil, err
+		}
+	case 'a':
+		arr := NewPhpArray()
+		d.setVar(slot, arr)
+		if err := d.decodeItems(arr); err != nil {
+			return nil, err
+		}
+		return arr, nil
+	case 'O':
+		n, err := d.readLen(':', 1)
+		if err != nil {
+			return nil, err
+		}
+		name, err := d.readString(n)
+		if err != nil {
+			return nil, err
+		} else if err = d.expect(':'); err != nil {
+			return nil, err
+		}
+		obj := &PhpObject{ClassName: name, Props: NewPhpArray()}
+		d.setVar(slot, obj)
+		if err = d.decodeItems(obj.Props); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case 'r', 'R':
+		str, err := d.readUntil(';')
+		if err != nil {
+			return nil, err
+		}
+		idx, err := strconv.Atoi(str)
+		if err != nil || idx < 1 || idx > len(d.vars) || idx-1 == slot {
+			return nil, d.errorf("invalid reference %q", str)
+		}
+		res = d.vars[idx-1]
+	default:
+		return nil, d.errorf("unsupported type '%c'", typ)
+	}
+
+	d.setVar(slot, res)
+	return res, nil
+}
+
+// setVar 记录已解码的值.
+func (d *phpDecoder) setVar(slot int, val interface{}) {
+	if slot >= 0 {
+		d.vars[slot] = val
+	}
+}
+
+// decodeItems 解析"n:{...}"形式的数组或对象元素.
+func (d *phpDecoder) decodeItems(arr *PhpArray) error {
+	d.depth++
+	defer func() { d.depth-- }()
+	if d.depth > d.opt.MaxDepth {
+		return fmt.Errorf("[PhpUnserialize]`offset %d: nesting depth %d: %w", d.pos, d.depth, ErrLimitExceeded)
+	}
+
+	//每个元素至少为"i:0;N;"
+	n, err := d.readLen(':', 6)
+	if err != nil {
+		return err
+	} else if err = d.expect('{'); err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		key, err := d.decode(true)
+		if err != nil {
+			return err
+		}
+		val, err := d.decode(false)
+		if err != nil {
+			return err
+		}
+		arr.Set(key, val)
+	}
+	return d.expect('}')
+}
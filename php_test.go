@@ -0,0 +1,288 @@
+package kgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPhpBase struct {
+	ID int `php:"id"`
+}
+
+type testPhpUser struct {
+	testPhpBase
+	Name    string            `php:"name"`
+	Tags    []string          `php:"tags"`
+	Attrs   map[string]string `php:"attrs"`
+	Created time.Time         `php:"created"`
+	Skip    string            `php:"-"`
+	secret  string
+}
+
+func TestPhp_PhpArray(t *testing.T) {
+	arr := NewPhpArray()
+	arr.Set("name", "kgo")
+	arr.Set("5", "five")
+	arr.Append("six")
+	arr.Set(true, "one")
+	arr.Set("name", "KGO")
+
+	assert.Equal(t, 4, arr.Len())
+	assert.Equal(t, []interface{}{"name", int64(5), int64(6), int64(1)}, arr.Keys())
+	assert.Equal(t, []interface{}{"KGO", "five", "six", "one"}, arr.Values())
+	assert.False(t, arr.IsList())
+
+	res, ok := arr.Get(5)
+	assert.True(t, ok)
+	assert.Equal(t, "five", res)
+	res, ok = arr.Get("06")
+	assert.False(t, ok)
+
+	arr.Delete("5")
+	arr.Delete("none")
+	assert.Equal(t, 3, arr.Len())
+	assert.Equal(t, map[string]interface{}{"name": "KGO", "6": "six", "1": "one"}, arr.ToMap())
+
+	list := NewPhpArray()
+	list.Append("a")
+	list.Append("b")
+	assert.True(t, list.IsList())
+}
+
+func BenchmarkPhp_PhpArray(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		arr := NewPhpArray()
+		arr.Set("a", 1)
+		arr.Append(2)
+	}
+}
+
+func TestPhp_PhpSerialize(t *testing.T) {
+	var res []byte
+	var err error
+
+	tests := []struct {
+		val  interface{}
+		want string
+	}{
+		{nil, "N;"},
+		{true, "b:1;"},
+		{-12, "i:-12;"},
+		{uint8(7), "i:7;"},
+		{0.1, "d:0.1;"},
+		{1.0, "d:1;"},
+		{1e25, "d:1E+25;"},
+		{math.Inf(-1), "d:-INF;"},
+		{"你好", `s:6:"你好";`},
+		{[]byte("ab"), `s:2:"ab";`},
+		{[]interface{}{1, "a", nil}, `a:3:{i:0;i:1;i:1;s:1:"a";i:2;N;}`},
+		{map[string]int{"b": 2, "a": 1, "10": 10, "2": 3}, `a:4:{i:2;i:3;i:10;i:10;s:1:"a";i:1;s:1:"b";i:2;}`},
+		{&PhpObject{ClassName: "Foo"}, `O:3:"Foo":0:{}`},
+	}
+	for _, test := range tests {
+		res, err = KStr.PhpSerialize(test.val)
+		assert.Nil(t, err)
+		assert.Equal(t, test.want, string(res))
+	}
+
+	arr := NewPhpArray()
+	arr.Set("x", []int{1})
+	obj := &PhpObject{ClassName: "Bar", Props: NewPhpArray()}
+	obj.Props.Set("\x00*\x00prot", arr)
+	res, err = KStr.PhpSerialize(obj)
+	assert.Nil(t, err)
+	assert.Equal(t, "O:3:\"Bar\":1:{s:7:\"\x00*\x00prot\";a:1:{s:1:\"x\";a:1:{i:0;i:1;}}}", string(res))
+
+	user := &testPhpUser{
+		testPhpBase: testPhpBase{ID: 3},
+		Name:        "kgo",
+		Tags:        []string{"a"},
+		Created:     time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Skip:        "x",
+		secret:      "y",
+	}
+	res, err = KStr.PhpSerialize(user)
+	assert.Nil(t, err)
+	assert.Equal(t, `a:5:{s:2:"id";i:3;s:4:"name";s:3:"kgo";s:4:"tags";a:1:{i:0;s:1:"a";}s:5:"attrs";N;s:7:"created";s:20:"2021-01-02T03:04:05Z";}`, string(res))
+
+	_, err = KStr.PhpSerialize(uint64(math.MaxUint64))
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+	_, err = KStr.PhpSerialize(make(chan int))
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+	//循环引用
+	loop := NewPhpArray()
+	loop.Set(0, loop)
+	_, err = KStr.PhpSerialize(loop)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+}
+
+func BenchmarkPhp_PhpSerialize(b *testing.B) {
+	data := map[string]interface{}{"name": "kgo", "tags": []string{"a", "b"}, "id": 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.PhpSerialize(data)
+	}
+}
+
+func TestPhp_PhpUnserialize(t *testing.T) {
+	var res interface{}
+	var err error
+
+	//PHP: serialize(['b' => true, 2 => 1.5, 'list' => [1, null], 'obj' => $user])
+	data := "a:4:{s:1:\"b\";b:1;i:2;d:1.5;s:4:\"list\";a:2:{i:0;i:1;i:1;N;}s:3:\"obj\";O:4:\"User\":3:{s:4:\"name\";s:3:\"kgo\";s:9:\"\x00User\x00pwd\";s:3:\"123\";s:6:\"\x00*\x00age\";i:18;}}"
+	res, err = KStr.PhpUnserialize([]byte(data))
+	assert.Nil(t, err)
+
+	arr := res.(*PhpArray)
+	assert.Equal(t, []interface{}{"b", int64(2), "list", "obj"}, arr.Keys())
+	val, _ := arr.Get(2)
+	assert.Equal(t, 1.5, val)
+	val, _ = arr.Get("list")
+	assert.Equal(t, []interface{}{int64(1), nil}, val.(*PhpArray).Values())
+
+	val, _ = arr.Get("obj")
+	obj := val.(*PhpObject)
+	assert.Equal(t, "User", obj.ClassName)
+	val, _ = obj.Props.Get("\x00User\x00pwd")
+	assert.Equal(t, "123", val)
+
+	//往返
+	out, err := KStr.PhpSerialize(res)
+	assert.Nil(t, err)
+	assert.Equal(t, data, string(out))
+
+	//引用: $a = ['x' => 1]; serialize([$a, &$a['x'], $o, $o])
+	res, err = KStr.PhpUnserialize([]byte(`a:4:{i:0;a:1:{s:1:"x";i:1;}i:1;R:3;i:2;O:8:"stdClass":0:{}i:3;r:4;}`))
+	assert.Nil(t, err)
+	items := res.(*PhpArray).Values()
+	assert.Equal(t, int64(1), items[1])
+	assert.Same(t, items[2], items[3])
+
+	res, err = KStr.PhpUnserialize([]byte(`d:NAN;`))
+	assert.Nil(t, err)
+	assert.True(t, math.IsNaN(res.(float64)))
+
+	res, err = KStr.PhpUnserialize([]byte(`s:6:"你好";`))
+	assert.Nil(t, err)
+	assert.Equal(t, "你好", res)
+
+	//错误和安全限制
+	bad := []string{
+		"", "N", "x:1;", "b:2;", "i:abc;", "d:x;", `s:5:"abc";`, `s:3:"abc"`, `s:-1:"";`,
+		`a:1:{i:0;i:1;`, `a:1:{d:1.5;i:1;}`, `a:99999999:{}`, `r:1;`, `a:1:{i:0;R:9;}`,
+		`C:3:"Foo":0:{}`, `i:1;i:2;`, `O:3:"Foo:0:{}`,
+	}
+	for _, b := range bad {
+		_, err = KStr.PhpUnserialize([]byte(b))
+		assert.NotNil(t, err, b)
+	}
+	assert.False(t, errors.Is(err, ErrLimitExceeded))
+
+	deep := strings.Repeat("a:1:{i:0;", 70) + "N;" + strings.Repeat("}", 70)
+	_, err = KStr.PhpUnserialize([]byte(deep))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	_, err = KStr.PhpUnserialize([]byte(deep), PhpUnserializeOptions{MaxDepth: 100})
+	assert.Nil(t, err)
+	_, err = KStr.PhpUnserialize([]byte(`s:3:"abc";`), PhpUnserializeOptions{MaxLength: 5})
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+}
+
+func BenchmarkPhp_PhpUnserialize(b *testing.B) {
+	data := []byte(`a:3:{s:2:"id";i:1;s:4:"name";s:3:"kgo";s:4:"tags";a:2:{i:0;s:1:"a";i:1;s:1:"b";}}`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.PhpUnserialize(data)
+	}
+}
+
+func TestPhp_PhpUnserializeTo(t *testing.T) {
+	var err error
+
+	data := `a:5:{s:2:"id";s:2:"12";s:4:"name";s:3:"kgo";s:4:"tags";a:2:{i:0;s:1:"a";i:1;s:1:"b";}s:5:"attrs";a:1:{s:1:"k";i:1;}s:7:"created";s:19:"2021-01-02 03:04:05";}`
+	var user testPhpUser
+	err = KStr.PhpUnserializeTo([]byte(data), &user)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, user.ID)
+	assert.Equal(t, "kgo", user.Name)
+	assert.Equal(t, []string{"a", "b"}, user.Tags)
+	assert.Equal(t, map[string]string{"k": "1"}, user.Attrs)
+	assert.Equal(t, 2021, user.Created.Year())
+
+	//对象转为字典,属性名去掉可见性前缀
+	var itf interface{}
+	err = KStr.PhpUnserializeTo([]byte("O:4:\"User\":2:{s:4:\"name\";s:3:\"kgo\";s:6:\"\x00*\x00age\";i:18;}"), &itf)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "kgo", "age": int64(18)}, itf)
+
+	var m map[string]interface{}
+	err = KStr.PhpUnserializeTo([]byte(`a:2:{i:0;s:1:"a";i:5;s:1:"b";}`), &m)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"0": "a", "5": "b"}, m)
+
+	var list []int
+	err = KStr.PhpUnserializeTo([]byte(`a:2:{i:0;i:1;i:1;s:1:"2";}`), &list)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, list)
+
+	var arr *PhpArray
+	err = KStr.PhpUnserializeTo([]byte(`a:1:{s:1:"x";i:1;}`), &arr)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, arr.Len())
+
+	//循环引用
+	err = KStr.PhpUnserializeTo([]byte(`a:1:{i:0;r:1;}`), &itf)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{nil}, itf)
+
+	//嵌套的引用共用同一结果,不会指数增长
+	var sb strings.Builder
+	sb.WriteString("a:22:{i:0;a:0:{}")
+	for k, prev := 1, 2; k < 22; k, prev = k+1, 3*k {
+		sb.WriteString(fmt.Sprintf("i:%d;a:2:{i:0;r:%d;i:1;r:%d;}", k, prev, prev))
+	}
+	sb.WriteString("}")
+	nested := []byte(sb.String())
+	assert.Less(t, len(nested), 4096)
+
+	start := time.Now()
+	err = KStr.PhpUnserializeTo(nested, &itf, PhpUnserializeOptions{MaxLength: 4096})
+	assert.Nil(t, err)
+	assert.Equal(t, 22, len(itf.([]interface{})))
+	var nestedList [][]interface{}
+	err = KStr.PhpUnserializeTo(nested, &nestedList, PhpUnserializeOptions{MaxLength: 4096})
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+
+	err = KStr.PhpUnserializeTo([]byte(`a:2:{i:0;a:1:{i:0;i:1;}i:1;r:2;}`), &nestedList, PhpUnserializeOptions{MaxNodes: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{int64(1)}, {int64(1)}}, nestedList)
+	err = KStr.PhpUnserializeTo([]byte(`a:2:{i:0;a:1:{i:0;i:1;}i:1;r:2;}`), &nestedList, PhpUnserializeOptions{MaxNodes: 4})
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+
+	var num int
+	err = KStr.PhpUnserializeTo([]byte(`s:3:"abc";`), &num)
+	var de *DecodeError
+	assert.True(t, errors.As(err, &de))
+
+	err = KStr.PhpUnserializeTo([]byte(`i:1;`), num)
+	assert.NotNil(t, err)
+	err = KStr.PhpUnserializeTo([]byte(`i:1`), &num)
+	assert.NotNil(t, err)
+}
+
+func BenchmarkPhp_PhpUnserializeTo(b *testing.B) {
+	data := []byte(`a:3:{s:2:"id";i:1;s:4:"name";s:3:"kgo";s:4:"tags";a:2:{i:0;s:1:"a";i:1;s:1:"b";}}`)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var user testPhpUser
+		_ = KStr.PhpUnserializeTo(data, &user)
+	}
+}
@@ -0,0 +1,201 @@
+package kgo
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RandSource 随机数源接口,与math/rand.Source64一致;
+// 可用 NewSecureSource 创建密码学安全的随机源,用 NewSeededSource 创建可复现的伪随机源.
+type RandSource interface {
+	Int63() int64
+	Uint64() uint64
+	Seed(seed int64)
+}
+
+// Reservoir 蓄水池抽样器,从未知长度的数据流中等概率地抽取k个元素.
+type Reservoir struct {
+	k     int
+	count int64
+	items []interface{}
+	rnd   *rand.Rand
+}
+
+// secureSource 基于crypto/rand的随机源
+type secureSource struct {
+}
+
+// lockedSource 并发安全的伪随机源
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+// defaultRandSource 默认的随机源,以启动时间为种子
+var defaultRandSource RandSource = NewSeededSource(time.Now().UnixNano())
+
+// NewSecureSource 创建基于crypto/rand的密码学安全随机源,Seed无效.
+func NewSecureSource() RandSource {
+	return secureSource{}
+}
+
+// NewSeededSource 创建以seed为种子的伪随机源,相同种子产生相同序列,可用于测试复现;并发安全.
+func NewSeededSource(seed int64) RandSource {
+	return &lockedSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+// Int63 获取一个非负的int64随机数.
+func (ss secureSource) Int63() int64 {
+	return int64(ss.Uint64() & math.MaxInt64)
+}
+
+// Uint64 获取一个uint64随机数.
+func (ss secureSource) Uint64() uint64 {
+	var buf [8]byte
+	if _, err := crand.Read(buf[:]); err != nil {
+		panic("[secureSource]`crypto/rand read failed: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// Seed 密码学安全随机源不需要种子,调用无效.
+func (ss secureSource) Seed(seed int64) {
+}
+
+// Int63 获取一个非负的int64随机数.
+func (ls *lockedSource) Int63() int64 {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.src.Int63()
+}
+
+// Uint64 获取一个uint64随机数.
+func (ls *lockedSource) Uint64() uint64 {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.src.Uint64()
+}
+
+// Seed 重新设置种子.
+func (ls *lockedSource) Seed(seed int64) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.src.Seed(seed)
+}
+
+// WithSource 创建使用随机源src的数值工具;src为nil时使用默认随机源.
+func (kn *LkkNumber) WithSource(src RandSource) *LkkNumber {
+	return &LkkNumber{source: src}
+}
+
+// Source 获取当前使用的随机源.
+func (kn *LkkNumber) Source() RandSource {
+	if kn.source == nil {
+		return defaultRandSource
+	}
+	return kn.source
+}
+
+// rnd 获取基于当前随机源的随机数生成器.
+func (kn *LkkNumber) rnd() *rand.Rand {
+	return rand.New(kn.Source())
+}
+
+// WithSource 创建使用随机源src的数组工具;src为nil时使用默认随机源.
+func (ka *LkkArray) WithSource(src RandSource) *LkkArray {
+	return &LkkArray{source: src}
+}
+
+// Source 获取当前使用的随机源.
+func (ka *LkkArray) Source() RandSource {
+	if ka.source == nil {
+		return defaultRandSource
+	}
+	return ka.source
+}
+
+// rnd 获取基于当前随机源的随机数生成器.
+func (ka *LkkArray) rnd() *rand.Rand {
+	return rand.New(ka.Source())
+}
+
+// WeightedChoice 按权重随机选择,返回被选中的下标;weights为各项的权重,须非负且总和大于0.
+func (kn *LkkNumber) WeightedChoice(weights []float64) (int, error) {
+	if len(weights) == 0 {
+		return -1, ErrEmptyInput
+	}
+
+	var total float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return -1, ErrInvalidParam
+		}
+		total += w
+	}
+	if total <= 0 {
+		return -1, ErrInvalidParam
+	}
+
+	r := kn.rnd().Float64() * total
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		if r < w {
+			return i, nil
+		}
+		r -= w
+		last = i
+	}
+
+	//浮点误差时取最后一个有效项
+	return last, nil
+}
+
+// RandNormal 生成正态(高斯)分布的随机数,mean为均值,stddev为标准差.
+func (kn *LkkNumber) RandNormal(mean, stddev float64) float64 {
+	return kn.rnd().NormFloat64()*stddev + mean
+}
+
+// RandExponential 生成指数分布的随机数,rate为速率参数λ(均值为1/λ);rate不是大于0的有限数时将panic,可改用 RandExponentialE .
+func (kn *LkkNumber) RandExponential(rate float64) float64 {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		panic("[RandExponential]`rate must be a finite number greater than 0")
+	}
+	return kn.rnd().ExpFloat64() / rate
+}
+
+// NewReservoir 创建容量为k的蓄水池抽样器,使用当前随机源;k小于1时将panic,可改用 NewReservoirE .
+func (kn *LkkNumber) NewReservoir(k int) *Reservoir {
+	if k < 1 {
+		panic("[NewReservoir]`k must be greater than 0")
+	}
+	return &Reservoir{k: k, items: make([]interface{}, 0, k), rnd: kn.rnd()}
+}
+
+// Add 添加一个元素,该元素以 k/n 的概率被保留(n为已添加的总数).
+func (rs *Reservoir) Add(item interface{}) {
+	rs.count++
+	if len(rs.items) < rs.k {
+		rs.items = append(rs.items, item)
+	} else if j := rs.rnd.Int63n(rs.count); j < int64(rs.k) {
+		rs.items[j] = item
+	}
+}
+
+// Count 获取已添加的元素总数.
+func (rs *Reservoir) Count() int64 {
+	return rs.count
+}
+
+// Items 获取当前的抽样结果(副本).
+func (rs *Reservoir) Items() []interface{} {
+	res := make([]interface{}, len(rs.items))
+	copy(res, rs.items)
+	return res
+}
@@ -0,0 +1,181 @@
+package kgo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandom_NewSecureSource(t *testing.T) {
+	src := NewSecureSource()
+	src.Seed(1)
+	assert.GreaterOrEqual(t, src.Int63(), int64(0))
+	assert.NotEqual(t, src.Uint64(), src.Uint64())
+
+	kn := KNum.WithSource(src)
+	for i := 0; i < 100; i++ {
+		res := kn.RandInt(-5, 5)
+		assert.True(t, res >= -5 && res < 5)
+	}
+}
+
+func BenchmarkRandom_NewSecureSource(b *testing.B) {
+	kn := KNum.WithSource(NewSecureSource())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		kn.RandInt64(0, 100)
+	}
+}
+
+func TestRandom_NewSeededSource(t *testing.T) {
+	kn1 := KNum.WithSource(NewSeededSource(42))
+	kn2 := KNum.WithSource(NewSeededSource(42))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, kn1.RandInt(0, 1000), kn2.RandInt(0, 1000))
+		assert.Equal(t, kn1.RandInt64(-1000, 1000), kn2.RandInt64(-1000, 1000))
+		assert.Equal(t, kn1.RandFloat64(0, 1), kn2.RandFloat64(0, 1))
+	}
+
+	//重新设置种子
+	src := NewSeededSource(7)
+	first := src.Int63()
+	src.Seed(7)
+	assert.Equal(t, first, src.Int63())
+	assert.NotEqual(t, src.Uint64(), src.Uint64())
+}
+
+func BenchmarkRandom_NewSeededSource(b *testing.B) {
+	kn := KNum.WithSource(NewSeededSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		kn.RandInt64(0, 100)
+	}
+}
+
+func TestRandom_WithSource(t *testing.T) {
+	assert.Equal(t, defaultRandSource, KNum.Source())
+	assert.Equal(t, defaultRandSource, KNum.WithSource(nil).Source())
+	assert.Equal(t, defaultRandSource, KArr.Source())
+
+	arr := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	res1 := KArr.WithSource(NewSeededSource(3)).ArrayShuffle(arr)
+	res2 := KArr.WithSource(NewSeededSource(3)).ArrayShuffle(arr)
+	assert.Equal(t, res1, res2)
+
+	res1 = KArr.WithSource(NewSeededSource(5)).ArrayRand(arr, 3)
+	res2 = KArr.WithSource(NewSeededSource(5)).ArrayRand(arr, 3)
+	assert.Equal(t, res1, res2)
+}
+
+func TestRandom_WeightedChoice(t *testing.T) {
+	var res int
+	var err error
+
+	kn := KNum.WithSource(NewSeededSource(1))
+	counts := make([]int, 3)
+	for i := 0; i < 10000; i++ {
+		res, err = kn.WeightedChoice([]float64{1, 0, 3})
+		assert.Nil(t, err)
+		counts[res]++
+	}
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 2500, counts[0], 200)
+	assert.InDelta(t, 7500, counts[2], 200)
+
+	_, err = KNum.WeightedChoice(nil)
+	assert.Equal(t, ErrEmptyInput, err)
+	_, err = KNum.WeightedChoice([]float64{1, -1})
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.WeightedChoice([]float64{0, 0})
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.WeightedChoice([]float64{math.NaN()})
+	assert.Equal(t, ErrInvalidParam, err)
+}
+
+func BenchmarkRandom_WeightedChoice(b *testing.B) {
+	weights := []float64{1, 2, 3, 4}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.WeightedChoice(weights)
+	}
+}
+
+func TestRandom_RandNormal(t *testing.T) {
+	kn := KNum.WithSource(NewSeededSource(1))
+	var ss StreamStats
+	for i := 0; i < 20000; i++ {
+		ss.Add(kn.RandNormal(10, 2))
+	}
+	assert.InDelta(t, 10, ss.Mean(), 0.1)
+	assert.InDelta(t, 2, ss.StdDev(true), 0.1)
+}
+
+func BenchmarkRandom_RandNormal(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.RandNormal(0, 1)
+	}
+}
+
+func TestRandom_RandExponential(t *testing.T) {
+	kn := KNum.WithSource(NewSeededSource(1))
+	var ss StreamStats
+	for i := 0; i < 20000; i++ {
+		ss.Add(kn.RandExponential(4))
+	}
+	assert.InDelta(t, 0.25, ss.Mean(), 0.01)
+	assert.GreaterOrEqual(t, ss.Min(), 0.0)
+	assert.Panics(t, func() { KNum.RandExponential(math.NaN()) })
+	assert.Panics(t, func() { KNum.RandExponential(math.Inf(1)) })
+
+	defer func() {
+		r := recover()
+		assert.NotEmpty(t, r)
+	}()
+	KNum.RandExponential(0)
+}
+
+func BenchmarkRandom_RandExponential(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KNum.RandExponential(1)
+	}
+}
+
+func TestRandom_Reservoir(t *testing.T) {
+	rs := KNum.WithSource(NewSeededSource(1)).NewReservoir(3)
+	rs.Add("a")
+	rs.Add("b")
+	assert.Equal(t, []interface{}{"a", "b"}, rs.Items())
+
+	//每个元素被选中的概率应接近k/n
+	counts := make([]int, 10)
+	for i := 0; i < 10000; i++ {
+		rs = KNum.WithSource(NewSeededSource(int64(i))).NewReservoir(3)
+		for j := 0; j < 10; j++ {
+			rs.Add(j)
+		}
+		assert.Equal(t, int64(10), rs.Count())
+		for _, v := range rs.Items() {
+			counts[v.(int)]++
+		}
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 3000, c, 200)
+	}
+
+	defer func() {
+		r := recover()
+		assert.NotEmpty(t, r)
+	}()
+	KNum.NewReservoir(0)
+}
+
+func BenchmarkRandom_Reservoir(b *testing.B) {
+	rs := KNum.NewReservoir(10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rs.Add(i)
+	}
+}
@@ -0,0 +1,351 @@
+package kgo
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+)
+
+// XxxE 为对应方法 Xxx 的错误返回版本:参数不合法时不再panic,而是返回可用errors.Is判断的错误,
+// 如 ErrEmptyInput 、 ErrInvalidParam 、 ErrNumberOverflow 、 ErrUnsupportedType .
+
+// safeCall 执行fn,将其中参数校验的panic转换为包装了sentinel的错误;
+// 越界、空指针等运行时错误(runtime.Error)属于程序缺陷,将继续panic.
+func safeCall(sentinel error, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if re, ok := r.(runtime.Error); ok {
+				panic(re)
+			}
+			err = fmt.Errorf("%v: %w", r, sentinel)
+		}
+	}()
+
+	fn()
+	return
+}
+
+// MaxIntE 整数序列求最大值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MaxIntE(nums ...int) (int, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.MaxInt(nums...), nil
+}
+
+// MaxFloat64E 64位浮点数序列求最大值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MaxFloat64E(nums ...float64) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.MaxFloat64(nums...), nil
+}
+
+// MaxE 取出任意类型中数值类型的最大值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MaxE(nums ...interface{}) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.Max(nums...), nil
+}
+
+// MinIntE 整数序列求最小值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MinIntE(nums ...int) (int, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.MinInt(nums...), nil
+}
+
+// MinFloat64E 64位浮点数序列求最小值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MinFloat64E(nums ...float64) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.MinFloat64(nums...), nil
+}
+
+// MinE 取出任意类型中数值类型的最小值;nums为空时返回 ErrEmptyInput .
+func (kn *LkkNumber) MinE(nums ...interface{}) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return kn.Min(nums...), nil
+}
+
+// NearLogarithmE 求以 base 为底 num 的对数临近值;num须为正整数,base须为大于1的整数,否则返回 ErrInvalidParam .
+func (kn *LkkNumber) NearLogarithmE(num, base int, left bool) (int, error) {
+	if num < 1 || base < 2 {
+		return 0, fmt.Errorf("[NearLogarithmE]`num %d, base %d: %w", num, base, ErrInvalidParam)
+	}
+	return kn.NearLogarithm(num, base, left), nil
+}
+
+// SplitNaturalNumE 将自然数 num 按底数 base 进行拆解;num须为自然数,base须为大于1的整数,否则返回 ErrInvalidParam .
+func (kn *LkkNumber) SplitNaturalNumE(num, base int) ([]int, error) {
+	if num < 0 || base < 2 {
+		return nil, fmt.Errorf("[SplitNaturalNumE]`num %d, base %d: %w", num, base, ErrInvalidParam)
+	}
+	return kn.SplitNaturalNum(num, base), nil
+}
+
+// RandInt64E 生成一个[min, max)范围内的随机int64整数;
+// 与 RandInt64 不同,范围过大时不会被截断为int32范围,而是在max-min超出int64时返回 ErrNumberOverflow .
+func (kn *LkkNumber) RandInt64E(min, max int64) (int64, error) {
+	if min > max {
+		min, max = max, min
+	} else if min == max {
+		return min, nil
+	}
+
+	span, err := kn.SubInt64(max, min)
+	if err != nil {
+		return 0, fmt.Errorf("[RandInt64E]`range %d~%d: %w", min, max, ErrNumberOverflow)
+	}
+	return kn.rnd().Int63n(span) + min, nil
+}
+
+// RandIntE 生成一个[min, max)范围内的随机int整数;max-min超出int64时返回 ErrNumberOverflow .
+func (kn *LkkNumber) RandIntE(min, max int) (int, error) {
+	res, err := kn.RandInt64E(int64(min), int64(max))
+	if err != nil {
+		return 0, err
+	}
+	return int(res), nil
+}
+
+// RandExponentialE 生成指数分布的随机数;rate不大于0时返回 ErrInvalidParam .
+func (kn *LkkNumber) RandExponentialE(rate float64) (float64, error) {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		return 0, ErrInvalidParam
+	}
+	return kn.RandExponential(rate), nil
+}
+
+// NewReservoirE 创建容量为k的蓄水池抽样器;k小于1时返回 ErrInvalidParam .
+func (kn *LkkNumber) NewReservoirE(k int) (*Reservoir, error) {
+	if k < 1 {
+		return nil, ErrInvalidParam
+	}
+	return kn.NewReservoir(k), nil
+}
+
+// ArrayKeysE 返回数组(切片/字典/结构体)中所有的键名;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayKeysE(arr interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayKeys(arr)
+	})
+	return
+}
+
+// ArrayValuesE 返回arr(数组/切片/字典/结构体)中所有的值;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayValuesE(arr interface{}, filterZero bool) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayValues(arr, filterZero)
+	})
+	return
+}
+
+// ArrayChunkE 将一个数组/切片分割成多个;size小于1时返回 ErrInvalidParam ,类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayChunkE(arr interface{}, size int) (res [][]interface{}, err error) {
+	if size < 1 {
+		return nil, fmt.Errorf("[ArrayChunkE]`size %d: %w", size, ErrInvalidParam)
+	}
+
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayChunk(arr, size)
+	})
+	return
+}
+
+// ArrayColumnE 返回数组(切片/字典/结构体)中元素指定的一列;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayColumnE(arr interface{}, columnKey string) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayColumn(arr, columnKey)
+	})
+	return
+}
+
+// ArrayKeyExistsE 检查arr(数组/切片/字典/结构体)里是否有key指定的键名;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayKeyExistsE(key interface{}, arr interface{}) (res bool, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayKeyExists(key, arr)
+	})
+	return
+}
+
+// ArrayReverseE 返回单元顺序相反的数组;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayReverseE(arr interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayReverse(arr)
+	})
+	return
+}
+
+// ImplodeE 用delimiter将数组(数组/切片/字典/结构体)的值连接为一个字符串;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ImplodeE(delimiter string, arr interface{}) (res string, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.Implode(delimiter, arr)
+	})
+	return
+}
+
+// ArrayDiffE 计算数组(数组/切片/字典)的差集;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayDiffE(arr1, arr2 interface{}, compareType LkkArrCompareType) (res map[interface{}]interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayDiff(arr1, arr2, compareType)
+	})
+	return
+}
+
+// ArrayIntersectE 计算数组(数组/切片/字典)的交集;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayIntersectE(arr1, arr2 interface{}, compareType LkkArrCompareType) (res map[interface{}]interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayIntersect(arr1, arr2, compareType)
+	})
+	return
+}
+
+// ArrayUniqueE 移除数组(切片/字典)中重复的值;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayUniqueE(arr interface{}) (res map[interface{}]interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayUnique(arr)
+	})
+	return
+}
+
+// ArraySearchItemE 从数组(切片/字典)中搜索对应元素(单个);类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArraySearchItemE(arr interface{}, condition map[string]interface{}) (res interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArraySearchItem(arr, condition)
+	})
+	return
+}
+
+// ArraySearchMutilE 从数组(切片/字典)中搜索对应元素(多个);类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArraySearchMutilE(arr interface{}, condition map[string]interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArraySearchMutil(arr, condition)
+	})
+	return
+}
+
+// ArrayShuffleE 打乱数组/切片排序;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayShuffleE(arr interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayShuffle(arr)
+	})
+	return
+}
+
+// IsEqualArrayE 两个数组/切片是否相同(不管元素顺序);类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) IsEqualArrayE(arr1, arr2 interface{}) (res bool, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.IsEqualArray(arr1, arr2)
+	})
+	return
+}
+
+// IsEqualMapE 两个字典是否相同;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) IsEqualMapE(arr1, arr2 interface{}) (res bool, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.IsEqualMap(arr1, arr2)
+	})
+	return
+}
+
+// DeleteSliceItemsE 删除数组/切片的元素;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) DeleteSliceItemsE(val interface{}, ids ...int) (res []interface{}, del int, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res, del = ka.DeleteSliceItems(val, ids...)
+	})
+	return
+}
+
+// InArrayE 元素needle是否在数组haystack(切片/字典)内;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) InArrayE(needle interface{}, haystack interface{}) (res bool, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.InArray(needle, haystack)
+	})
+	return
+}
+
+// ArrayFlipE 交换数组(切片/字典)中的键和值;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayFlipE(arr interface{}) (res map[interface{}]interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayFlip(arr)
+	})
+	return
+}
+
+// MergeSliceE 合并一个或多个数组/切片;ss中有非数组/切片时返回 ErrUnsupportedType .
+func (ka *LkkArray) MergeSliceE(filterZero bool, ss ...interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.MergeSlice(filterZero, ss...)
+	})
+	return
+}
+
+// MergeMapE 合并字典;ss中有非字典时返回 ErrUnsupportedType .
+func (ka *LkkArray) MergeMapE(ss ...interface{}) (res map[interface{}]interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.MergeMap(ss...)
+	})
+	return
+}
+
+// ArrayPadE 以指定长度将一个值item填充进arr数组/切片;类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayPadE(arr interface{}, size int, item interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayPad(arr, size, item)
+	})
+	return
+}
+
+// ArrayRandE 从数组(切片/字典)中随机取出num个元素;num小于1时返回 ErrInvalidParam ,类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) ArrayRandE(arr interface{}, num int) (res []interface{}, err error) {
+	if num < 1 {
+		return nil, fmt.Errorf("[ArrayRandE]`num %d: %w", num, ErrInvalidParam)
+	}
+
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.ArrayRand(arr, num)
+	})
+	return
+}
+
+// CutSliceE 裁剪切片;size小于1时返回 ErrInvalidParam ,类型不支持时返回 ErrUnsupportedType .
+func (ka *LkkArray) CutSliceE(arr interface{}, offset, size int) (res []interface{}, err error) {
+	if size < 1 {
+		return nil, fmt.Errorf("[CutSliceE]`size %d: %w", size, ErrInvalidParam)
+	}
+
+	err = safeCall(ErrUnsupportedType, func() {
+		res = ka.CutSlice(arr, offset, size)
+	})
+	return
+}
+
+// Byte2Float64E 字节切片转64位浮点数;长度不足8时返回 ErrInvalidParam .
+func (kc *LkkConvert) Byte2Float64E(bytes []byte) (float64, error) {
+	if len(bytes) < 8 {
+		return 0, fmt.Errorf("[Byte2Float64E]`bytes length %d: %w", len(bytes), ErrInvalidParam)
+	}
+	return kc.Byte2Float64(bytes), nil
+}
+
+// Byte2Int64E 字节切片转64位整型;长度不足8时返回 ErrInvalidParam .
+func (kc *LkkConvert) Byte2Int64E(val []byte) (int64, error) {
+	if len(val) < 8 {
+		return 0, fmt.Errorf("[Byte2Int64E]`bytes length %d: %w", len(val), ErrInvalidParam)
+	}
+	return kc.Byte2Int64(val), nil
+}
+
+// ToInterfacesE 强制将变量转为接口切片;val类型不是数组/切片/字典/结构体时返回 ErrUnsupportedType .
+func (kc *LkkConvert) ToInterfacesE(val interface{}) (res []interface{}, err error) {
+	err = safeCall(ErrUnsupportedType, func() {
+		res = kc.ToInterfaces(val)
+	})
+	return
+}
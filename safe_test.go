@@ -0,0 +1,300 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafe_MaxMinE(t *testing.T) {
+	var resi int
+	var resf float64
+	var err error
+
+	resi, err = KNum.MaxIntE(1, 3, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, resi)
+	resi, err = KNum.MinIntE(1, 3, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, resi)
+
+	resf, err = KNum.MaxFloat64E(1.5, -2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, resf)
+	resf, err = KNum.MinFloat64E(1.5, -2)
+	assert.Nil(t, err)
+	assert.Equal(t, -2.0, resf)
+
+	resf, err = KNum.MaxE(1, "4", 2.5, "hello")
+	assert.Nil(t, err)
+	assert.Equal(t, 4.0, resf)
+	resf, err = KNum.MinE(1, "4", -2.5)
+	assert.Nil(t, err)
+	assert.Equal(t, -2.5, resf)
+
+	_, err = KNum.MaxIntE()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = KNum.MaxFloat64E()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = KNum.MaxE()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = KNum.MinIntE()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = KNum.MinFloat64E()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+	_, err = KNum.MinE()
+	assert.True(t, errors.Is(err, ErrEmptyInput))
+}
+
+func BenchmarkSafe_MaxE(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.MaxE(1, 2.5, "3")
+	}
+}
+
+func TestSafe_NearLogarithmE(t *testing.T) {
+	var res int
+	var err error
+
+	res, err = KNum.NearLogarithmE(100, 10, true)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, res)
+	res, err = KNum.NearLogarithmE(101, 10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, res)
+
+	_, err = KNum.NearLogarithmE(-1, 10, true)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KNum.NearLogarithmE(0, 10, true)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KNum.NearLogarithmE(10, 1, true)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func TestSafe_SplitNaturalNumE(t *testing.T) {
+	var res []int
+	var err error
+
+	res, err = KNum.SplitNaturalNumE(56, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, KNum.SplitNaturalNum(56, 3), res)
+
+	res, err = KNum.SplitNaturalNumE(0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0}, res)
+
+	_, err = KNum.SplitNaturalNumE(-3, 2)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = KNum.SplitNaturalNumE(10, 0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func TestSafe_RandIntE(t *testing.T) {
+	var res int64
+	var err error
+
+	kn := KNum.WithSource(NewSeededSource(1))
+	for i := 0; i < 100; i++ {
+		res, err = kn.RandInt64E(10, -10)
+		assert.Nil(t, err)
+		assert.True(t, res >= -10 && res < 10)
+	}
+
+	//大范围不截断
+	res, err = kn.RandInt64E(1<<40, 1<<41)
+	assert.Nil(t, err)
+	assert.True(t, res >= 1<<40 && res < 1<<41)
+
+	res, err = kn.RandInt64E(5, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), res)
+
+	_, err = kn.RandInt64E(math.MinInt64, 0)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	resi, err := kn.RandIntE(-3, 3)
+	assert.Nil(t, err)
+	assert.True(t, resi >= -3 && resi < 3)
+	_, err = kn.RandIntE(math.MinInt64, math.MaxInt64)
+	assert.True(t, errors.Is(err, ErrNumberOverflow))
+
+	_, err = kn.RandExponentialE(1)
+	assert.Nil(t, err)
+	_, err = kn.RandExponentialE(0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+	_, err = kn.RandExponentialE(math.NaN())
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	rs, err := kn.NewReservoirE(2)
+	assert.Nil(t, err)
+	assert.NotNil(t, rs)
+	_, err = kn.NewReservoirE(0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+}
+
+func BenchmarkSafe_RandInt64E(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.RandInt64E(0, 100)
+	}
+}
+
+func TestSafe_ArrayE(t *testing.T) {
+	var err error
+
+	arr := []int{1, 2, 3, 2}
+	mp := map[string]int{"a": 1, "b": 2}
+
+	keys, err := KArr.ArrayKeysE(arr)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, keys)
+
+	chunks, err := KArr.ArrayChunkE(arr, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]interface{}{{1, 2, 3}, {2}}, chunks)
+	_, err = KArr.ArrayChunkE(arr, 0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	rev, err := KArr.ArrayReverseE(arr)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 3, 2, 1}, rev)
+
+	str, err := KArr.ImplodeE(",", arr)
+	assert.Nil(t, err)
+	assert.Equal(t, "1,2,3,2", str)
+
+	ok, err := KArr.InArrayE(3, arr)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = KArr.IsEqualMapE(mp, map[string]int{"b": 2, "a": 1})
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	res, del, err := KArr.DeleteSliceItemsE(arr, 0, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, del)
+	assert.Equal(t, []interface{}{3, 2}, res)
+
+	cut, err := KArr.CutSliceE(arr, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 3}, cut)
+	_, err = KArr.CutSliceE(arr, 1, 0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	rnd, err := KArr.ArrayRandE(arr, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(rnd))
+	_, err = KArr.ArrayRandE(arr, 0)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	//类型不支持
+	bad := 123
+	errs := []error{}
+	appendErr := func(err error) {
+		errs = append(errs, err)
+	}
+	_, err = KArr.ArrayKeysE(bad)
+	appendErr(err)
+	_, err = KArr.ArrayValuesE(bad, false)
+	appendErr(err)
+	_, err = KArr.ArrayChunkE(bad, 2)
+	appendErr(err)
+	_, err = KArr.ArrayColumnE(bad, "a")
+	appendErr(err)
+	_, err = KArr.ArrayKeyExistsE(1, bad)
+	appendErr(err)
+	_, err = KArr.ArrayReverseE(bad)
+	appendErr(err)
+	_, err = KArr.ImplodeE(",", bad)
+	appendErr(err)
+	_, err = KArr.ArrayDiffE(bad, arr, COMPARE_ONLY_VALUE)
+	appendErr(err)
+	_, err = KArr.ArrayIntersectE(arr, bad, COMPARE_ONLY_VALUE)
+	appendErr(err)
+	_, err = KArr.ArrayUniqueE(bad)
+	appendErr(err)
+	_, err = KArr.ArraySearchItemE(bad, map[string]interface{}{"a": 1})
+	appendErr(err)
+	_, err = KArr.ArraySearchMutilE(bad, map[string]interface{}{"a": 1})
+	appendErr(err)
+	_, err = KArr.ArrayShuffleE(bad)
+	appendErr(err)
+	_, err = KArr.IsEqualArrayE(bad, arr)
+	appendErr(err)
+	_, err = KArr.IsEqualMapE(bad, mp)
+	appendErr(err)
+	_, _, err = KArr.DeleteSliceItemsE(bad, 1)
+	appendErr(err)
+	_, err = KArr.InArrayE(1, bad)
+	appendErr(err)
+	_, err = KArr.ArrayFlipE(bad)
+	appendErr(err)
+	_, err = KArr.MergeSliceE(false, arr, bad)
+	appendErr(err)
+	_, err = KArr.MergeMapE(mp, bad)
+	appendErr(err)
+	_, err = KArr.ArrayPadE(bad, 5, 0)
+	appendErr(err)
+	_, err = KArr.ArrayRandE(bad, 1)
+	appendErr(err)
+	_, err = KArr.CutSliceE(bad, 0, 1)
+	appendErr(err)
+	for i, e := range errs {
+		assert.True(t, errors.Is(e, ErrUnsupportedType), i)
+	}
+
+	//运行时错误不转换为ErrUnsupportedType
+	assert.Panics(t, func() {
+		var m map[string]int
+		_ = safeCall(ErrUnsupportedType, func() {
+			m["a"] = 1
+		})
+	})
+	assert.Panics(t, func() {
+		_ = safeCall(ErrUnsupportedType, func() {
+			_ = arr[len(arr)+mp["x"]]
+		})
+	})
+}
+
+func BenchmarkSafe_ArrayChunkE(b *testing.B) {
+	arr := []int{1, 2, 3, 4, 5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KArr.ArrayChunkE(arr, 2)
+	}
+}
+
+func TestSafe_ConvertE(t *testing.T) {
+	var err error
+
+	f, err := KConv.Byte2Float64E(KConv.Float64ToByte(1.5))
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, f)
+	_, err = KConv.Byte2Float64E([]byte{1, 2})
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	i, err := KConv.Byte2Int64E(KConv.Int64ToByte(-7))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-7), i)
+	_, err = KConv.Byte2Int64E(nil)
+	assert.True(t, errors.Is(err, ErrInvalidParam))
+
+	res, err := KConv.ToInterfacesE([]string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a"}, res)
+	_, err = KConv.ToInterfacesE("a")
+	assert.True(t, errors.Is(err, ErrUnsupportedType))
+}
+
+func BenchmarkSafe_ToInterfacesE(b *testing.B) {
+	arr := []int{1, 2, 3}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KConv.ToInterfacesE(arr)
+	}
+}
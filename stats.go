@@ -0,0 +1,435 @@
+package kgo
+
+import (
+	"math"
+	"sort"
+)
+
+// HistogramBin 直方图的分组
+type HistogramBin struct {
+	Min   float64 `json:"min"`   //下界(包含)
+	Max   float64 `json:"max"`   //上界(不包含,最后一组包含)
+	Count int     `json:"count"` //数量
+}
+
+// StreamStats 流式统计累加器,使用Welford算法计算均值和方差,无需保存全部数据.
+type StreamStats struct {
+	count int64
+	mean  float64
+	m2    float64
+	min   float64
+	max   float64
+	sum   float64
+}
+
+// P2Quantile 流式分位数估算器,使用P²算法,仅需常数内存.
+type P2Quantile struct {
+	p     float64
+	count int
+	q     [5]float64 //标记高度
+	n     [5]float64 //标记位置
+	np    [5]float64 //期望位置
+	dn    [5]float64 //期望位置增量
+}
+
+// sortedCopy 获取排序后的副本,不修改原切片.
+func sortedCopy(nums []float64) []float64 {
+	res := make([]float64, len(nums))
+	copy(res, nums)
+	sort.Float64s(res)
+	return res
+}
+
+// quantileSorted 计算已排序数据的分位数,q为0~1.
+func quantileSorted(sorted []float64, q float64, method LkkQuantileMethod) float64 {
+	h := float64(len(sorted)-1) * q
+	lo, hi := math.Floor(h), math.Ceil(h)
+	vlo, vhi := sorted[int(lo)], sorted[int(hi)]
+
+	switch method {
+	case QUANTILE_LOWER:
+		return vlo
+	case QUANTILE_HIGHER:
+		return vhi
+	case QUANTILE_NEAREST:
+		return sorted[int(math.Round(h))]
+	case QUANTILE_MIDPOINT:
+		return (vlo + vhi) / 2
+	default:
+		return vlo + (h-lo)*(vhi-vlo)
+	}
+}
+
+// ranks 获取数据的秩,相同值取平均秩.
+func ranks(nums []float64) []float64 {
+	length := len(nums)
+	idx := make([]int, length)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return nums[idx[i]] < nums[idx[j]]
+	})
+
+	res := make([]float64, length)
+	for i := 0; i < length; {
+		j := i
+		for j+1 < length && nums[idx[j+1]] == nums[idx[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			res[idx[k]] = rank
+		}
+		i = j + 1
+	}
+
+	return res
+}
+
+// Median 求中位数.
+func (kn *LkkNumber) Median(nums ...float64) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	}
+	return quantileSorted(sortedCopy(nums), 0.5, QUANTILE_MIDPOINT), nil
+}
+
+// Mode 求众数;有多个出现次数相同的众数时全部返回(升序);nums中有NaN或无穷大时返回 ErrInvalidParam .
+func (kn *LkkNumber) Mode(nums ...float64) ([]float64, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyInput
+	}
+	for _, v := range nums {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, ErrInvalidParam
+		}
+	}
+
+	sorted := sortedCopy(nums)
+	var res []float64
+	var maxCount int
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		if count := j - i; count > maxCount {
+			maxCount = count
+			res = []float64{sorted[i]}
+		} else if count == maxCount {
+			res = append(res, sorted[i])
+		}
+		i = j
+	}
+
+	return res, nil
+}
+
+// Variance 求方差;sample为true时求样本方差(除以n-1),否则求总体方差(除以n).
+func (kn *LkkNumber) Variance(nums []float64, sample bool) (float64, error) {
+	var ss StreamStats
+	for _, v := range nums {
+		ss.Add(v)
+	}
+	return ss.variance(sample)
+}
+
+// StdDev 求标准差;sample为true时求样本标准差,否则求总体标准差.
+func (kn *LkkNumber) StdDev(nums []float64, sample bool) (float64, error) {
+	res, err := kn.Variance(nums, sample)
+	return math.Sqrt(res), err
+}
+
+// Quantile 求分位数,q为0~1,method为插值方法.
+func (kn *LkkNumber) Quantile(nums []float64, q float64, method LkkQuantileMethod) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmptyInput
+	} else if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, ErrInvalidParam
+	}
+	return quantileSorted(sortedCopy(nums), q, method), nil
+}
+
+// Percentile 求百分位数,p为0~100,method为插值方法.
+func (kn *LkkNumber) Percentile(nums []float64, p float64, method LkkQuantileMethod) (float64, error) {
+	return kn.Quantile(nums, p/100, method)
+}
+
+// Histogram 将数据按等宽分为bins组,统计每组的数量;nums中有NaN或无穷大时返回 ErrInvalidParam .
+func (kn *LkkNumber) Histogram(nums []float64, bins int) ([]HistogramBin, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmptyInput
+	} else if bins < 1 {
+		return nil, ErrInvalidParam
+	}
+
+	min, max := nums[0], nums[0]
+	for _, v := range nums {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, ErrInvalidParam
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+
+	res := make([]HistogramBin, bins)
+	width := (max - min) / float64(bins)
+	for i := range res {
+		res[i].Min = min + float64(i)*width
+		res[i].Max = min + float64(i+1)*width
+	}
+	res[bins-1].Max = max
+
+	for _, v := range nums {
+		i := bins - 1
+		if width > 0 {
+			//差值溢出时f可能为NaN,按第一组处理
+			if f := (v - min) / width; !(f >= 0) {
+				i = 0
+			} else if f < float64(bins) {
+				i = int(f)
+			}
+		}
+		res[i].Count++
+	}
+
+	return res, nil
+}
+
+// Pearson 求两组数据的皮尔逊相关系数.
+func (kn *LkkNumber) Pearson(x, y []float64) (float64, error) {
+	if len(x) != len(y) {
+		return 0, ErrLengthMismatch
+	} else if len(x) < 2 {
+		return 0, ErrEmptyInput
+	}
+
+	mx, my := kn.AverageFloat64(x...), kn.AverageFloat64(y...)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+
+	if sxx == 0 || syy == 0 {
+		return 0, ErrInvalidParam
+	}
+	return sxy / math.Sqrt(sxx*syy), nil
+}
+
+// Spearman 求两组数据的斯皮尔曼等级相关系数,相同值取平均秩.
+func (kn *LkkNumber) Spearman(x, y []float64) (float64, error) {
+	if len(x) != len(y) {
+		return 0, ErrLengthMismatch
+	}
+	return kn.Pearson(ranks(x), ranks(y))
+}
+
+// LinearRegression 最小二乘法一元线性回归 y = slope*x + intercept,r2为决定系数.
+func (kn *LkkNumber) LinearRegression(x, y []float64) (slope, intercept, r2 float64, err error) {
+	if len(x) != len(y) {
+		err = ErrLengthMismatch
+		return
+	} else if len(x) < 2 {
+		err = ErrEmptyInput
+		return
+	}
+
+	mx, my := kn.AverageFloat64(x...), kn.AverageFloat64(y...)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+
+	if sxx == 0 {
+		err = ErrInvalidParam
+		return
+	}
+
+	slope = sxy / sxx
+	intercept = my - slope*mx
+	if syy == 0 {
+		r2 = 1
+	} else {
+		r2 = sxy * sxy / (sxx * syy)
+	}
+
+	return
+}
+
+// ZScores 求每个数据的标准分数(z-score);sample为是否使用样本标准差.
+func (kn *LkkNumber) ZScores(nums []float64, sample bool) ([]float64, error) {
+	sd, err := kn.StdDev(nums, sample)
+	if err != nil {
+		return nil, err
+	} else if sd == 0 {
+		return nil, ErrInvalidParam
+	}
+
+	mean := kn.AverageFloat64(nums...)
+	res := make([]float64, len(nums))
+	for i, v := range nums {
+		res[i] = (v - mean) / sd
+	}
+
+	return res, nil
+}
+
+// Add 添加一个数据.
+func (ss *StreamStats) Add(x float64) {
+	ss.count++
+	if ss.count == 1 {
+		ss.min, ss.max = x, x
+	} else {
+		ss.min = math.Min(ss.min, x)
+		ss.max = math.Max(ss.max, x)
+	}
+
+	delta := x - ss.mean
+	ss.mean += delta / float64(ss.count)
+	ss.m2 += delta * (x - ss.mean)
+	ss.sum += x
+}
+
+// Count 获取数据数量.
+func (ss *StreamStats) Count() int64 {
+	return ss.count
+}
+
+// Sum 获取数据之和.
+func (ss *StreamStats) Sum() float64 {
+	return ss.sum
+}
+
+// Mean 获取均值,无数据时为0.
+func (ss *StreamStats) Mean() float64 {
+	return ss.mean
+}
+
+// Min 获取最小值,无数据时为0.
+func (ss *StreamStats) Min() float64 {
+	return ss.min
+}
+
+// Max 获取最大值,无数据时为0.
+func (ss *StreamStats) Max() float64 {
+	return ss.max
+}
+
+// variance 计算方差.
+func (ss *StreamStats) variance(sample bool) (float64, error) {
+	if ss.count == 0 || (sample && ss.count < 2) {
+		return 0, ErrEmptyInput
+	}
+
+	if sample {
+		return ss.m2 / float64(ss.count-1), nil
+	}
+	return ss.m2 / float64(ss.count), nil
+}
+
+// Variance 获取方差;sample为是否样本方差,数据不足时为0.
+func (ss *StreamStats) Variance(sample bool) float64 {
+	res, _ := ss.variance(sample)
+	return res
+}
+
+// StdDev 获取标准差;sample为是否样本标准差,数据不足时为0.
+func (ss *StreamStats) StdDev(sample bool) float64 {
+	return math.Sqrt(ss.Variance(sample))
+}
+
+// NewP2Quantile 创建P²流式分位数估算器,q为0~1,如0.5为中位数,0.99为P99.
+func NewP2Quantile(q float64) (*P2Quantile, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return nil, ErrInvalidParam
+	}
+
+	return &P2Quantile{
+		p:  q,
+		n:  [5]float64{1, 2, 3, 4, 5},
+		np: [5]float64{1, 1 + 2*q, 1 + 4*q, 3 + 2*q, 5},
+		dn: [5]float64{0, q / 2, q, (1 + q) / 2, 1},
+	}, nil
+}
+
+// Add 添加一个数据.
+func (pq *P2Quantile) Add(x float64) {
+	if pq.count < 5 {
+		pq.q[pq.count] = x
+		pq.count++
+		if pq.count == 5 {
+			sort.Float64s(pq.q[:])
+		}
+		return
+	}
+	pq.count++
+
+	//定位x所在的区间,并更新极值
+	var k int
+	switch {
+	case x < pq.q[0]:
+		pq.q[0] = x
+		k = 0
+	case x >= pq.q[4]:
+		pq.q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3 && x >= pq.q[k+1]; k++ {
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		pq.n[i]++
+	}
+	for i := range pq.np {
+		pq.np[i] += pq.dn[i]
+	}
+
+	//调整中间3个标记
+	for i := 1; i < 4; i++ {
+		d := pq.np[i] - pq.n[i]
+		if (d >= 1 && pq.n[i+1]-pq.n[i] > 1) || (d <= -1 && pq.n[i-1]-pq.n[i] < -1) {
+			ds := 1.0
+			if d < 0 {
+				ds = -1.0
+			}
+
+			qp := pq.parabolic(i, ds)
+			if pq.q[i-1] < qp && qp < pq.q[i+1] {
+				pq.q[i] = qp
+			} else {
+				j := i + int(ds)
+				pq.q[i] += ds * (pq.q[j] - pq.q[i]) / (pq.n[j] - pq.n[i])
+			}
+			pq.n[i] += ds
+		}
+	}
+}
+
+// parabolic P²的抛物线插值.
+func (pq *P2Quantile) parabolic(i int, d float64) float64 {
+	q, n := pq.q, pq.n
+	return q[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// Count 获取数据数量.
+func (pq *P2Quantile) Count() int {
+	return pq.count
+}
+
+// Value 获取分位数的估算值;数据少于5个时精确计算,无数据时为0.
+func (pq *P2Quantile) Value() float64 {
+	if pq.count == 0 {
+		return 0
+	} else if pq.count < 5 {
+		return quantileSorted(sortedCopy(pq.q[:pq.count]), pq.p, QUANTILE_LINEAR)
+	}
+	return pq.q[2]
+}
@@ -0,0 +1,358 @@
+package kgo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var statsNums = []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+func TestStats_Median(t *testing.T) {
+	var res float64
+	var err error
+
+	res, err = KNum.Median(statsNums...)
+	assert.Nil(t, err)
+	assert.Equal(t, 4.5, res)
+
+	res, err = KNum.Median(3, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, res)
+
+	_, err = KNum.Median()
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkStats_Median(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Median(statsNums...)
+	}
+}
+
+func TestStats_Mode(t *testing.T) {
+	var res []float64
+	var err error
+
+	res, err = KNum.Mode(statsNums...)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{4}, res)
+
+	res, err = KNum.Mode(3, 1, 3, 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1, 3}, res)
+
+	_, err = KNum.Mode()
+	assert.Equal(t, ErrEmptyInput, err)
+	_, err = KNum.Mode(1, math.NaN(), 2)
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.Mode(1, math.Inf(-1))
+	assert.Equal(t, ErrInvalidParam, err)
+}
+
+func BenchmarkStats_Mode(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Mode(statsNums...)
+	}
+}
+
+func TestStats_Variance_StdDev(t *testing.T) {
+	var res float64
+	var err error
+
+	res, err = KNum.Variance(statsNums, false)
+	assert.Nil(t, err)
+	assert.InDelta(t, 4.0, res, 1e-12)
+
+	res, err = KNum.StdDev(statsNums, false)
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0, res, 1e-12)
+
+	res, err = KNum.Variance(statsNums, true)
+	assert.Nil(t, err)
+	assert.InDelta(t, 32.0/7, res, 1e-12)
+
+	_, err = KNum.Variance([]float64{1}, true)
+	assert.Equal(t, ErrEmptyInput, err)
+
+	_, err = KNum.StdDev(nil, false)
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkStats_StdDev(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.StdDev(statsNums, true)
+	}
+}
+
+func TestStats_Quantile(t *testing.T) {
+	var res float64
+	var err error
+	nums := []float64{1, 2, 3, 4}
+
+	tests := []struct {
+		method LkkQuantileMethod
+		expect float64
+	}{
+		{QUANTILE_LINEAR, 1.75},
+		{QUANTILE_LOWER, 1},
+		{QUANTILE_HIGHER, 2},
+		{QUANTILE_NEAREST, 2},
+		{QUANTILE_MIDPOINT, 1.5},
+	}
+	for _, test := range tests {
+		res, err = KNum.Quantile(nums, 0.25, test.method)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expect, res)
+	}
+
+	res, _ = KNum.Quantile(nums, 0, QUANTILE_LINEAR)
+	assert.Equal(t, 1.0, res)
+	res, _ = KNum.Quantile(nums, 1, QUANTILE_LINEAR)
+	assert.Equal(t, 4.0, res)
+
+	_, err = KNum.Quantile(nums, 1.5, QUANTILE_LINEAR)
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.Quantile(nil, 0.5, QUANTILE_LINEAR)
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkStats_Quantile(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Quantile(statsNums, 0.9, QUANTILE_LINEAR)
+	}
+}
+
+func TestStats_Percentile(t *testing.T) {
+	res, err := KNum.Percentile([]float64{15, 20, 35, 40, 50}, 40, QUANTILE_LINEAR)
+	assert.Nil(t, err)
+	assert.InDelta(t, 29.0, res, 1e-12)
+
+	_, err = KNum.Percentile(statsNums, -1, QUANTILE_LINEAR)
+	assert.Equal(t, ErrInvalidParam, err)
+}
+
+func BenchmarkStats_Percentile(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Percentile(statsNums, 90, QUANTILE_LINEAR)
+	}
+}
+
+func TestStats_Histogram(t *testing.T) {
+	res, err := KNum.Histogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 10}, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(res))
+	assert.Equal(t, HistogramBin{Min: 0, Max: 2, Count: 2}, res[0])
+	assert.Equal(t, HistogramBin{Min: 8, Max: 10, Count: 2}, res[4])
+
+	var total int
+	for _, bin := range res {
+		total += bin.Count
+	}
+	assert.Equal(t, 10, total)
+
+	//所有值相同
+	res, err = KNum.Histogram([]float64{3, 3, 3}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, res[1].Count)
+
+	_, err = KNum.Histogram(statsNums, 0)
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.Histogram(nil, 3)
+	assert.Equal(t, ErrEmptyInput, err)
+
+	//非有限值
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = KNum.Histogram([]float64{1, v, 2}, 3)
+		assert.Equal(t, ErrInvalidParam, err)
+	}
+
+	//范围溢出时不越界
+	res, err = KNum.Histogram([]float64{-math.MaxFloat64, 0, math.MaxFloat64}, 4)
+	assert.Nil(t, err)
+	total = 0
+	for _, bin := range res {
+		total += bin.Count
+	}
+	assert.Equal(t, 3, total)
+}
+
+func BenchmarkStats_Histogram(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Histogram(statsNums, 4)
+	}
+}
+
+func TestStats_Pearson(t *testing.T) {
+	var res float64
+	var err error
+
+	res, err = KNum.Pearson([]float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.0, res, 1e-12)
+
+	res, err = KNum.Pearson([]float64{1, 2, 3}, []float64{3, 2, 1})
+	assert.Nil(t, err)
+	assert.InDelta(t, -1.0, res, 1e-12)
+
+	_, err = KNum.Pearson([]float64{1, 2}, []float64{1})
+	assert.Equal(t, ErrLengthMismatch, err)
+	_, err = KNum.Pearson([]float64{1, 1}, []float64{1, 2})
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.Pearson(nil, nil)
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkStats_Pearson(b *testing.B) {
+	x, y := []float64{1, 2, 3, 4, 5}, []float64{2, 1, 4, 3, 5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Pearson(x, y)
+	}
+}
+
+func TestStats_Spearman(t *testing.T) {
+	var res float64
+	var err error
+
+	//单调非线性
+	res, err = KNum.Spearman([]float64{1, 2, 3, 4, 5}, []float64{1, 4, 9, 16, 25})
+	assert.Nil(t, err)
+	assert.InDelta(t, 1.0, res, 1e-12)
+
+	//含相同值
+	res, err = KNum.Spearman([]float64{1, 2, 2, 3}, []float64{1, 2, 3, 4})
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.9486832980505138, res, 1e-12)
+
+	_, err = KNum.Spearman([]float64{1}, []float64{1, 2})
+	assert.Equal(t, ErrLengthMismatch, err)
+}
+
+func BenchmarkStats_Spearman(b *testing.B) {
+	x, y := []float64{1, 2, 3, 4, 5}, []float64{2, 1, 4, 3, 5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.Spearman(x, y)
+	}
+}
+
+func TestStats_LinearRegression(t *testing.T) {
+	slope, intercept, r2, err := KNum.LinearRegression([]float64{1, 2, 3, 4}, []float64{3, 5, 7, 9})
+	assert.Nil(t, err)
+	assert.InDelta(t, 2.0, slope, 1e-12)
+	assert.InDelta(t, 1.0, intercept, 1e-12)
+	assert.InDelta(t, 1.0, r2, 1e-12)
+
+	slope, intercept, r2, err = KNum.LinearRegression([]float64{1, 2, 3}, []float64{1, 3, 2})
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.5, slope, 1e-12)
+	assert.InDelta(t, 1.0, intercept, 1e-12)
+	assert.InDelta(t, 0.25, r2, 1e-12)
+
+	_, _, _, err = KNum.LinearRegression([]float64{2, 2}, []float64{1, 3})
+	assert.Equal(t, ErrInvalidParam, err)
+	_, _, _, err = KNum.LinearRegression([]float64{2, 2}, []float64{1})
+	assert.Equal(t, ErrLengthMismatch, err)
+}
+
+func BenchmarkStats_LinearRegression(b *testing.B) {
+	x, y := []float64{1, 2, 3, 4, 5}, []float64{2, 1, 4, 3, 5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _, _ = KNum.LinearRegression(x, y)
+	}
+}
+
+func TestStats_ZScores(t *testing.T) {
+	res, err := KNum.ZScores(statsNums, false)
+	assert.Nil(t, err)
+	assert.InDelta(t, -1.5, res[0], 1e-12)
+	assert.InDelta(t, 2.0, res[7], 1e-12)
+
+	_, err = KNum.ZScores([]float64{2, 2}, false)
+	assert.Equal(t, ErrInvalidParam, err)
+	_, err = KNum.ZScores(nil, false)
+	assert.Equal(t, ErrEmptyInput, err)
+}
+
+func BenchmarkStats_ZScores(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.ZScores(statsNums, false)
+	}
+}
+
+func TestStats_StreamStats(t *testing.T) {
+	var ss StreamStats
+	assert.Equal(t, int64(0), ss.Count())
+	assert.Equal(t, 0.0, ss.Variance(true))
+
+	for _, v := range statsNums {
+		ss.Add(v)
+	}
+	assert.Equal(t, int64(8), ss.Count())
+	assert.Equal(t, 40.0, ss.Sum())
+	assert.Equal(t, 5.0, ss.Mean())
+	assert.Equal(t, 2.0, ss.Min())
+	assert.Equal(t, 9.0, ss.Max())
+	assert.InDelta(t, 4.0, ss.Variance(false), 1e-12)
+	assert.InDelta(t, 2.0, ss.StdDev(false), 1e-12)
+	assert.InDelta(t, 32.0/7, ss.Variance(true), 1e-12)
+}
+
+func BenchmarkStats_StreamStats(b *testing.B) {
+	var ss StreamStats
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ss.Add(float64(i))
+	}
+}
+
+func TestStats_P2Quantile(t *testing.T) {
+	var pq *P2Quantile
+	var err error
+
+	_, err = NewP2Quantile(2)
+	assert.Equal(t, ErrInvalidParam, err)
+
+	pq, err = NewP2Quantile(0.5)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, pq.Value())
+
+	//少于5个时精确计算
+	for _, v := range []float64{4, 1, 3} {
+		pq.Add(v)
+	}
+	assert.Equal(t, 3, pq.Count())
+	assert.Equal(t, 3.0, pq.Value())
+
+	//大量数据时接近真实值
+	rd := rand.New(rand.NewSource(1))
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		pq, _ = NewP2Quantile(q)
+		nums := make([]float64, 10000)
+		for i := range nums {
+			nums[i] = rd.Float64() * 100
+			pq.Add(nums[i])
+		}
+		exact, _ := KNum.Quantile(nums, q, QUANTILE_LINEAR)
+		assert.True(t, math.Abs(pq.Value()-exact) < 1, q)
+	}
+}
+
+func BenchmarkStats_P2Quantile(b *testing.B) {
+	pq, _ := NewP2Quantile(0.99)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Add(float64(i % 1000))
+	}
+}
@@ -2,8 +2,12 @@ package kgo
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // DateFormat pattern rules.
@@ -47,19 +51,69 @@ var datePatterns = []string{
 	"r", time.RFC1123Z,
 }
 
+// durationUnits 格式化时长的单位,从大到小.
+var durationUnits = []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second, time.Millisecond, time.Microsecond, time.Nanosecond}
+
+// durationUnitNames 各风格下的时长单位名称,与durationUnits对应.
+var durationUnitNames = map[LkkDurationStyle][]string{
+	DURATION_SHORT:   {"d", "h", "m", "s", "ms", "µs", "ns"},
+	DURATION_LONG:    {"day", "hour", "minute", "second", "millisecond", "microsecond", "nanosecond"},
+	DURATION_CHINESE: {"天", "小时", "分钟", "秒", "毫秒", "微秒", "纳秒"},
+}
+
+// humanizeUnits 相对时间的单位,从大到小.
+var humanizeUnits = []time.Duration{365 * 24 * time.Hour, 30 * 24 * time.Hour, 7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// humanizeUnitNames 各语言区域下相对时间的单位名称,与humanizeUnits对应.
+var humanizeUnitNames = map[LkkLocale][]string{
+	LOCALE_EN:    {"year", "month", "week", "day", "hour", "minute", "second"},
+	LOCALE_ZH_CN: {"年", "个月", "周", "天", "小时", "分钟", "秒"},
+}
+
+// parseDurationUnits ParseDuration可识别的单位.
+var parseDurationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond, "nanosecond": time.Nanosecond, "nanoseconds": time.Nanosecond, "纳秒": time.Nanosecond,
+	"us": time.Microsecond, "µs": time.Microsecond, "μs": time.Microsecond, "microsecond": time.Microsecond, "microseconds": time.Microsecond, "微秒": time.Microsecond,
+	"ms": time.Millisecond, "millisecond": time.Millisecond, "milliseconds": time.Millisecond, "毫秒": time.Millisecond,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second, "秒": time.Second, "秒钟": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute, "分": time.Minute, "分钟": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour, "时": time.Hour, "小时": time.Hour, "钟头": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour, "天": 24 * time.Hour, "日": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"周": 7 * 24 * time.Hour, "星期": 7 * 24 * time.Hour, "礼拜": 7 * 24 * time.Hour,
+}
+
+// WithClock 创建使用时钟c的时间工具,便于测试时注入FakeClock;c为nil时使用系统时钟.
+func (kt *LkkTime) WithClock(c Clock) *LkkTime {
+	return &LkkTime{clock: c}
+}
+
+// Clock 获取当前使用的时钟.
+func (kt *LkkTime) Clock() Clock {
+	if kt.clock == nil {
+		return systemClock
+	}
+	return kt.clock
+}
+
+// Now 获取(时钟的)当前时间.
+func (kt *LkkTime) Now() time.Time {
+	return kt.Clock().Now()
+}
+
 // UnixTime 获取当前Unix时间戳(秒,10位).
 func (kt *LkkTime) UnixTime() int64 {
-	return time.Now().Unix()
+	return kt.Now().Unix()
 }
 
 // MilliTime 获取当前Unix时间戳(毫秒,13位).
 func (kt *LkkTime) MilliTime() int64 {
-	return time.Now().UnixNano() / int64(time.Millisecond)
+	return kt.Now().UnixNano() / int64(time.Millisecond)
 }
 
 // MicroTime 获取当前Unix时间戳(微秒,16位).
 func (kt *LkkTime) MicroTime() int64 {
-	return time.Now().UnixNano() / int64(time.Microsecond)
+	return kt.Now().UnixNano() / int64(time.Microsecond)
 }
 
 // Str2Timestruct 将字符串转换为时间结构.
@@ -74,7 +128,7 @@ func (kt *LkkTime) Str2Timestruct(str string, format ...string) (time.Time, erro
 	}
 
 	if len(str) != len(f) {
-		return time.Now(), errors.New("[Str2Timestruct]`format error")
+		return kt.Now(), errors.New("[Str2Timestruct]`format error")
 	}
 
 	return time.ParseInLocation(f, str, kuptime.Location())
@@ -95,10 +149,9 @@ func (kt *LkkTime) Str2Timestamp(str string, format ...string) (int64, error) {
 // Date 格式化时间.
 // format 格式,如"Y-m-d H:i:s".
 // ts为int/int64类型时间戳或time.Time类型.
+// 另支持农历占位符:{LY}农历年,{LYN}农历年中文,{LM}农历月,{LD}农历日,{GY}年干支,{GM}月干支,{GD}日干支,
+// {Z}生肖,{ST}节气,{FE}传统节日,如"Y-m-d {GY}年{LM}月{LD}".
 func (kt *LkkTime) Date(format string, ts ...interface{}) string {
-	replacer := strings.NewReplacer(datePatterns...)
-	format = replacer.Replace(format)
-
 	var t time.Time
 	if len(ts) > 0 {
 		val := ts[0]
@@ -112,10 +165,22 @@ func (kt *LkkTime) Date(format string, ts ...interface{}) string {
 			return ""
 		}
 	} else {
-		t = time.Now()
+		t = kt.Now()
 	}
 
-	return t.Format(format)
+	var lunar *strings.Replacer
+	if strings.ContainsRune(format, '{') {
+		format, lunar = lunarDatePlaceholder(format, t)
+	}
+
+	replacer := strings.NewReplacer(datePatterns...)
+	format = replacer.Replace(format)
+	res := t.Format(format)
+	if lunar != nil {
+		res = lunar.Replace(res)
+	}
+
+	return res
 }
 
 // CheckDate 检查是否正常的日期.
@@ -144,12 +209,12 @@ func (kt *LkkTime) CheckDate(year, month, day int) bool {
 
 // Sleep 延缓执行,秒.
 func (kt *LkkTime) Sleep(t int64) {
-	time.Sleep(time.Duration(t) * time.Second)
+	kt.Clock().Sleep(time.Duration(t) * time.Second)
 }
 
 // Usleep 以指定的微秒数延迟执行.
 func (kt *LkkTime) Usleep(t int64) {
-	time.Sleep(time.Duration(t) * time.Microsecond)
+	kt.Clock().Sleep(time.Duration(t) * time.Microsecond)
 }
 
 // ServiceStartime 获取当前服务启动时间戳,秒.
@@ -159,7 +224,7 @@ func (kt *LkkTime) ServiceStartime() int64 {
 
 // ServiceUptime 获取当前服务运行时间,纳秒int64.
 func (kt *LkkTime) ServiceUptime() time.Duration {
-	return time.Since(kuptime)
+	return kt.Clock().Since(kuptime)
 }
 
 // GetMonthDays 获取指定月份的天数.year年份,可选,默认当前年份.
@@ -170,7 +235,7 @@ func (kt *LkkTime) GetMonthDays(month int, year ...int) (days int) {
 
 	var yr int
 	if len(year) == 0 {
-		yr = time.Now().Year()
+		yr = kt.Now().Year()
 	} else {
 		yr = year[0]
 	}
@@ -198,7 +263,7 @@ func (kt *LkkTime) Year(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return tm.Year()
 }
@@ -209,7 +274,7 @@ func (kt *LkkTime) Month(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return int(tm.Month())
 }
@@ -220,7 +285,7 @@ func (kt *LkkTime) Day(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return tm.Day()
 }
@@ -231,7 +296,7 @@ func (kt *LkkTime) Hour(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return tm.Hour()
 }
@@ -242,7 +307,7 @@ func (kt *LkkTime) Minute(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return tm.Minute()
 }
@@ -253,7 +318,7 @@ func (kt *LkkTime) Second(t ...time.Time) int {
 	if len(t) > 0 {
 		tm = t[0]
 	} else {
-		tm = time.Now()
+		tm = kt.Now()
 	}
 	return tm.Second()
 }
@@ -355,10 +420,245 @@ func (kt *LkkTime) IsDate2time(str string) (bool, int64) {
 		str = str + reference[leng:19]
 	}
 
-	tim, err := KTime.Str2Timestamp(str)
+	tim, err := kt.Str2Timestamp(str)
 	if err != nil {
 		return false, 0
 	}
 
 	return true, tim
 }
+
+// FormatDuration 将时长格式化为易读的字符串,如"2d 4h 13m"、"2 days 4 hours"、"2天4小时13分钟".
+// precision为最多显示的单位个数(从最大的非零单位开始计算),0为不限制;style为格式风格.
+func (kt *LkkTime) FormatDuration(d time.Duration, precision uint8, style LkkDurationStyle) string {
+	names, ok := durationUnitNames[style]
+	if !ok {
+		names, style = durationUnitNames[DURATION_SHORT], DURATION_SHORT
+	}
+
+	var neg bool
+	var abs uint64
+	if d < 0 {
+		neg = true
+		abs = uint64(-(d + 1)) + 1
+	} else {
+		abs = uint64(d)
+	}
+
+	var parts []string
+	var count uint8
+	for i, unit := range durationUnits {
+		if precision > 0 && count >= precision {
+			break
+		}
+
+		n := abs / uint64(unit)
+		if n == 0 {
+			if count > 0 {
+				count++
+			}
+			continue
+		}
+		abs -= n * uint64(unit)
+		count++
+		parts = append(parts, formatDurationUnit(n, names[i], style))
+	}
+
+	if len(parts) == 0 {
+		return formatDurationUnit(0, names[3], style)
+	}
+
+	sep := " "
+	if style == DURATION_CHINESE {
+		sep = ""
+	}
+	res := strings.Join(parts, sep)
+	if neg {
+		res = "-" + res
+	}
+
+	return res
+}
+
+// formatDurationUnit 格式化单个时长单位.
+func formatDurationUnit(n uint64, name string, style LkkDurationStyle) string {
+	switch style {
+	case DURATION_LONG:
+		if n != 1 {
+			name += "s"
+		}
+		return strconv.FormatUint(n, 10) + " " + name
+	default:
+		return strconv.FormatUint(n, 10) + name
+	}
+}
+
+// HumanizeSince 获取时间t相对于now的人性化描述,如"3 minutes ago"、"in 2 days"、"刚刚"、"1小时前".
+// locale为语言区域,不足1分钟的过去时间显示为"just now"/"刚刚".
+func (kt *LkkTime) HumanizeSince(t, now time.Time, locale LkkLocale) string {
+	names, ok := humanizeUnitNames[locale]
+	if !ok {
+		names, locale = humanizeUnitNames[LOCALE_EN], LOCALE_EN
+	}
+
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+
+	if diff < time.Minute {
+		if locale == LOCALE_ZH_CN {
+			if future {
+				return "马上"
+			}
+			return "刚刚"
+		} else if future {
+			return "in a moment"
+		}
+		return "just now"
+	}
+
+	var n int64
+	var name string
+	for i, unit := range humanizeUnits {
+		if diff >= unit {
+			n = int64(diff / unit)
+			name = names[i]
+			break
+		}
+	}
+
+	if locale == LOCALE_ZH_CN {
+		if future {
+			return fmt.Sprintf("%d%s后", n, name)
+		}
+		return fmt.Sprintf("%d%s前", n, name)
+	}
+
+	if n != 1 {
+		name += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, name)
+	}
+	return fmt.Sprintf("%d %s ago", n, name)
+}
+
+// ParseDuration 解析时长字符串.除time.ParseDuration支持的格式外,
+// 还支持天、周及中文单位,单位与数字间可有空格,如"1d2h"、"1 week"、"1.5 hours"、"3天"、"2小时30分钟".
+func (kt *LkkTime) ParseDuration(str string) (time.Duration, error) {
+	str = strings.TrimSpace(str)
+	if res, err := time.ParseDuration(str); err == nil {
+		return res, nil
+	}
+
+	errInvalid := errors.New("[ParseDuration]`invalid duration: " + str)
+	runes := []rune(str)
+	length := len(runes)
+	if length == 0 {
+		return 0, errInvalid
+	}
+
+	var neg bool
+	pos := 0
+	if runes[0] == '-' || runes[0] == '+' {
+		neg = runes[0] == '-'
+		pos++
+	}
+
+	var total float64
+	var found bool
+	for pos < length {
+		//跳过空白和分隔符
+		for pos < length && (unicode.IsSpace(runes[pos]) || runes[pos] == ',' || runes[pos] == '，') {
+			pos++
+		}
+		if pos >= length {
+			break
+		}
+
+		//数值
+		start := pos
+		for pos < length && (unicode.IsDigit(runes[pos]) || runes[pos] == '.') {
+			pos++
+		}
+		num, err := strconv.ParseFloat(string(runes[start:pos]), 64)
+		if err != nil {
+			return 0, errInvalid
+		}
+		for pos < length && unicode.IsSpace(runes[pos]) {
+			pos++
+		}
+
+		//单位
+		start = pos
+		for pos < length && !unicode.IsDigit(runes[pos]) && !unicode.IsSpace(runes[pos]) && runes[pos] != ',' && runes[pos] != '，' {
+			pos++
+		}
+		name := strings.TrimPrefix(strings.ToLower(string(runes[start:pos])), "个")
+		unit, ok := parseDurationUnits[name]
+		if !ok {
+			return 0, errInvalid
+		}
+
+		total += num * float64(unit)
+		found = true
+	}
+
+	if !found || total >= math.MaxInt64 {
+		return 0, errInvalid
+	}
+
+	if neg {
+		total = -total
+	}
+
+	return time.Duration(total), nil
+}
+
+// Quarter 获取季度,1~4.
+func (kt *LkkTime) Quarter(t ...time.Time) int {
+	return (kt.Month(t...)-1)/3 + 1
+}
+
+// StartOfQuarter 获取日期中当季度的开始时间.
+func (kt *LkkTime) StartOfQuarter(date time.Time) time.Time {
+	month := time.Month((int(date.Month())-1)/3*3 + 1)
+	return time.Date(date.Year(), month, 1, 0, 0, 0, 0, date.Location())
+}
+
+// EndOfQuarter 获取日期中当季度的结束时间.
+func (kt *LkkTime) EndOfQuarter(date time.Time) time.Time {
+	return kt.StartOfQuarter(date).AddDate(0, 3, 0).Add(-time.Nanosecond)
+}
+
+// ISOWeek 获取ISO 8601标准的年份和周数.
+func (kt *LkkTime) ISOWeek(t ...time.Time) (year, week int) {
+	var tm time.Time
+	if len(t) > 0 {
+		tm = t[0]
+	} else {
+		tm = kt.Now()
+	}
+	return tm.ISOWeek()
+}
+
+// StartOfISOWeek 获取ISO年份year第week周的开始时间(周一零点),本地时区.
+func (kt *LkkTime) StartOfISOWeek(year, week int) time.Time {
+	//1月4日总在第1周
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.Local)
+	return kt.StartOfWeek(jan4).AddDate(0, 0, (week-1)*7)
+}
+
+// EndOfISOWeek 获取ISO年份year第week周的结束时间.
+func (kt *LkkTime) EndOfISOWeek(year, week int) time.Time {
+	return kt.StartOfISOWeek(year, week).AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// ISOWeeksInYear 获取ISO年份的总周数,52或53.
+func (kt *LkkTime) ISOWeeksInYear(year int) int {
+	//12月28日总在最后一周
+	_, week := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
@@ -489,3 +489,208 @@ func BenchmarkTime_IsDate2time(b *testing.B) {
 		_, _ = KTime.IsDate2time(strTime7)
 	}
 }
+
+func TestTime_Date_Lunar(t *testing.T) {
+	var res string
+	tim := time.Date(2024, 2, 10, 8, 0, 0, 0, time.Local)
+
+	res = KTime.Date("Y-m-d {LY} {GY}年{LM}月{LD}", tim)
+	assert.Equal(t, "2024-02-10 2024 甲辰年正月初一", res)
+
+	res = KTime.Date("{LYN}年 {GM}月{GD}日 {Z} {FE}", tim)
+	assert.Equal(t, "二〇二四年 丙寅月甲辰日 龙 春节", res)
+
+	res = KTime.Date("{ST}", time.Date(2024, 4, 4, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "清明", res)
+
+	//超出农历范围
+	res = KTime.Date("Y {LM}", time.Date(1800, 1, 1, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "1800 ", res)
+}
+
+func TestTime_FormatDuration(t *testing.T) {
+	var tests = []struct {
+		d         time.Duration
+		precision uint8
+		style     LkkDurationStyle
+		expected  string
+	}{
+		{0, 0, DURATION_SHORT, "0s"},
+		{0, 0, DURATION_LONG, "0 seconds"},
+		{0, 0, DURATION_CHINESE, "0秒"},
+		{52*time.Hour + 13*time.Minute, 0, DURATION_SHORT, "2d 4h 13m"},
+		{52*time.Hour + 13*time.Minute + 5*time.Second, 2, DURATION_SHORT, "2d 4h"},
+		{48*time.Hour + 13*time.Minute, 2, DURATION_SHORT, "2d"},
+		{25*time.Hour + time.Minute, 0, DURATION_LONG, "1 day 1 hour 1 minute"},
+		{52*time.Hour + 13*time.Minute, 0, DURATION_CHINESE, "2天4小时13分钟"},
+		{-90 * time.Second, 0, DURATION_SHORT, "-1m 30s"},
+		{1500 * time.Microsecond, 0, DURATION_SHORT, "1ms 500µs"},
+		{time.Hour, 0, LkkDurationStyle(9), "1h"},
+	}
+	for _, test := range tests {
+		actual := KTime.FormatDuration(test.d, test.precision, test.style)
+		assert.Equal(t, test.expected, actual)
+	}
+
+	//最小值不溢出
+	assert.NotEmpty(t, KTime.FormatDuration(time.Duration(INT64_MIN), 1, DURATION_SHORT))
+}
+
+func BenchmarkTime_FormatDuration(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.FormatDuration(52*time.Hour+13*time.Minute, 0, DURATION_SHORT)
+	}
+}
+
+func TestTime_HumanizeSince(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.Local)
+	var tests = []struct {
+		t        time.Time
+		locale   LkkLocale
+		expected string
+	}{
+		{now.Add(-10 * time.Second), LOCALE_EN, "just now"},
+		{now.Add(-10 * time.Second), LOCALE_ZH_CN, "刚刚"},
+		{now.Add(10 * time.Second), LOCALE_EN, "in a moment"},
+		{now.Add(10 * time.Second), LOCALE_ZH_CN, "马上"},
+		{now.Add(-3 * time.Minute), LOCALE_EN, "3 minutes ago"},
+		{now.Add(-1 * time.Hour), LOCALE_EN, "1 hour ago"},
+		{now.Add(-1 * time.Hour), LOCALE_ZH_CN, "1小时前"},
+		{now.Add(49 * time.Hour), LOCALE_EN, "in 2 days"},
+		{now.Add(49 * time.Hour), LOCALE_ZH_CN, "2天后"},
+		{now.Add(-15 * 24 * time.Hour), LOCALE_ZH_CN, "2周前"},
+		{now.Add(-62 * 24 * time.Hour), LOCALE_EN, "2 months ago"},
+		{now.Add(-400 * 24 * time.Hour), LOCALE_ZH_CN, "1年前"},
+		{now.Add(-3 * time.Minute), LkkLocale(9), "3 minutes ago"},
+	}
+	for _, test := range tests {
+		actual := KTime.HumanizeSince(test.t, now, test.locale)
+		assert.Equal(t, test.expected, actual)
+	}
+}
+
+func BenchmarkTime_HumanizeSince(b *testing.B) {
+	now := time.Now()
+	tim := now.Add(-3 * time.Minute)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.HumanizeSince(tim, now, LOCALE_ZH_CN)
+	}
+}
+
+func TestTime_ParseDuration(t *testing.T) {
+	var tests = []struct {
+		str      string
+		expected time.Duration
+		ok       bool
+	}{
+		{"1h30m", 90 * time.Minute, true},
+		{"1d2h", 26 * time.Hour, true},
+		{"1 week", 7 * 24 * time.Hour, true},
+		{"2 days, 3 hours", 51 * time.Hour, true},
+		{"1.5 hours", 90 * time.Minute, true},
+		{"-1d", -24 * time.Hour, true},
+		{"3天", 72 * time.Hour, true},
+		{"2小时30分钟", 150 * time.Minute, true},
+		{"1个星期", 7 * 24 * time.Hour, true},
+		{"10 秒", 10 * time.Second, true},
+		{"", 0, false},
+		{"hello", 0, false},
+		{"3 fortnights", 0, false},
+		{"100000000 weeks", 0, false},
+	}
+	for _, test := range tests {
+		actual, err := KTime.ParseDuration(test.str)
+		if test.ok {
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, actual)
+		} else {
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+func BenchmarkTime_ParseDuration(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KTime.ParseDuration("2 days, 3 hours")
+	}
+}
+
+func TestTime_Quarter(t *testing.T) {
+	assert.Equal(t, 1, KTime.Quarter(myDate1))
+	assert.Equal(t, 2, KTime.Quarter(myDate3))
+	assert.Greater(t, KTime.Quarter(), 0)
+
+	res := KTime.StartOfQuarter(myDate3)
+	assert.Equal(t, "2020-04-01 00:00:00", res.Format("2006-01-02 15:04:05"))
+
+	res = KTime.EndOfQuarter(myDate3)
+	assert.Equal(t, "2020-06-30 23:59:59.999999999", res.Format("2006-01-02 15:04:05.999999999"))
+}
+
+func BenchmarkTime_StartOfQuarter(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.StartOfQuarter(myDate1)
+	}
+}
+
+func TestTime_ISOWeek(t *testing.T) {
+	year, week := KTime.ISOWeek(time.Date(2021, 1, 3, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, 2020, year)
+	assert.Equal(t, 53, week)
+
+	year, week = KTime.ISOWeek()
+	assert.Greater(t, year, 0)
+	assert.Greater(t, week, 0)
+
+	res := KTime.StartOfISOWeek(2020, 53)
+	assert.Equal(t, "2020-12-28", res.Format("2006-01-02"))
+	res = KTime.StartOfISOWeek(2021, 1)
+	assert.Equal(t, "2021-01-04", res.Format("2006-01-02"))
+	res = KTime.EndOfISOWeek(2021, 1)
+	assert.Equal(t, "2021-01-10 23:59:59", res.Format("2006-01-02 15:04:05"))
+
+	assert.Equal(t, 53, KTime.ISOWeeksInYear(2020))
+	assert.Equal(t, 52, KTime.ISOWeeksInYear(2021))
+}
+
+func BenchmarkTime_StartOfISOWeek(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		KTime.StartOfISOWeek(2021, 1)
+	}
+}
+
+func TestTime_WithClock(t *testing.T) {
+	start := time.Date(2022, 2, 1, 8, 30, 0, 0, time.Local)
+	fc := NewFakeClock(start)
+	kt := KTime.WithClock(fc)
+
+	assert.Equal(t, fc, kt.Clock())
+	assert.Equal(t, start.Unix(), kt.UnixTime())
+	assert.Equal(t, start.UnixNano()/int64(time.Millisecond), kt.MilliTime())
+	assert.Equal(t, start.UnixNano()/int64(time.Microsecond), kt.MicroTime())
+	assert.Equal(t, "2022-02-01 08:30:00", kt.Date("Y-m-d H:i:s"))
+	assert.Equal(t, 2022, kt.Year())
+	assert.Equal(t, 28, kt.GetMonthDays(2))
+
+	fc.Set(kuptime.Add(time.Hour))
+	assert.Equal(t, time.Hour, kt.ServiceUptime())
+
+	done := make(chan struct{})
+	go func() {
+		kt.Sleep(2)
+		close(done)
+	}()
+	fc.BlockUntil(1)
+	fc.Advance(2 * time.Second)
+	<-done
+
+	//nil使用系统时钟
+	kt = KTime.WithClock(nil)
+	assert.Equal(t, systemClock, kt.Clock())
+	assert.Equal(t, systemClock, KTime.Clock())
+}
@@ -0,0 +1,866 @@
+package kgo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tomlParser TOML解析器
+type tomlParser struct {
+	data    string
+	pos     int
+	line    int
+	root    map[string]interface{}
+	cur     map[string]interface{}
+	defined map[uintptr]bool  //已用[table]定义的表
+	closed  map[uintptr]bool  //内联表及其子表,不能再扩展
+	arrays  map[tomlSlot]bool //用[[table]]定义的表数组,其他数组不能再扩展
+}
+
+// tomlSlot 表中的键
+type tomlSlot struct {
+	table uintptr
+	key   string
+}
+
+var (
+	// tomlDatetimeReg TOML日期时间
+	tomlDatetimeReg = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(?:[Tt ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:[Zz]|[+-]\d{2}:\d{2})?)?`)
+	// tomlTimeReg TOML本地时间
+	tomlTimeReg = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(?:\.\d+)?`)
+	// tomlIntReg TOML十进制整数
+	tomlIntReg = regexp.MustCompile(`^[+-]?(?:0|[1-9]\d*)$`)
+	// tomlFloatReg TOML浮点数
+	tomlFloatReg = regexp.MustCompile(`^[+-]?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+	// tomlBareKeyReg TOML裸键
+	tomlBareKeyReg = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// TomlDecode 对 TOML 格式的str字符串进行解码,注意res使用指针.
+// res为*map[string]interface{}或*interface{}时直接赋值,否则按"toml"标签使用 Map2Struct 解码.
+// 表解码为map[string]interface{},数组为[]interface{},整数为int64,浮点数为float64;
+// 带时区的日期时间为time.Time,本地日期时间和本地日期按本地时区转为time.Time,本地时间为字符串.
+func (ks *LkkString) TomlDecode(str []byte, res interface{}) error {
+	p := &tomlParser{
+		data: string(str), line: 1, root: make(map[string]interface{}),
+		defined: make(map[uintptr]bool), closed: make(map[uintptr]bool), arrays: make(map[tomlSlot]bool),
+	}
+	p.cur = p.root
+	if err := p.parse(); err != nil {
+		return err
+	}
+
+	switch r := res.(type) {
+	case *map[string]interface{}:
+		*r = p.root
+		return nil
+	case *interface{}:
+		*r = p.root
+		return nil
+	}
+	return KConv.Map2Struct(p.root, res, "toml")
+}
+
+// errorf 生成带行号的解析错误.
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("[TomlDecode]`line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+// eof 是否已到结尾.
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+// peek 获取当前字节,到结尾时为0.
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.data[p.pos]
+}
+
+// skipSpace 跳过空格和制表符.
+func (p *tomlParser) skipSpace() {
+	for c := p.peek(); c == ' ' || c == '\t'; c = p.peek() {
+		p.pos++
+	}
+}
+
+// skipComment 跳过注释,不包括换行符.
+func (p *tomlParser) skipComment() {
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+}
+
+// skipBlank 跳过空白、注释和换行符.
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if c := p.peek(); c == '\n' {
+			p.line++
+			p.pos++
+		} else if c == '\r' {
+			p.pos++
+		} else {
+			return
+		}
+	}
+}
+
+// endLine 当前行剩余部分只能是空白和注释.
+func (p *tomlParser) endLine() error {
+	p.skipSpace()
+	p.skipComment()
+	if strings.HasPrefix(p.data[p.pos:], "\r\n") {
+		p.pos++
+	}
+	if !p.eof() && p.peek() != '\n' {
+		return p.errorf("unexpected %q", p.peek())
+	}
+	return nil
+}
+
+// parse 解析整个文档.
+func (p *tomlParser) parse() error {
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		var err error
+		if strings.HasPrefix(p.data[p.pos:], "[[") {
+			p.pos += 2
+			err = p.parseArrayTable()
+		} else if p.peek() == '[' {
+			p.pos++
+			err = p.parseTable()
+		} else {
+			err = p.parseKeyValue(p.cur)
+		}
+		if err == nil {
+			err = p.endLine()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parseKeys 解析(带点的)键.
+func (p *tomlParser) parseKeys() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		var key string
+		switch c := p.peek(); c {
+		case '"', '\'':
+			str, err := p.parseString()
+			if err != nil {
+				return nil, err
+			}
+			key = str
+		default:
+			start := p.pos
+			for c := p.peek(); (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-'; c = p.peek() {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("invalid key")
+			}
+			key = p.data[start:p.pos]
+		}
+		keys = append(keys, key)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+// walkTable 从root开始按keys逐级进入(或创建)表;遇到表数组时进入其最后一个元素.
+// 内联表和非表数组的数组不能再扩展.
+func (p *tomlParser) walkTable(root map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	table := root
+	for _, key := range keys {
+		switch v := table[key].(type) {
+		case nil:
+			sub := make(map[string]interface{})
+			table[key] = sub
+			table = sub
+		case map[string]interface{}:
+			if p.closed[reflect.ValueOf(v).Pointer()] {
+				return nil, p.errorf("inline table %q cannot be extended", key)
+			}
+			table = v
+		case []interface{}:
+			if !p.arrays[tomlSlot{reflect.ValueOf(table).Pointer(), key}] {
+				return nil, p.errorf("array %q cannot be extended", key)
+			}
+			table = v[len(v)-1].(map[string]interface{})
+		default:
+			return nil, p.errorf("key %q is not a table", key)
+		}
+	}
+	return table, nil
+}
+
+// close 将内联表v及其中所有的子表标记为不可扩展.
+func (p *tomlParser) close(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		p.closed[reflect.ValueOf(v).Pointer()] = true
+		for _, item := range v {
+			p.close(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			p.close(item)
+		}
+	}
+}
+
+// parseTable 解析[table]表头.
+func (p *tomlParser) parseTable() error {
+	keys, err := p.parseKeys()
+	if err != nil {
+		return err
+	} else if p.peek() != ']' {
+		return p.errorf("expected ']'")
+	}
+	p.pos++
+
+	parent, err := p.walkTable(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	key := keys[len(keys)-1]
+	if _, ok := parent[key].([]interface{}); ok {
+		return p.errorf("key %q is an array", key)
+	}
+	table, err := p.walkTable(parent, keys[len(keys)-1:])
+	if err != nil {
+		return err
+	}
+
+	ptr := reflect.ValueOf(table).Pointer()
+	if p.defined[ptr] {
+		return p.errorf("table %q redefined", strings.Join(keys, "."))
+	}
+	p.defined[ptr] = true
+	p.cur = table
+	return nil
+}
+
+// parseArrayTable 解析[[table]]表头.
+func (p *tomlParser) parseArrayTable() error {
+	keys, err := p.parseKeys()
+	if err != nil {
+		return err
+	} else if !strings.HasPrefix(p.data[p.pos:], "]]") {
+		return p.errorf("expected ']]'")
+	}
+	p.pos += 2
+
+	parent, err := p.walkTable(p.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	slot := tomlSlot{reflect.ValueOf(parent).Pointer(), key}
+	table := make(map[string]interface{})
+	switch v := parent[key].(type) {
+	case nil:
+		parent[key] = []interface{}{table}
+		p.arrays[slot] = true
+	case []interface{}:
+		if !p.arrays[slot] {
+			return p.errorf("key %q is not an array of tables", key)
+		}
+		parent[key] = append(v, table)
+	default:
+		return p.errorf("key %q is not an array of tables", key)
+	}
+	p.cur = table
+	return nil
+}
+
+// parseKeyValue 解析 key = value 并写入table.
+func (p *tomlParser) parseKeyValue(table map[string]interface{}) error {
+	keys, err := p.parseKeys()
+	if err != nil {
+		return err
+	} else if p.peek() != '=' {
+		return p.errorf("expected '=' after key")
+	}
+	p.pos++
+	p.skipSpace()
+
+	val, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	table, err = p.walkTable(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	key := keys[len(keys)-1]
+	if _, ok := table[key]; ok {
+		return p.errorf("duplicate key %q", key)
+	}
+	table[key] = val
+	return nil
+}
+
+// parseValue 解析值.
+func (p *tomlParser) parseValue() (interface{}, error) {
+	rest := p.data[p.pos:]
+	switch c := p.peek(); {
+	case c == '"' || c == '\'':
+		return p.parseString()
+	case c == '[':
+		return p.parseArray()
+	case c == '{':
+		return p.parseInlineTable()
+	case strings.HasPrefix(rest, "true"):
+		p.pos += 4
+		return true, nil
+	case strings.HasPrefix(rest, "false"):
+		p.pos += 5
+		return false, nil
+	}
+
+	if m := tomlDatetimeReg.FindString(rest); m != "" {
+		p.pos += len(m)
+		return p.parseDatetime(m)
+	} else if m = tomlTimeReg.FindString(rest); m != "" {
+		p.pos += len(m)
+		return m, nil
+	}
+
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.peek()) < 0 {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, p.errorf("missing value")
+	}
+	return p.parseNumber(p.data[start:p.pos])
+}
+
+// parseDatetime 解析日期时间.
+func (p *tomlParser) parseDatetime(str string) (interface{}, error) {
+	str = strings.Replace(strings.ToUpper(str), " ", "T", 1)
+	var res time.Time
+	var err error
+	if strings.HasSuffix(str, "Z") || strings.LastIndexAny(str, "+-") > len("2006-01-02") {
+		res, err = time.Parse(time.RFC3339Nano, str)
+	} else if len(str) == len("2006-01-02") {
+		res, err = time.ParseInLocation("2006-01-02", str, kuptime.Location())
+	} else {
+		res, err = time.ParseInLocation("2006-01-02T15:04:05.999999999", str, kuptime.Location())
+	}
+	if err != nil {
+		return nil, p.errorf("invalid datetime %q", str)
+	}
+	return res, nil
+}
+
+// parseNumber 解析整数、浮点数、inf和nan.
+func (p *tomlParser) parseNumber(str string) (interface{}, error) {
+	body := str
+	if body[0] == '+' || body[0] == '-' {
+		body = body[1:]
+	}
+	switch body {
+	case "inf":
+		if str[0] == '-' {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case "nan":
+		return math.NaN(), nil
+	}
+
+	//下划线须在两个数字之间,十六进制数之外e不是数字
+	isDigit := func(c byte) bool {
+		return c >= '0' && c <= '9'
+	}
+	if strings.HasPrefix(body, "0x") {
+		isDigit = isHexDigit
+	}
+	for i := 0; i < len(str); i++ {
+		if str[i] == '_' && (i == 0 || i == len(str)-1 || !isDigit(str[i-1]) || !isDigit(str[i+1])) {
+			return nil, p.errorf("invalid number %q", str)
+		}
+	}
+	num := strings.ReplaceAll(str, "_", "")
+
+	if len(num) > 2 && num[0] == '0' {
+		base := 0
+		switch num[1] {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+		if base > 0 {
+			res, err := strconv.ParseInt(num[2:], base, 64)
+			if err != nil || num[2] == '+' || num[2] == '-' {
+				return nil, p.errorf("invalid number %q", str)
+			}
+			return res, nil
+		}
+	}
+
+	if tomlIntReg.MatchString(num) {
+		res, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, p.errorf("integer %q out of range", str)
+		}
+		return res, nil
+	} else if tomlFloatReg.MatchString(num) {
+		res, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return nil, p.errorf("float %q out of range", str)
+		}
+		return res, nil
+	}
+	return nil, p.errorf("invalid value %q", str)
+}
+
+// isHexDigit 是否十六进制数字.
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// parseString 解析基本字符串、字面量字符串及其多行形式.
+func (p *tomlParser) parseString() (string, error) {
+	quote := p.data[p.pos : p.pos+1]
+	multi := strings.HasPrefix(p.data[p.pos:], strings.Repeat(quote, 3))
+	if multi {
+		p.pos += 3
+		//紧跟开始引号的换行符被忽略
+		if strings.HasPrefix(p.data[p.pos:], "\r\n") {
+			p.pos += 2
+			p.line++
+		} else if p.peek() == '\n' {
+			p.pos++
+			p.line++
+		}
+	} else {
+		p.pos++
+	}
+
+	var buf strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated string")
+		}
+
+		c := p.data[p.pos]
+		switch {
+		case multi && strings.HasPrefix(p.data[p.pos:], strings.Repeat(quote, 3)):
+			p.pos += 3
+			//结束引号前最多可再有两个引号
+			for i := 0; i < 2 && p.peek() == quote[0]; i++ {
+				buf.WriteByte(quote[0])
+				p.pos++
+			}
+			return buf.String(), nil
+		case !multi && c == quote[0]:
+			p.pos++
+			return buf.String(), nil
+		case c == '\n':
+			if !multi {
+				return "", p.errorf("newline in string")
+			}
+			p.line++
+			buf.WriteByte(c)
+			p.pos++
+		case c == '\\' && quote == `"`:
+			if err := p.parseEscape(&buf, multi); err != nil {
+				return "", err
+			}
+		default:
+			buf.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// parseEscape 解析基本字符串中的转义序列.
+func (p *tomlParser) parseEscape(buf *strings.Builder, multi bool) error {
+	p.pos++
+	if p.eof() {
+		return p.errorf("unterminated string")
+	}
+
+	c := p.data[p.pos]
+	p.pos++
+	switch c {
+	case 'b':
+		buf.WriteByte('\b')
+	case 't':
+		buf.WriteByte('\t')
+	case 'n':
+		buf.WriteByte('\n')
+	case 'f':
+		buf.WriteByte('\f')
+	case 'r':
+		buf.WriteByte('\r')
+	case '"':
+		buf.WriteByte('"')
+	case '\\':
+		buf.WriteByte('\\')
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.data) {
+			return p.errorf("invalid unicode escape")
+		}
+		code, err := strconv.ParseUint(p.data[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid unicode escape")
+		}
+		buf.WriteRune(rune(code))
+		p.pos += size
+	default:
+		//多行字符串中行尾的反斜杠,将删除其后的空白和换行
+		if multi && (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+			p.pos--
+			start := p.pos
+			p.skipSpace()
+			if p.peek() != '\r' && p.peek() != '\n' {
+				p.pos = start
+				return p.errorf("invalid escape '\\%c'", c)
+			}
+			for c := p.peek(); c == ' ' || c == '\t' || c == '\r' || c == '\n'; c = p.peek() {
+				if c == '\n' {
+					p.line++
+				}
+				p.pos++
+			}
+			return nil
+		}
+		return p.errorf("invalid escape '\\%c'", c)
+	}
+	return nil
+}
+
+// parseArray 解析数组,可跨行,允许末尾的逗号.
+func (p *tomlParser) parseArray() ([]interface{}, error) {
+	p.pos++
+	res := make([]interface{}, 0)
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return res, nil
+		}
+
+		val, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, val)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return res, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// parseInlineTable 解析内联表.
+func (p *tomlParser) parseInlineTable() (map[string]interface{}, error) {
+	p.pos++
+	res := make(map[string]interface{})
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		p.close(res)
+		return res, nil
+	}
+
+	for {
+		p.skipSpace()
+		if err := p.parseKeyValue(res); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			p.close(res)
+			return res, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// TomlEncode 对val变量进行 TOML 编码,val须为键为字符串的字典或结构体(按"toml"标签转换).
+// 值为nil的键将被忽略;同级的键按名称排序,普通值在前,子表和表数组在后.
+func (ks *LkkString) TomlEncode(val interface{}) ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(val))
+	if v.Kind() == reflect.Struct {
+		m, err := struct2Map(v.Interface(), "toml", Struct2MapOptions{Recursive: true, keepTime: true})
+		if err != nil {
+			return nil, err
+		}
+		v = reflect.ValueOf(m)
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("[TomlEncode]`%T: %w", val, ErrUnsupportedType)
+	}
+
+	var buf bytes.Buffer
+	if err := tomlEncodeTable(&buf, nil, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tomlIndirect 解引用接口和指针.
+func tomlIndirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// tomlIsTable 值是否编码为子表.
+func tomlIsTable(v reflect.Value) bool {
+	return v.Kind() == reflect.Map || (v.Kind() == reflect.Struct && v.Type() != timeType)
+}
+
+// tomlIsArrayTable 值是否编码为表数组,即元素均为表的非空切片.
+func tomlIsArrayTable(v reflect.Value) bool {
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Len() == 0 {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if !tomlIsTable(tomlIndirect(v.Index(i))) {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlTable 将字典或结构体转为按键名排序的键和值.
+func tomlTable(v reflect.Value) ([]string, map[string]reflect.Value, error) {
+	if v.Kind() == reflect.Struct {
+		m, err := struct2MapValue(v, "toml", &Struct2MapOptions{Recursive: true, keepTime: true}, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		v = reflect.ValueOf(m)
+	} else if v.Type().Key().Kind() != reflect.String {
+		return nil, nil, fmt.Errorf("[TomlEncode]`%s: %w", v.Type(), ErrUnsupportedType)
+	}
+
+	keys := make([]string, 0, v.Len())
+	vals := make(map[string]reflect.Value, v.Len())
+	for _, k := range v.MapKeys() {
+		item := tomlIndirect(v.MapIndex(k))
+		if item.IsValid() && !((item.Kind() == reflect.Interface || item.Kind() == reflect.Ptr) && item.IsNil()) {
+			keys = append(keys, k.String())
+			vals[k.String()] = item
+		}
+	}
+	sort.Strings(keys)
+	return keys, vals, nil
+}
+
+// tomlEncodeTable 编码表,path为表的完整键路径.
+func tomlEncodeTable(buf *bytes.Buffer, path []string, v reflect.Value) error {
+	keys, vals, err := tomlTable(v)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if item := vals[k]; !tomlIsTable(item) && !tomlIsArrayTable(item) {
+			buf.WriteString(tomlKey(k) + " = ")
+			if err = tomlEncodeValue(buf, item); err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+		}
+	}
+
+	for _, k := range keys {
+		item := vals[k]
+		sub := append(append([]string{}, path...), k)
+		if tomlIsTable(item) {
+			buf.WriteString("\n[" + tomlPath(sub) + "]\n")
+			if err = tomlEncodeTable(buf, sub, item); err != nil {
+				return err
+			}
+		} else if tomlIsArrayTable(item) {
+			for i := 0; i < item.Len(); i++ {
+				buf.WriteString("\n[[" + tomlPath(sub) + "]]\n")
+				if err = tomlEncodeTable(buf, sub, tomlIndirect(item.Index(i))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// tomlEncodeValue 编码行内的值.
+func tomlEncodeValue(buf *bytes.Buffer, v reflect.Value) error {
+	v = tomlIndirect(v)
+	switch v.Kind() {
+	case reflect.String:
+		buf.WriteString(tomlQuote(v.String()))
+	case reflect.Bool:
+		buf.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Errorf("[TomlEncode]`%d: %w", v.Uint(), ErrNumberOverflow)
+		}
+		buf.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString(tomlFloat(v.Float()))
+	case reflect.Slice, reflect.Array:
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if err := tomlEncodeValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case reflect.Map, reflect.Struct:
+		if v.Type() == timeType {
+			buf.WriteString(v.Interface().(time.Time).Format(time.RFC3339Nano))
+			return nil
+		}
+
+		keys, vals, err := tomlTable(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(" " + tomlKey(k) + " = ")
+			if err = tomlEncodeValue(buf, vals[k]); err != nil {
+				return err
+			}
+		}
+		if len(keys) > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("[TomlEncode]`%s: %w", v.Kind(), ErrUnsupportedType)
+	}
+	return nil
+}
+
+// tomlFloat 格式化浮点数,保证带有小数点或指数.
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	res := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(res, ".eE") {
+		res += ".0"
+	}
+	return res
+}
+
+// tomlKey 格式化键,非裸键将加引号.
+func tomlKey(key string) string {
+	if tomlBareKeyReg.MatchString(key) {
+		return key
+	}
+	return tomlQuote(key)
+}
+
+// tomlPath 格式化表头的键路径.
+func tomlPath(keys []string) string {
+	res := make([]string, len(keys))
+	for i, k := range keys {
+		res[i] = tomlKey(k)
+	}
+	return strings.Join(res, ".")
+}
+
+// tomlQuote 将字符串转为基本字符串.
+func tomlQuote(str string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
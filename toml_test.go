@@ -0,0 +1,277 @@
+package kgo
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTomlDoc = `# 配置文件
+title = "TOML \"Example\"\u00e9"
+literal = 'C:\Users\kgo'
+multi = """
+Roses are red \
+    Violets are blue"""
+raw = '''
+first
+second'''
+int1 = +99
+int2 = 1_000
+hex = 0xDEAD_beef
+oct = 0o755
+bin = 0b1101
+flt = -3.14e-2
+inf = -inf
+enabled = true
+dob = 1979-05-27T07:32:00-08:00
+local = 1979-05-27 07:32:00
+day = 1979-05-27
+alarm = 07:32:00
+site."google.com" = true
+
+[owner]
+name = "Tom" # 行尾注释
+tags = [ "a", 'b',
+  "c", ] # 多行数组
+point = { x = 1, y = 2, z.w = 3 }
+
+[servers.alpha]
+ip = "10.0.0.1"
+
+[[products]]
+name = "Hammer"
+
+[[products]]
+name = "Nail"
+
+[products.dims]
+size = 1
+
+[[products.parts]]
+id = 1
+`
+
+func TestToml_TomlDecode(t *testing.T) {
+	var res map[string]interface{}
+	err := KStr.TomlDecode([]byte(testTomlDoc), &res)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "TOML \"Example\"é", res["title"])
+	assert.Equal(t, `C:\Users\kgo`, res["literal"])
+	assert.Equal(t, "Roses are red Violets are blue", res["multi"])
+	assert.Equal(t, "first\nsecond", res["raw"])
+	assert.Equal(t, int64(99), res["int1"])
+	assert.Equal(t, int64(1000), res["int2"])
+	assert.Equal(t, int64(0xdeadbeef), res["hex"])
+	assert.Equal(t, int64(0755), res["oct"])
+	assert.Equal(t, int64(13), res["bin"])
+	assert.Equal(t, -0.0314, res["flt"])
+	assert.True(t, math.IsInf(res["inf"].(float64), -1))
+	assert.Equal(t, true, res["enabled"])
+	assert.Equal(t, int64(296667120), res["dob"].(time.Time).Unix())
+	assert.Equal(t, "1979-05-27 07:32:00", res["local"].(time.Time).Format("2006-01-02 15:04:05"))
+	assert.Equal(t, "1979-05-27", res["day"].(time.Time).Format("2006-01-02"))
+	assert.Equal(t, "07:32:00", res["alarm"])
+	assert.Equal(t, map[string]interface{}{"google.com": true}, res["site"])
+
+	owner := res["owner"].(map[string]interface{})
+	assert.Equal(t, "Tom", owner["name"])
+	assert.Equal(t, []interface{}{"a", "b", "c"}, owner["tags"])
+	assert.Equal(t, map[string]interface{}{"x": int64(1), "y": int64(2), "z": map[string]interface{}{"w": int64(3)}}, owner["point"])
+	assert.Equal(t, "10.0.0.1", res["servers"].(map[string]interface{})["alpha"].(map[string]interface{})["ip"])
+
+	products := res["products"].([]interface{})
+	assert.Equal(t, 2, len(products))
+	nail := products[1].(map[string]interface{})
+	assert.Equal(t, "Nail", nail["name"])
+	assert.Equal(t, map[string]interface{}{"size": int64(1)}, nail["dims"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": int64(1)}}, nail["parts"])
+
+	//解码到结构体
+	type owner2 struct {
+		Name string   `toml:"name"`
+		Tags []string `toml:"tags"`
+	}
+	type config struct {
+		Title   string    `toml:"title"`
+		Int2    int       `toml:"int2"`
+		Dob     time.Time `toml:"dob"`
+		Owner   owner2    `toml:"owner"`
+		Enabled bool      `toml:"enabled"`
+	}
+	var conf config
+	err = KStr.TomlDecode([]byte(testTomlDoc), &conf)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, conf.Int2)
+	assert.Equal(t, []string{"a", "b", "c"}, conf.Owner.Tags)
+	assert.Equal(t, int64(296667120), conf.Dob.Unix())
+
+	var itf interface{}
+	err = KStr.TomlDecode([]byte("a = 1"), &itf)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"a": int64(1)}, itf)
+
+	//错误
+	bad := []string{
+		"a = 1\na = 2",
+		"[a]\n[a]",
+		"a = 1\n[a]",
+		"a = ",
+		"a = 01",
+		"a = 1__0",
+		"a = 0x_1",
+		"a = \"unterminated",
+		"a = \"bad \\q escape\"",
+		"a = [1, 2",
+		"a = { x = 1",
+		"a = 1 b = 2",
+		"= 1",
+		"[a",
+		"[[a]",
+		"a = 99999999999999999999",
+		"a = 1979-13-45",
+		"g = --inf",
+		"g = +-1",
+		"f = 1e_5",
+		"f = 1_e5",
+		"f = 1._5",
+		"a = {x=1}\na.y = 2",
+		"a = {x=1}\n[a]",
+		"a = {x={y=1}}\n[a.x]",
+		"a = {b.c=1}\n[a.b]",
+		"[[a]]\n[a]",
+		"a = [{x=1}]\n[[a]]",
+		"a = [{x=1}]\n[a.b]",
+	}
+	for _, doc := range bad {
+		err = KStr.TomlDecode([]byte(doc), &res)
+		assert.NotNil(t, err, doc)
+	}
+	//合法的边界情况
+	err = KStr.TomlDecode([]byte("a = -inf\nb = 0xdead_beef\nc = 1_000.5e1_0\n[[t]]\nx = 1\n[t.sub]\ny = 2\n[[t]]\nx = 2"), &res)
+	assert.Nil(t, err)
+	assert.True(t, math.IsInf(res["a"].(float64), -1))
+	assert.Equal(t, int64(0xdeadbeef), res["b"])
+	assert.Equal(t, 1000.5e10, res["c"])
+	assert.Equal(t, 2, len(res["t"].([]interface{})))
+
+	err = KStr.TomlDecode([]byte("x = 1\n\ny = ]"), &res)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func BenchmarkToml_TomlDecode(b *testing.B) {
+	var res map[string]interface{}
+	data := []byte(testTomlDoc)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = KStr.TomlDecode(data, &res)
+	}
+}
+
+func TestToml_TomlEncode(t *testing.T) {
+	tim := time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC)
+	data := map[string]interface{}{
+		"title":   "a \"quoted\"\ttitle",
+		"count":   int64(3),
+		"ratio":   2.0,
+		"enabled": true,
+		"dob":     tim,
+		"nothing": nil,
+		"list":    []interface{}{1, "two", []int{3}, map[string]interface{}{"k": "v"}},
+		"a.b":     1,
+		"owner":   map[string]interface{}{"name": "Tom", "sub": map[string]int{"x": 1}},
+		"items":   []map[string]interface{}{{"id": 1}, {"id": 2}},
+	}
+	res, err := KStr.TomlEncode(data)
+	assert.Nil(t, err)
+	assert.Equal(t, `"a.b" = 1
+count = 3
+dob = 1979-05-27T07:32:00Z
+enabled = true
+list = [1, "two", [3], { k = "v" }]
+ratio = 2.0
+title = "a \"quoted\"\ttitle"
+
+[[items]]
+id = 1
+
+[[items]]
+id = 2
+
+[owner]
+name = "Tom"
+
+[owner.sub]
+x = 1
+`, string(res))
+
+	//往返
+	var back map[string]interface{}
+	err = KStr.TomlDecode(res, &back)
+	assert.Nil(t, err)
+	assert.Equal(t, "a \"quoted\"\ttitle", back["title"])
+	assert.Equal(t, 2.0, back["ratio"])
+	assert.True(t, tim.Equal(back["dob"].(time.Time)))
+	assert.Equal(t, int64(2), back["items"].([]interface{})[1].(map[string]interface{})["id"])
+
+	//结构体
+	type server struct {
+		Host string `toml:"host"`
+		Port int    `toml:"port"`
+	}
+	type config struct {
+		Name    string   `toml:"name"`
+		Servers []server `toml:"servers"`
+		Skip    string   `toml:"-"`
+	}
+	res, err = KStr.TomlEncode(&config{Name: "kgo", Servers: []server{{"a", 80}}, Skip: "x"})
+	assert.Nil(t, err)
+	assert.Equal(t, "name = \"kgo\"\n\n[[servers]]\nhost = \"a\"\nport = 80\n", string(res))
+
+	var conf config
+	err = KStr.TomlDecode(res, &conf)
+	assert.Nil(t, err)
+	assert.Equal(t, 80, conf.Servers[0].Port)
+
+	//结构体的时间字段编码为TOML日期时间
+	type event struct {
+		At    time.Time            `toml:"at"`
+		Ptr   *time.Time           `toml:"ptr"`
+		Times map[string]time.Time `toml:"times"`
+	}
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	res, err = KStr.TomlEncode(event{At: at, Ptr: &at, Times: map[string]time.Time{"x": at}})
+	assert.Nil(t, err)
+	assert.Equal(t, "at = 2020-01-02T03:04:05Z\nptr = 2020-01-02T03:04:05Z\n\n[times]\nx = 2020-01-02T03:04:05Z\n", string(res))
+
+	back = nil
+	err = KStr.TomlDecode(res, &back)
+	assert.Nil(t, err)
+	assert.True(t, at.Equal(back["at"].(time.Time)))
+	var ev event
+	err = KStr.TomlDecode(res, &ev)
+	assert.Nil(t, err)
+	assert.True(t, at.Equal(ev.At))
+	assert.True(t, at.Equal(ev.Times["x"]))
+
+	res, err = KStr.TomlEncode(map[string]interface{}{"f": math.Inf(1), "n": math.NaN(), "e": 1e21})
+	assert.Nil(t, err)
+	assert.Equal(t, "e = 1e+21\nf = inf\nn = nan\n", string(res))
+
+	_, err = KStr.TomlEncode(1)
+	assert.NotNil(t, err)
+	_, err = KStr.TomlEncode(map[string]interface{}{"u": uint64(math.MaxUint64)})
+	assert.NotNil(t, err)
+	_, err = KStr.TomlEncode(map[string]interface{}{"c": make(chan int)})
+	assert.NotNil(t, err)
+}
+
+func BenchmarkToml_TomlEncode(b *testing.B) {
+	data := map[string]interface{}{"title": "kgo", "owner": map[string]interface{}{"name": "Tom"}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KStr.TomlEncode(data)
+	}
+}
@@ -0,0 +1,221 @@
+package kgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Unit 计量单位,基准值 = 数值*Factor + Offset .
+type Unit struct {
+	Symbol    string           `json:"symbol"`    //符号,如kg
+	Name      string           `json:"name"`      //名称
+	Dimension LkkUnitDimension `json:"dimension"` //量纲
+	Factor    float64          `json:"factor"`    //换算为基准单位的系数
+	Offset    float64          `json:"offset"`    //换算为基准单位的偏移量,仅温度等非比例单位使用
+}
+
+// Quantity 带单位的数值.
+type Quantity struct {
+	Value float64 `json:"value"` //数值
+	Unit  string  `json:"unit"`  //单位符号
+}
+
+// UnitRegistry 计量单位注册表,并发安全.
+type UnitRegistry struct {
+	mu    sync.RWMutex
+	units map[string]*Unit
+}
+
+var (
+	// ErrUnknownUnit 未知的计量单位
+	ErrUnknownUnit = errors.New("[Unit]`unknown unit")
+	// ErrDimensionMismatch 量纲不一致,无法换算
+	ErrDimensionMismatch = errors.New("[Unit]`dimension mismatch")
+
+	// quantityRegex 带单位数值的正则,如"12.5 kg"
+	quantityRegex = regexp.MustCompile(`^\s*([+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?)\s*(\S.*?)\s*$`)
+
+	// defaultUnitRegistry 默认的计量单位注册表
+	defaultUnitRegistry = NewUnitRegistry()
+)
+
+// builtinUnits 内置的计量单位,第一个为符号,其余为别名
+var builtinUnits = []struct {
+	unit    Unit
+	aliases []string
+}{
+	//长度
+	{Unit{"m", "米", DIMENSION_LENGTH, 1, 0}, []string{"meter", "metre", "米"}},
+	{Unit{"km", "千米", DIMENSION_LENGTH, 1000, 0}, []string{"kilometer", "kilometre", "千米", "公里"}},
+	{Unit{"dm", "分米", DIMENSION_LENGTH, 0.1, 0}, []string{"分米"}},
+	{Unit{"cm", "厘米", DIMENSION_LENGTH, 0.01, 0}, []string{"centimeter", "centimetre", "厘米"}},
+	{Unit{"mm", "毫米", DIMENSION_LENGTH, 0.001, 0}, []string{"millimeter", "millimetre", "毫米"}},
+	{Unit{"um", "微米", DIMENSION_LENGTH, 1e-6, 0}, []string{"μm", "micrometer", "微米"}},
+	{Unit{"nm", "纳米", DIMENSION_LENGTH, 1e-9, 0}, []string{"nanometer", "纳米"}},
+	{Unit{"in", "英寸", DIMENSION_LENGTH, 0.0254, 0}, []string{"inch", "英寸"}},
+	{Unit{"ft", "英尺", DIMENSION_LENGTH, 0.3048, 0}, []string{"foot", "feet", "英尺"}},
+	{Unit{"yd", "码", DIMENSION_LENGTH, 0.9144, 0}, []string{"yard", "码"}},
+	{Unit{"mi", "英里", DIMENSION_LENGTH, 1609.344, 0}, []string{"mile", "英里"}},
+	{Unit{"nmi", "海里", DIMENSION_LENGTH, 1852, 0}, []string{"海里"}},
+	{Unit{"里", "里", DIMENSION_LENGTH, 500, 0}, []string{"市里"}},
+	{Unit{"丈", "丈", DIMENSION_LENGTH, 10.0 / 3, 0}, nil},
+	{Unit{"尺", "尺", DIMENSION_LENGTH, 1.0 / 3, 0}, []string{"市尺"}},
+	{Unit{"寸", "寸", DIMENSION_LENGTH, 1.0 / 30, 0}, []string{"市寸"}},
+
+	//质量
+	{Unit{"kg", "千克", DIMENSION_MASS, 1, 0}, []string{"kilogram", "千克", "公斤"}},
+	{Unit{"g", "克", DIMENSION_MASS, 0.001, 0}, []string{"gram", "克"}},
+	{Unit{"mg", "毫克", DIMENSION_MASS, 1e-6, 0}, []string{"milligram", "毫克"}},
+	{Unit{"t", "吨", DIMENSION_MASS, 1000, 0}, []string{"tonne", "吨"}},
+	{Unit{"lb", "磅", DIMENSION_MASS, 0.45359237, 0}, []string{"lbs", "pound", "磅"}},
+	{Unit{"oz", "盎司", DIMENSION_MASS, 0.028349523125, 0}, []string{"ounce", "盎司"}},
+	{Unit{"斤", "斤", DIMENSION_MASS, 0.5, 0}, []string{"市斤"}},
+	{Unit{"两", "两", DIMENSION_MASS, 0.05, 0}, []string{"市两"}},
+	{Unit{"钱", "钱", DIMENSION_MASS, 0.005, 0}, nil},
+
+	//温度
+	{Unit{"K", "开尔文", DIMENSION_TEMPERATURE, 1, 0}, []string{"kelvin", "开尔文"}},
+	{Unit{"℃", "摄氏度", DIMENSION_TEMPERATURE, 1, 273.15}, []string{"°C", "C", "celsius", "摄氏度"}},
+	{Unit{"℉", "华氏度", DIMENSION_TEMPERATURE, 5.0 / 9, 273.15 - 32*5.0/9}, []string{"°F", "F", "fahrenheit", "华氏度"}},
+
+	//面积
+	{Unit{"m²", "平方米", DIMENSION_AREA, 1, 0}, []string{"m2", "sqm", "平方米"}},
+	{Unit{"km²", "平方千米", DIMENSION_AREA, 1e6, 0}, []string{"km2", "平方千米", "平方公里"}},
+	{Unit{"cm²", "平方厘米", DIMENSION_AREA, 1e-4, 0}, []string{"cm2", "平方厘米"}},
+	{Unit{"ha", "公顷", DIMENSION_AREA, 1e4, 0}, []string{"hectare", "公顷"}},
+	{Unit{"acre", "英亩", DIMENSION_AREA, 4046.8564224, 0}, []string{"ac", "英亩"}},
+	{Unit{"ft²", "平方英尺", DIMENSION_AREA, 0.09290304, 0}, []string{"ft2", "sqft", "平方英尺"}},
+	{Unit{"mi²", "平方英里", DIMENSION_AREA, 2589988.110336, 0}, []string{"mi2", "平方英里"}},
+	{Unit{"亩", "亩", DIMENSION_AREA, 10000.0 / 15, 0}, []string{"市亩"}},
+
+	//数据传输速率
+	{Unit{"bps", "比特每秒", DIMENSION_DATA_RATE, 1, 0}, []string{"bit/s", "b/s"}},
+	{Unit{"kbps", "千比特每秒", DIMENSION_DATA_RATE, 1e3, 0}, []string{"Kbps", "kbit/s", "kb/s"}},
+	{Unit{"Mbps", "兆比特每秒", DIMENSION_DATA_RATE, 1e6, 0}, []string{"Mbit/s", "Mb/s"}},
+	{Unit{"Gbps", "吉比特每秒", DIMENSION_DATA_RATE, 1e9, 0}, []string{"Gbit/s", "Gb/s"}},
+	{Unit{"Tbps", "太比特每秒", DIMENSION_DATA_RATE, 1e12, 0}, []string{"Tbit/s", "Tb/s"}},
+	{Unit{"B/s", "字节每秒", DIMENSION_DATA_RATE, 8, 0}, []string{"Bps"}},
+	{Unit{"kB/s", "千字节每秒", DIMENSION_DATA_RATE, 8e3, 0}, nil},
+	{Unit{"MB/s", "兆字节每秒", DIMENSION_DATA_RATE, 8e6, 0}, nil},
+	{Unit{"GB/s", "吉字节每秒", DIMENSION_DATA_RATE, 8e9, 0}, nil},
+	{Unit{"KiB/s", "KiB每秒", DIMENSION_DATA_RATE, 8 * 1024, 0}, nil},
+	{Unit{"MiB/s", "MiB每秒", DIMENSION_DATA_RATE, 8 * 1024 * 1024, 0}, nil},
+	{Unit{"GiB/s", "GiB每秒", DIMENSION_DATA_RATE, 8 * 1024 * 1024 * 1024, 0}, nil},
+}
+
+// NewUnitRegistry 创建计量单位注册表,已包含内置的公制、英制及中国市制单位.
+func NewUnitRegistry() *UnitRegistry {
+	ur := &UnitRegistry{units: make(map[string]*Unit)}
+	for _, v := range builtinUnits {
+		if err := ur.Register(v.unit, v.aliases...); err != nil {
+			panic(err)
+		}
+	}
+	return ur
+}
+
+// Register 注册计量单位,aliases为可选的别名;符号或别名已被其他单位占用时返回错误.
+func (ur *UnitRegistry) Register(unit Unit, aliases ...string) error {
+	if unit.Symbol == "" {
+		return errors.New("[UnitRegistry]`unit symbol is empty")
+	} else if unit.Factor == 0 || math.IsNaN(unit.Factor) || math.IsInf(unit.Factor, 0) || math.IsNaN(unit.Offset) || math.IsInf(unit.Offset, 0) {
+		return fmt.Errorf("[UnitRegistry]`invalid factor or offset of unit %q", unit.Symbol)
+	}
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+
+	names := append([]string{unit.Symbol}, aliases...)
+	for _, name := range names {
+		if exist, ok := ur.units[name]; ok && *exist != unit {
+			return fmt.Errorf("[UnitRegistry]`unit %q already registered", name)
+		}
+	}
+
+	u := unit
+	for _, name := range names {
+		if name != "" {
+			ur.units[name] = &u
+		}
+	}
+
+	return nil
+}
+
+// Lookup 按符号或别名查找计量单位,未找到时返回 ErrUnknownUnit .
+func (ur *UnitRegistry) Lookup(symbol string) (Unit, error) {
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+
+	if u, ok := ur.units[strings.TrimSpace(symbol)]; ok {
+		return *u, nil
+	}
+	return Unit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, symbol)
+}
+
+// Convert 将数值value从单位from换算为单位to;量纲不一致时返回 ErrDimensionMismatch .
+func (ur *UnitRegistry) Convert(value float64, from, to string) (float64, error) {
+	fu, err := ur.Lookup(from)
+	if err != nil {
+		return 0, err
+	}
+	tu, err := ur.Lookup(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if fu.Dimension != tu.Dimension {
+		return 0, fmt.Errorf("%w: %s to %s", ErrDimensionMismatch, fu.Symbol, tu.Symbol)
+	} else if fu == tu {
+		return value, nil
+	}
+
+	base := value*fu.Factor + fu.Offset
+	return (base - tu.Offset) / tu.Factor, nil
+}
+
+// Parse 解析带单位的数值字符串,如"12.5 kg"、"-3℃"、"1,024 Mbps";返回的单位为规范符号.
+func (ur *UnitRegistry) Parse(str string) (Quantity, error) {
+	match := quantityRegex.FindStringSubmatch(strings.ReplaceAll(str, ",", ""))
+	if match == nil {
+		return Quantity{}, fmt.Errorf("[UnitRegistry]`invalid quantity: %q", str)
+	}
+
+	val, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return Quantity{}, fmt.Errorf("[UnitRegistry]`invalid quantity: %q", str)
+	}
+
+	u, err := ur.Lookup(match[2])
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	return Quantity{Value: val, Unit: u.Symbol}, nil
+}
+
+// RegisterUnit 向默认注册表注册计量单位,aliases为可选的别名.
+func (kn *LkkNumber) RegisterUnit(unit Unit, aliases ...string) error {
+	return defaultUnitRegistry.Register(unit, aliases...)
+}
+
+// LookupUnit 在默认注册表中按符号或别名查找计量单位.
+func (kn *LkkNumber) LookupUnit(symbol string) (Unit, error) {
+	return defaultUnitRegistry.Lookup(symbol)
+}
+
+// ConvertUnit 单位换算,将数值value从单位from换算为单位to,如ConvertUnit(1, "斤", "kg")为0.5.
+// 单位未知时返回 ErrUnknownUnit ,量纲不一致时返回 ErrDimensionMismatch ,可用errors.Is判断.
+func (kn *LkkNumber) ConvertUnit(value float64, from, to string) (float64, error) {
+	return defaultUnitRegistry.Convert(value, from, to)
+}
+
+// ParseQuantity 解析带单位的数值字符串,如"12.5 kg".
+func (kn *LkkNumber) ParseQuantity(str string) (Quantity, error) {
+	return defaultUnitRegistry.Parse(str)
+}
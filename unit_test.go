@@ -0,0 +1,152 @@
+package kgo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ConvertUnit(t *testing.T) {
+	var res float64
+	var err error
+
+	tests := []struct {
+		value    float64
+		from     string
+		to       string
+		expected float64
+	}{
+		{1, "km", "m", 1000},
+		{1, "mi", "km", 1.609344},
+		{12, "in", "ft", 1},
+		{1, "里", "m", 500},
+		{3, "尺", "m", 1},
+		{1, "公里", "里", 2},
+		{1, "斤", "kg", 0.5},
+		{1, "kg", "斤", 2},
+		{1, "斤", "两", 10},
+		{1, "lb", "g", 453.59237},
+		{16, "oz", "lb", 1},
+		{100, "℃", "℉", 212},
+		{-40, "°C", "°F", -40},
+		{0, "K", "C", -273.15},
+		{32, "F", "K", 273.15},
+		{1, "ha", "亩", 15},
+		{1, "亩", "m2", 10000.0 / 15},
+		{1, "km²", "ha", 100},
+		{1, "acre", "ft²", 43560},
+		{1, "MB/s", "Mbps", 8},
+		{1, "Gbps", "kbps", 1e6},
+		{1, "MiB/s", "bps", 8388608},
+		{5, "kg", "kilogram", 5},
+	}
+	for _, test := range tests {
+		res, err = KNum.ConvertUnit(test.value, test.from, test.to)
+		assert.Nil(t, err)
+		assert.InDelta(t, test.expected, res, 1e-9*(1+math.Abs(test.expected)), test.from+"->"+test.to)
+	}
+
+	_, err = KNum.ConvertUnit(1, "kg", "m")
+	assert.True(t, errors.Is(err, ErrDimensionMismatch))
+	_, err = KNum.ConvertUnit(1, "℃", "m²")
+	assert.True(t, errors.Is(err, ErrDimensionMismatch))
+	_, err = KNum.ConvertUnit(1, "furlong", "m")
+	assert.True(t, errors.Is(err, ErrUnknownUnit))
+	_, err = KNum.ConvertUnit(1, "m", "furlong")
+	assert.True(t, errors.Is(err, ErrUnknownUnit))
+}
+
+func BenchmarkUnit_ConvertUnit(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.ConvertUnit(float64(i), "斤", "lb")
+	}
+}
+
+func TestUnit_ParseQuantity(t *testing.T) {
+	var res Quantity
+	var err error
+
+	tests := []struct {
+		str      string
+		expected Quantity
+	}{
+		{"12.5 kg", Quantity{12.5, "kg"}},
+		{"12.5kg", Quantity{12.5, "kg"}},
+		{" -3 °C ", Quantity{-3, "℃"}},
+		{"1,024 Mbps", Quantity{1024, "Mbps"}},
+		{".5 亩", Quantity{0.5, "亩"}},
+		{"1e3 m", Quantity{1000, "m"}},
+		{"3 公斤", Quantity{3, "kg"}},
+		{"2 平方公里", Quantity{2, "km²"}},
+	}
+	for _, test := range tests {
+		res, err = KNum.ParseQuantity(test.str)
+		assert.Nil(t, err, test.str)
+		assert.Equal(t, test.expected, res, test.str)
+	}
+
+	_, err = KNum.ParseQuantity("kg")
+	assert.NotNil(t, err)
+	_, err = KNum.ParseQuantity("12.5")
+	assert.NotNil(t, err)
+	_, err = KNum.ParseQuantity("12.5 furlong")
+	assert.True(t, errors.Is(err, ErrUnknownUnit))
+}
+
+func BenchmarkUnit_ParseQuantity(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.ParseQuantity("12.5 kg")
+	}
+}
+
+func TestUnit_Registry(t *testing.T) {
+	var res float64
+	var u Unit
+	var err error
+
+	ur := NewUnitRegistry()
+	err = ur.Register(Unit{"fur", "弗隆", DIMENSION_LENGTH, 201.168, 0}, "furlong")
+	assert.Nil(t, err)
+
+	res, err = ur.Convert(1, "furlong", "m")
+	assert.Nil(t, err)
+	assert.InDelta(t, 201.168, res, 1e-9)
+
+	u, err = ur.Lookup("fur")
+	assert.Nil(t, err)
+	assert.Equal(t, "弗隆", u.Name)
+
+	//不影响默认注册表
+	_, err = KNum.LookupUnit("furlong")
+	assert.True(t, errors.Is(err, ErrUnknownUnit))
+
+	//重复注册相同单位允许,冲突时报错
+	err = ur.Register(Unit{"fur", "弗隆", DIMENSION_LENGTH, 201.168, 0})
+	assert.Nil(t, err)
+	err = ur.Register(Unit{"kg", "千克", DIMENSION_MASS, 2, 0})
+	assert.NotNil(t, err)
+	err = ur.Register(Unit{"x", "x", DIMENSION_MASS, 2, 0}, "g")
+	assert.NotNil(t, err)
+	err = ur.Register(Unit{"", "", DIMENSION_MASS, 1, 0})
+	assert.NotNil(t, err)
+	err = ur.Register(Unit{"y", "y", DIMENSION_MASS, 0, 0})
+	assert.NotNil(t, err)
+
+	//默认注册表
+	err = KNum.RegisterUnit(Unit{"担", "担", DIMENSION_MASS, 50, 0}, "市担")
+	assert.Nil(t, err)
+	res, err = KNum.ConvertUnit(1, "市担", "斤")
+	assert.Nil(t, err)
+	assert.InDelta(t, 100, res, 1e-9)
+}
+
+func BenchmarkUnit_Registry(b *testing.B) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KNum.LookupUnit("kg")
+	}
+}